@@ -0,0 +1,384 @@
+// This file contains the support for the /apidiff page, which shows
+// the changes of the exported API of a package between two package
+// trees, such as a module at two versions in the module cache.
+//
+// The exported declarations of the package documentation of both
+// trees are compared syntactically: types are compared as written,
+// and so are the values of constants; parameter and receiver names
+// are ignored. Each change is classified as compatible if it doesn't
+// break clients of the package, following the Go 1 compatibility
+// rules: adding declarations, methods and struct fields is compatible,
+// removing or changing them is not. Adding a method to an interface
+// is compatible only if the interface can't be implemented outside
+// the package (it has unexported methods).
+
+package godoc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/printer"
+	"go/token"
+	"net/http"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/miclle/godoc/vfs"
+)
+
+// An APIChange describes a change of an exported declaration.
+type APIChange struct {
+	Name       string // "Name", or "Type.Name" for methods and fields
+	Kind       string // "const", "var", "func", "type", "method", "field" or "interface method"
+	Old, New   string // old and new declaration; "" if added or removed
+	Compatible bool   // if set, the change doesn't break clients of the package
+}
+
+// An APIDiff describes the changes of the exported API of a package
+// between two package trees.
+type APIDiff struct {
+	Old, New string // roots of the old and new package trees
+	Pkg      string // slash-separated path of the package directory in both trees
+
+	Removed []APIChange
+	Changed []APIChange
+	Added   []APIChange
+}
+
+// Compatible reports whether all changes of d are compatible.
+func (d *APIDiff) Compatible() bool {
+	for _, list := range [][]APIChange{d.Removed, d.Changed, d.Added} {
+		for _, c := range list {
+			if !c.Compatible {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// An apiFeature is an exported declaration of a package.
+type apiFeature struct {
+	kind   string
+	decl   string // declaration, as shown
+	key    string // normalized declaration, as compared
+	sealed bool   // for interfaces: it has unexported methods
+}
+
+// apiFeatures returns the exported declarations of the documented
+// package pkg, by the names of APIChange.
+func apiFeatures(fset *token.FileSet, pkg *doc.Package) map[string]*apiFeature {
+	features := make(map[string]*apiFeature)
+	add := func(name, kind, decl, key string) *apiFeature {
+		f := &apiFeature{kind: kind, decl: decl, key: key}
+		features[name] = f
+		return f
+	}
+	values := func(list []*doc.Value) {
+		for _, v := range list {
+			kind := v.Decl.Tok.String()
+			var typ ast.Expr // type of implicitly repeated constant specs
+			for _, spec := range v.Decl.Specs {
+				s := spec.(*ast.ValueSpec)
+				if s.Type != nil || len(s.Values) > 0 {
+					typ = s.Type
+				}
+				for i, name := range s.Names {
+					decl := kind + " " + name.Name
+					if typ != nil {
+						decl += " " + formatAPINode(fset, typ)
+					}
+					if kind == "const" && i < len(s.Values) {
+						decl += " = " + formatAPINode(fset, s.Values[i])
+					}
+					add(name.Name, kind, decl, decl)
+				}
+			}
+		}
+	}
+	funcs := func(list []*doc.Func, recv string) {
+		for _, f := range list {
+			name, kind := f.Name, "func"
+			if recv != "" && f.Decl.Recv != nil {
+				name, kind = recv+"."+f.Name, "method"
+			}
+			key := &ast.FuncDecl{
+				Recv: unnamedFields(f.Decl.Recv),
+				Name: f.Decl.Name,
+				Type: unnamedFuncType(f.Decl.Type),
+			}
+			add(name, kind, formatAPINode(fset, f.Decl), formatAPINode(fset, key))
+		}
+	}
+
+	values(pkg.Consts)
+	values(pkg.Vars)
+	funcs(pkg.Funcs, "")
+	for _, t := range pkg.Types {
+		values(t.Consts)
+		values(t.Vars)
+		funcs(t.Funcs, "")
+		funcs(t.Methods, t.Name)
+		for _, spec := range t.Decl.Specs {
+			s, ok := spec.(*ast.TypeSpec)
+			if !ok || s.Name.Name != t.Name {
+				continue
+			}
+			switch typ := s.Type.(type) {
+			case *ast.StructType:
+				decl := formatTypeHeader(fset, s, "struct")
+				add(t.Name, "type", decl, decl)
+				for _, m := range typeMembers(s) {
+					ftyp := formatAPINode(fset, m.field.Type)
+					decl := ftyp // embedded field
+					if len(m.field.Names) > 0 {
+						decl = m.name + " " + ftyp
+					}
+					add(t.Name+"."+m.name, "field", decl, decl)
+				}
+			case *ast.InterfaceType:
+				decl := formatTypeHeader(fset, s, "interface")
+				add(t.Name, "type", decl, decl).sealed = typ.Incomplete
+				for _, f := range typ.Methods.List {
+					if ftyp, ok := f.Type.(*ast.FuncType); ok && len(f.Names) > 0 {
+						name := f.Names[0].Name
+						decl := name + strings.TrimPrefix(formatAPINode(fset, ftyp), "func")
+						key := name + strings.TrimPrefix(formatAPINode(fset, unnamedFuncType(ftyp)), "func")
+						add(t.Name+"."+name, "interface method", decl, key)
+						continue
+					}
+					decl := formatAPINode(fset, f.Type) // embedded interface or type constraint
+					add(t.Name+"."+decl, "interface method", decl, decl)
+				}
+			default:
+				decl := "type " + formatAPINode(fset, s)
+				add(t.Name, "type", decl, decl)
+			}
+		}
+	}
+	return features
+}
+
+// formatTypeHeader returns the declaration of the type s with its
+// underlying type abbreviated to keyword.
+func formatTypeHeader(fset *token.FileSet, s *ast.TypeSpec, keyword string) string {
+	header := *s
+	header.Doc, header.Comment = nil, nil
+	header.Type = ast.NewIdent(keyword)
+	return "type " + formatAPINode(fset, &header)
+}
+
+// unnamedFields returns a copy of list without field names, for
+// comparing parameter lists; a field with several names is repeated.
+func unnamedFields(list *ast.FieldList) *ast.FieldList {
+	if list == nil {
+		return nil
+	}
+	unnamed := &ast.FieldList{}
+	for _, f := range list.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			unnamed.List = append(unnamed.List, &ast.Field{Type: f.Type})
+		}
+	}
+	return unnamed
+}
+
+// unnamedFuncType returns a copy of the function type typ without
+// parameter and result names.
+func unnamedFuncType(typ *ast.FuncType) *ast.FuncType {
+	unnamed := *typ
+	unnamed.Params = unnamedFields(typ.Params)
+	unnamed.Results = unnamedFields(typ.Results)
+	return &unnamed
+}
+
+// formatAPINode formats node as Go source, with spaces for indentation.
+func formatAPINode(fset *token.FileSet, node interface{}) string {
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces, Tabwidth: 8}
+	if err := cfg.Fprint(&buf, fset, node); err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return buf.String()
+}
+
+// diffAPI returns the changes of the exported declarations from old
+// to new, sorted by name.
+func diffAPI(old, new map[string]*apiFeature) (removed, changed, added []APIChange) {
+	// members of added or removed types are covered by their types
+	parentIn := func(name string, features map[string]*apiFeature) bool {
+		if i := strings.IndexByte(name, '.'); i >= 0 {
+			return features[name[:i]] != nil
+		}
+		return true
+	}
+	for name, o := range old {
+		n := new[name]
+		switch {
+		case n == nil:
+			if parentIn(name, new) {
+				removed = append(removed, APIChange{Name: name, Kind: o.kind, Old: o.decl})
+			}
+		case o.kind != n.kind || o.key != n.key:
+			changed = append(changed, APIChange{Name: name, Kind: n.kind, Old: o.decl, New: n.decl})
+		}
+	}
+	for name, n := range new {
+		if old[name] != nil || !parentIn(name, old) {
+			continue
+		}
+		compatible := true
+		if n.kind == "interface method" {
+			// existing implementations of the interface lack the method
+			parent := old[name[:strings.IndexByte(name, '.')]]
+			compatible = parent.sealed
+		}
+		added = append(added, APIChange{Name: name, Kind: n.kind, New: n.decl, Compatible: compatible})
+	}
+	for _, list := range [][]APIChange{removed, changed, added} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	}
+	return
+}
+
+// ----------------------------------------------------------------------------
+// API diffs of package trees
+
+// An apiDiffParamError reports an invalid root or package path
+// given to Corpus.APIDiff.
+type apiDiffParamError string
+
+func (e apiDiffParamError) Error() string { return string(e) }
+
+// apiDiffRoot returns the root of a package tree, with its symbolic
+// links evaluated, which must be inside one of c.APIDiffRoots.
+func (c *Corpus) apiDiffRoot(root string) (string, error) {
+	if root == "" {
+		return "", errors.New("missing root")
+	}
+	root = filepath.Clean(root)
+	if !filepath.IsAbs(root) {
+		return "", fmt.Errorf("root %s is not an absolute path", root)
+	}
+	// The symbolic links are evaluated so that a link inside an API
+	// diff root can't lead outside of it.
+	resolved, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("root %s not found", root)
+	}
+	for _, dir := range c.APIDiffRoots {
+		if d, err := filepath.EvalSymlinks(dir); err == nil && inDir(d, resolved) {
+			return resolved, nil
+		}
+	}
+	return "", fmt.Errorf("root %s is not in an API diff root", root)
+}
+
+// inDir reports whether the path is dir or inside it.
+func inDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// analyzeAPI returns the documentation of the package in the directory
+// pkg of the package tree at root, for the build configuration cfg.
+func (c *Corpus) analyzeAPI(root, pkg string, cfg BuildConfig) (*Package, error) {
+	dir, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(pkg)))
+	if err != nil {
+		return nil, err
+	}
+	if !inDir(root, dir) {
+		return nil, apiDiffParamError("invalid pkg: " + pkg + " is a link outside of the root")
+	}
+	tree := NewCorpus(vfs.OS(root))
+	tree.BuildConfig = c.BuildConfig
+	p, err := tree.syntaxAnalysis(pathpkg.Join("/", pkg), pkg, 0, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if p.DocPackage == nil {
+		return nil, fmt.Errorf("no Go package in %s", filepath.Join(root, filepath.FromSlash(pkg)))
+	}
+	return p, nil
+}
+
+// APIDiff returns the changes of the exported API of the package in
+// the directory pkg, a slash-separated path, from the package tree
+// at oldRoot to the one at newRoot. The package files are selected
+// for the build configuration cfg, as for package pages. Both roots
+// must be inside one of c.APIDiffRoots; otherwise, or if pkg is not
+// inside the roots, the error is an apiDiffParamError.
+func (c *Corpus) APIDiff(oldRoot, newRoot, pkg string, cfg BuildConfig) (*APIDiff, error) {
+	var err error
+	if oldRoot, err = c.apiDiffRoot(oldRoot); err != nil {
+		return nil, apiDiffParamError("invalid old: " + err.Error())
+	}
+	if newRoot, err = c.apiDiffRoot(newRoot); err != nil {
+		return nil, apiDiffParamError("invalid new: " + err.Error())
+	}
+	pkg = pathpkg.Clean(strings.TrimPrefix(pkg, "/"))
+	if pkg == ".." || strings.HasPrefix(pkg, "../") {
+		return nil, apiDiffParamError("invalid pkg: " + pkg)
+	}
+
+	oldPkg, err := c.analyzeAPI(oldRoot, pkg, cfg)
+	if err != nil {
+		return nil, err
+	}
+	newPkg, err := c.analyzeAPI(newRoot, pkg, cfg)
+	if err != nil {
+		return nil, err
+	}
+	d := &APIDiff{Old: oldRoot, New: newRoot, Pkg: pkg}
+	d.Removed, d.Changed, d.Added = diffAPI(
+		apiFeatures(oldPkg.FSet, oldPkg.DocPackage),
+		apiFeatures(newPkg.FSet, newPkg.DocPackage),
+	)
+	return d, nil
+}
+
+// apiDiffPage is the data of the /apidiff page.
+type apiDiffPage struct {
+	Old, New, Pkg string // query
+	Diff          *APIDiff
+}
+
+// HandleAPIDiff serves the /apidiff page, showing the API changes of
+// the package given by the "pkg" URL parameter from the package tree
+// given by "old" to the one given by "new". Without parameters, it
+// shows a form for them.
+func (p *Presentation) HandleAPIDiff(w http.ResponseWriter, r *http.Request) {
+	page := &apiDiffPage{
+		Old: r.FormValue("old"),
+		New: r.FormValue("new"),
+		Pkg: r.FormValue("pkg"),
+	}
+	title := "API diff"
+	if page.Old != "" || page.New != "" || page.Pkg != "" {
+		d, err := p.Corpus.APIDiff(page.Old, page.New, page.Pkg, getBuildConfig(r))
+		if err != nil {
+			if _, ok := err.(apiDiffParamError); ok {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			p.ServeError(w, r, page.Pkg, err)
+			return
+		}
+		page.Diff = d
+		title = "API diff of " + d.Pkg
+	}
+	p.ServePage(w, Page{
+		Title:    title,
+		Tabtitle: title,
+		Body:     applyTemplate(p.APIDiffHTML, "apidiffHTML", page),
+	})
+}
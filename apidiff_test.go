@@ -0,0 +1,166 @@
+package godoc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const apiDiffOld = `package p
+
+const (
+	A = 1
+	B = 2
+)
+
+var V int
+
+func F(a, b int) error { return nil }
+
+func G() {}
+
+type S struct {
+	X int
+	Y string
+}
+
+func (s *S) M(n int) {}
+
+type I interface {
+	M()
+}
+
+type J interface {
+	M()
+	unexported()
+}
+
+type Old int
+`
+
+const apiDiffNew = `package p
+
+const (
+	A = 1
+	B = 3
+)
+
+var V int
+
+func F(x, y int) error { return nil }
+
+func G(n int) {}
+
+func H() {}
+
+type S struct {
+	X int
+	Z bool
+}
+
+func (t *S) M(n int) {}
+
+func (S) N() {}
+
+type I interface {
+	M()
+	N()
+}
+
+type J interface {
+	M()
+	N()
+	unexported()
+}
+`
+
+func TestAPIDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-apidiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for root, src := range map[string]string{"old": apiDiffOld, "new": apiDiffNew} {
+		if err := os.MkdirAll(filepath.Join(dir, root, "p"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, root, "p", "p.go"), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldRoot, newRoot := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+
+	c := NewCorpus(nil)
+	if _, err := c.APIDiff(oldRoot, newRoot, "p", BuildConfig{}); err == nil {
+		t.Error("APIDiff succeeded without API diff roots")
+	} else if _, ok := err.(apiDiffParamError); !ok {
+		t.Errorf("APIDiff without API diff roots: %v; want an apiDiffParamError", err)
+	}
+
+	c.APIDiffRoots = []string{dir}
+	if _, err := c.APIDiff(oldRoot, newRoot, "../p", BuildConfig{}); err == nil {
+		t.Error("APIDiff succeeded for a package outside of the roots")
+	}
+	d, err := c.APIDiff(oldRoot, newRoot, "p", BuildConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		name       string
+		compatible bool
+	}
+	changes := func(list []APIChange) []change {
+		var l []change
+		for _, c := range list {
+			l = append(l, change{c.Name, c.Compatible})
+		}
+		return l
+	}
+	if got, want := changes(d.Removed), []change{{"Old", false}, {"S.Y", false}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("removed = %v; want %v", got, want)
+	}
+	if got, want := changes(d.Changed), []change{{"B", false}, {"G", false}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changed = %v; want %v", got, want)
+	}
+	if got, want := changes(d.Added), []change{{"H", true}, {"I.N", false}, {"J.N", true}, {"S.N", true}, {"S.Z", true}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("added = %v; want %v", got, want)
+	}
+	if d.Compatible() {
+		t.Error("incompatible changes reported as compatible")
+	}
+	if got, want := d.Changed[1], (APIChange{"G", "func", "func G()", "func G(n int)", false}); got != want {
+		t.Errorf("change of G = %+v; want %+v", got, want)
+	}
+
+	// Symbolic links inside the roots don't lead outside of them.
+	outside, err := ioutil.TempDir("", "godoc-apidiff-outside")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+	if err := os.MkdirAll(filepath.Join(outside, "p"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(outside, "p", "p.go"), []byte(apiDiffOld), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "link")); err != nil {
+		t.Skipf("symbolic links not supported: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(oldRoot, "link")); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct{ old, pkg string }{
+		{filepath.Join(dir, "link"), "p"},
+		{oldRoot, "link/p"},
+	} {
+		if _, err := c.APIDiff(tc.old, tc.old, tc.pkg, BuildConfig{}); err == nil {
+			t.Errorf("APIDiff of %s in %s succeeded through a link outside of the roots", tc.pkg, tc.old)
+		} else if _, ok := err.(apiDiffParamError); !ok {
+			t.Errorf("APIDiff of %s in %s: %v; want an apiDiffParamError", tc.pkg, tc.old, err)
+		}
+	}
+}
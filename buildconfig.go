@@ -0,0 +1,125 @@
+// This file contains the build configurations selecting the package
+// files documented on package pages.
+//
+// A build configuration is given by the GOOS, GOARCH, tags and cgo
+// URL parameters of a request; the settings it leaves empty are taken
+// from Corpus.BuildConfig, and then from build.Default. The parameters
+// of a request are carried by the links of its package page, like the
+// "m" parameter.
+
+package godoc
+
+import (
+	"go/build"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// A BuildConfig selects the package files of a package like the
+// corresponding settings of the go command. Empty fields select the
+// defaults.
+type BuildConfig struct {
+	GOOS   string // target operating system
+	GOARCH string // target architecture
+	Tags   string // comma- or space-separated build tags, as for "go build -tags"
+	Cgo    string // "1" or "0" to enable or disable cgo
+}
+
+// buildConfigParams are the URL parameters of the BuildConfig fields.
+const (
+	goosParam   = "GOOS"
+	goarchParam = "GOARCH"
+	tagsParam   = "tags"
+	cgoParam    = "cgo"
+)
+
+// getBuildConfig returns the build configuration given by the URL
+// parameters of r. Invalid cgo values are ignored.
+func getBuildConfig(r *http.Request) BuildConfig {
+	cfg := BuildConfig{
+		GOOS:   strings.TrimSpace(r.FormValue(goosParam)),
+		GOARCH: strings.TrimSpace(r.FormValue(goarchParam)),
+		Tags:   strings.Join(buildTags(r.FormValue(tagsParam)), ","),
+	}
+	if cgo := r.FormValue(cgoParam); cgo == "0" || cgo == "1" {
+		cfg.Cgo = cgo
+	}
+	return cfg
+}
+
+// buildTags splits a list of build tags separated by commas or spaces.
+func buildTags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// override returns cfg with the fields set in o replaced.
+func (cfg BuildConfig) override(o BuildConfig) BuildConfig {
+	if o.GOOS != "" {
+		cfg.GOOS = o.GOOS
+	}
+	if o.GOARCH != "" {
+		cfg.GOARCH = o.GOARCH
+	}
+	if o.Tags != "" {
+		cfg.Tags = o.Tags
+	}
+	if o.Cgo != "" {
+		cfg.Cgo = o.Cgo
+	}
+	return cfg
+}
+
+// apply sets the fields of ctxt selected by cfg.
+func (cfg BuildConfig) apply(ctxt *build.Context) {
+	if cfg.GOOS != "" {
+		ctxt.GOOS = cfg.GOOS
+	}
+	if cfg.GOARCH != "" {
+		ctxt.GOARCH = cfg.GOARCH
+	}
+	if tags := buildTags(cfg.Tags); len(tags) > 0 {
+		ctxt.BuildTags = append(ctxt.BuildTags[:len(ctxt.BuildTags):len(ctxt.BuildTags)], tags...)
+	}
+	if cfg.Cgo != "" {
+		ctxt.CgoEnabled = cfg.Cgo == "1"
+	}
+}
+
+// queryString returns the URL query persisting mode and cfg between
+// pages, starting with '?', or "" if both are empty.
+func queryString(mode PageInfoMode, cfg BuildConfig) string {
+	var params []string
+	if names := mode.names(); len(names) > 0 {
+		params = append(params, PageInfoModeQueryString+"="+strings.Join(names, ","))
+	}
+	for _, p := range []struct{ name, value string }{
+		{goosParam, cfg.GOOS},
+		{goarchParam, cfg.GOARCH},
+		{tagsParam, cfg.Tags},
+		{cgoParam, cfg.Cgo},
+	} {
+		if p.value != "" {
+			params = append(params, p.name+"="+url.QueryEscape(p.value))
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + strings.Join(params, "&")
+}
+
+// withModeFunc returns mode with the mode named name added.
+func withModeFunc(mode PageInfoMode, name string) PageInfoMode {
+	return mode | modeNames[name]
+}
+
+// A dirListing is a list of directories whose links carry a query.
+type dirListing struct {
+	Query string // query string of the links; see queryString
+	Dirs  []*Directory
+}
+
+func dirListingFunc(query string, dirs []*Directory) dirListing {
+	return dirListing{query, dirs}
+}
@@ -0,0 +1,61 @@
+package godoc
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestBuildConfig(t *testing.T) {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/p/p.go":           "// Package p is a package.\npackage p\n\nfunc P() {}\n",
+		"src/p/integration.go": "//go:build integration\n\npackage p\n\nfunc Integration() {}\n",
+		"src/p/noasm.go":       "//go:build noasm\n\npackage p\n\nfunc NoAsm() {}\n",
+		"src/p/cgo.go":         "package p\n\nimport \"C\"\n\nfunc Cgo() {}\n",
+	}))
+	c.IndexEnabled = false
+	c.BuildConfig = BuildConfig{Tags: "integration"}
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		cfg  BuildConfig
+		want []string
+	}{
+		{BuildConfig{Cgo: "0"}, []string{"Integration", "P"}},
+		{BuildConfig{Cgo: "1"}, []string{"Cgo", "Integration", "P"}},
+		{BuildConfig{Tags: "noasm", Cgo: "0"}, []string{"NoAsm", "P"}},
+		{BuildConfig{Tags: "integration,noasm", Cgo: "0"}, []string{"Integration", "NoAsm", "P"}},
+	} {
+		pkg, err := c.syntaxAnalysis("/src/p", "p", 0, test.cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var funcs []string
+		for _, f := range pkg.DocPackage.Funcs {
+			funcs = append(funcs, f.Name)
+		}
+		sort.Strings(funcs)
+		if !reflect.DeepEqual(funcs, test.want) {
+			t.Errorf("%+v: funcs = %v; want %v", test.cfg, funcs, test.want)
+		}
+	}
+}
+
+func TestGetBuildConfig(t *testing.T) {
+	r := httptest.NewRequest("GET", "/pkg/p/?m=all&GOOS=windows&tags=integration+noasm&cgo=yes", nil)
+	cfg := getBuildConfig(r)
+	if want := (BuildConfig{GOOS: "windows", Tags: "integration,noasm"}); cfg != want {
+		t.Errorf("getBuildConfig = %+v; want %+v", cfg, want)
+	}
+	if got, want := queryString(NoFiltering, cfg), "?m=all&GOOS=windows&tags=integration%2Cnoasm"; got != want {
+		t.Errorf("queryString = %q; want %q", got, want)
+	}
+	if got := queryString(0, BuildConfig{}); got != "" {
+		t.Errorf("queryString of defaults = %q; want empty", got)
+	}
+}
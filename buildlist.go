@@ -0,0 +1,566 @@
+// This file contains the loading of the build list of the main module,
+// or the main modules of a go.work workspace, in module mode, without
+// running the go command, and the /diagnostics page describing it.
+// In vendor mode, the build list is read from vendor/modules.txt.
+//
+// The requirements of the main module and of its dependencies are read
+// from their go.mod files in the module cache, and the build list is
+// computed by minimal version selection over the module graph, pruned
+// below the modules at go 1.17 or higher as the go command does. The
+// replace directives of the main modules and of the go.work file are
+// applied, and the requirements on excluded versions are ignored. The
+// modules which are not in the module cache are reported rather than
+// downloaded.
+
+package godoc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/miclle/godoc/vfs"
+	"github.com/miclle/godoc/vfs/modfs"
+)
+
+// A BuildList is the build list of a main module.
+type BuildList struct {
+	GoMod   string            // go.mod file of the main module, if not in a workspace
+	GoWork  string            // go.work file of the workspace of the main modules, if any
+	Vendor  string            // vendor/modules.txt file the build list is read from in vendor mode, if any
+	Modules []BuildListModule // main modules first, then sorted by path
+	Errors  []string          // problems loading the module graph, such as missing go.mod files
+}
+
+// A BuildListModule is a module of a build list.
+type BuildListModule struct {
+	Path    string
+	Version string         // "" for the main modules
+	Main    bool           // whether the module is a main module
+	Vendor  bool           // whether the packages of the module are vendored
+	Replace string         // replacement module@version or directory, if any
+	Dir     string         // directory, or module zip file, of the module; "" if missing
+	FS      vfs.FileSystem // file system of the module, rooted at its directory; nil if missing or without vendored packages
+	Err     string         // why the module is missing
+}
+
+// Missing returns the modules of the build list which are missing.
+func (b *BuildList) Missing() []BuildListModule {
+	var list []BuildListModule
+	for _, m := range b.Modules {
+		if m.Err != "" {
+			list = append(list, m)
+		}
+	}
+	return list
+}
+
+// FindGoMod returns the go.mod file of the main module of the directory
+// dir, which is found in dir or its parent directories, as the go command
+// does. It returns "" in GOPATH mode, if GO111MODULE is off or there is
+// no go.mod file.
+func FindGoMod(dir string) string {
+	if os.Getenv("GO111MODULE") == "off" {
+		return ""
+	}
+	return findFile(dir, "go.mod")
+}
+
+// findFile returns the file name in dir or its closest parent directory
+// containing it, or "" if there is none.
+func findFile(dir, name string) string {
+	for {
+		file := filepath.Join(dir, name)
+		if fi, err := os.Stat(file); err == nil && !fi.IsDir() {
+			return file
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadBuildList loads the build list of the main module of the go.mod
+// file goMod, whose dependencies are read from the module cache directory
+// cacheDir. It returns an error only if the go.mod file of the main module
+// can't be read; the missing dependencies are recorded in the build list.
+func LoadBuildList(goMod, cacheDir string) (*BuildList, error) {
+	l := newBuildListLoader(cacheDir)
+	if err := l.addMain(goMod); err != nil {
+		return nil, err
+	}
+	b := l.buildList()
+	b.GoMod = goMod
+	return b, nil
+}
+
+// LoadWorkspaceBuildList is like LoadBuildList for the main modules of
+// the workspace of the go.work file goWork, which are those of its use
+// directives. The replace directives of the go.work file override those
+// of the main modules.
+func LoadWorkspaceBuildList(goWork, cacheDir string) (*BuildList, error) {
+	data, err := ioutil.ReadFile(goWork)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.ParseWork(goWork, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	l := newBuildListLoader(cacheDir)
+	l.work = true
+	workDir := filepath.Dir(goWork)
+	for _, u := range f.Use {
+		if err := l.addMain(filepath.Join(absDir(workDir, u.Path), "go.mod")); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range f.Replace {
+		l.replace[r.Old] = replacement{r.New, workDir}
+	}
+	b := l.buildList()
+	b.GoWork = goWork
+	return b, nil
+}
+
+// VendorModules returns the vendor/modules.txt file of the main module
+// of the go.mod file goMod, or "" if its dependencies are not vendored.
+func VendorModules(goMod string) string {
+	name := filepath.Join(filepath.Dir(goMod), "vendor", "modules.txt")
+	if fi, err := os.Stat(name); err != nil || fi.IsDir() {
+		return ""
+	}
+	return name
+}
+
+// LoadVendorBuildList loads the build list of the main module of the
+// go.mod file goMod from its vendor/modules.txt file, as the go command
+// does in vendor mode. The modules are served from the vendor directory,
+// which holds only their packages imported by the main module.
+func LoadVendorBuildList(goMod string) (*BuildList, error) {
+	l := newBuildListLoader("")
+	if err := l.addMain(goMod); err != nil {
+		return nil, err
+	}
+	name := VendorModules(goMod)
+	if name == "" {
+		return nil, fmt.Errorf("%s: no vendor/modules.txt file", filepath.Dir(goMod))
+	}
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	vendorDir := filepath.Dir(name)
+	b := &BuildList{GoMod: goMod, Vendor: name, Modules: l.mains}
+	var m *BuildListModule
+	for _, line := range strings.Split(string(data), "\n") {
+		switch {
+		case strings.HasPrefix(line, "# "):
+			// # module version [=> replacement [version]]
+			fields := strings.Fields(line[len("# "):])
+			old, repl := fields, []string(nil)
+			for i, f := range fields {
+				if f == "=>" {
+					old, repl = fields[:i], fields[i+1:]
+					break
+				}
+			}
+			if len(old) == 0 || len(old) > 2 || repl != nil && (len(repl) == 0 || len(repl) > 2) {
+				b.Errors = append(b.Errors, fmt.Sprintf("%s: malformed module line %q", name, line))
+				m = nil
+				continue
+			}
+			b.Modules = append(b.Modules, BuildListModule{Path: old[0], Vendor: true})
+			m = &b.Modules[len(b.Modules)-1]
+			if len(old) == 2 {
+				m.Version = old[1]
+			}
+			m.Replace = strings.Join(repl, "@")
+		case strings.HasPrefix(line, "#"), line == "":
+			// ## annotations
+		default:
+			// vendored package of the module m
+			if m != nil && m.FS == nil {
+				m.Dir = filepath.Join(vendorDir, filepath.FromSlash(m.Path))
+				if fi, err := os.Stat(m.Dir); err == nil && fi.IsDir() {
+					m.FS = vfs.OS(m.Dir)
+				} else {
+					m.Err = "vendored packages not found"
+					m.Dir = ""
+				}
+			}
+		}
+	}
+	sort.Slice(b.Modules[len(l.mains):], func(i, j int) bool {
+		return b.Modules[len(l.mains)+i].Path < b.Modules[len(l.mains)+j].Path
+	})
+	return b, nil
+}
+
+// vendoredModule returns the vendored module of c.BuildList holding the
+// package with the given import path, or nil.
+func (c *Corpus) vendoredModule(importPath string) *BuildListModule {
+	b := c.BuildList
+	if b == nil || b.Vendor == "" {
+		return nil
+	}
+	var mod *BuildListModule
+	for i, m := range b.Modules {
+		if m.Vendor && (importPath == m.Path || strings.HasPrefix(importPath, m.Path+"/")) {
+			if mod == nil || len(m.Path) > len(mod.Path) {
+				mod = &b.Modules[i]
+			}
+		}
+	}
+	return mod
+}
+
+// FindGoWork returns the go.work file of the workspace of the directory
+// dir, which is $GOWORK or else found in dir or its parent directories,
+// as the go command does. It returns "" if GOWORK or GO111MODULE is off
+// or there is no go.work file.
+func FindGoWork(dir string) string {
+	if os.Getenv("GO111MODULE") == "off" {
+		return ""
+	}
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return ""
+	case "":
+		return findFile(dir, "go.work")
+	default:
+		return gowork
+	}
+}
+
+// A buildListLoader loads the module graph of the main modules.
+type buildListLoader struct {
+	cacheDir string
+	mains    []BuildListModule // main modules
+	files    []*modfile.File   // go.mod files of the main modules
+	main     map[string]bool   // paths of the main modules
+	work     bool              // whether the main modules are those of a workspace
+	replace  map[module.Version]replacement
+	exclude  map[module.Version]bool
+}
+
+// A replacement is the replacement of a module by a replace directive
+// of the go.mod or go.work file of the directory dir.
+type replacement struct {
+	mod module.Version // Version is "" for a replacement directory
+	dir string         // directory of the go.mod or go.work file
+}
+
+func newBuildListLoader(cacheDir string) *buildListLoader {
+	return &buildListLoader{
+		cacheDir: cacheDir,
+		main:     make(map[string]bool),
+		replace:  make(map[module.Version]replacement),
+		exclude:  make(map[module.Version]bool),
+	}
+}
+
+// addMain adds the main module of the go.mod file goMod.
+func (l *buildListLoader) addMain(goMod string) error {
+	data, err := ioutil.ReadFile(goMod)
+	if err != nil {
+		return err
+	}
+	f, err := modfile.Parse(goMod, data, nil)
+	if err != nil {
+		return err
+	}
+	if f.Module == nil {
+		return fmt.Errorf("%s: no module declaration", goMod)
+	}
+	dir := filepath.Dir(goMod)
+	l.mains = append(l.mains, BuildListModule{
+		Path: f.Module.Mod.Path,
+		Main: true,
+		Dir:  dir,
+		FS:   vfs.OS(dir),
+	})
+	l.files = append(l.files, f)
+	l.main[f.Module.Mod.Path] = true
+	for _, r := range f.Replace {
+		l.replace[r.Old] = replacement{r.New, dir}
+	}
+	for _, x := range f.Exclude {
+		l.exclude[x.Mod] = true
+	}
+	return nil
+}
+
+// buildList returns the build list of the main modules.
+func (l *buildListLoader) buildList() *BuildList {
+	b := &BuildList{Modules: l.mains}
+
+	// Minimal version selection: select the highest version of each
+	// module path of the module graph. As the go command does, the
+	// graph is pruned below the modules at go 1.17 or higher: their
+	// requirements are in the graph, but not loaded, unless reached
+	// from a module without graph pruning, which loads the whole
+	// graph below it. The main modules of a workspace prune it.
+	selected := make(map[string]string)
+	sel := func(m module.Version) {
+		if v, ok := selected[m.Path]; !ok || semver.Compare(m.Version, v) > 0 {
+			selected[m.Path] = m.Version
+		}
+	}
+	type node struct {
+		mod      module.Version
+		unpruned bool // whether it is reached from a module without graph pruning
+	}
+	var queue []node
+	for _, f := range l.files {
+		unpruned := !l.work && !goAtLeast(f, "1.17")
+		for _, r := range l.requirements(f) {
+			sel(r)
+			queue = append(queue, node{r, unpruned})
+		}
+	}
+	loaded := make(map[node]bool)
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if loaded[n] {
+			continue
+		}
+		loaded[n] = true
+		reqs, pruned, err := l.load(n.mod)
+		if err != nil {
+			b.Errors = append(b.Errors, err.Error())
+		}
+		for _, r := range reqs {
+			sel(r)
+			if n.unpruned || !pruned {
+				queue = append(queue, node{r, n.unpruned})
+			}
+		}
+	}
+
+	var paths []string
+	for path := range selected {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		b.Modules = append(b.Modules, l.module(module.Version{Path: path, Version: selected[path]}))
+	}
+	sort.Strings(b.Errors)
+	return b
+}
+
+// goAtLeast reports whether the go.mod file f declares a go version
+// higher than or equal to version, such as "1.17".
+func goAtLeast(f *modfile.File, version string) bool {
+	if f.Go == nil {
+		return false
+	}
+	v := f.Go.Version
+	if i := strings.IndexAny(v, "abcdefghijklmnopqrstuvwxyz"); i >= 0 {
+		v = v[:i] // pre-release, such as 1.21rc1
+	}
+	return semver.Compare("v"+v, "v"+version) >= 0
+}
+
+// replacement returns the replacement of the module m and whether it is
+// replaced.
+func (l *buildListLoader) replacement(m module.Version) (replacement, bool) {
+	if r, ok := l.replace[m]; ok {
+		return r, true
+	}
+	r, ok := l.replace[module.Version{Path: m.Path}]
+	return r, ok
+}
+
+// absDir returns the directory path, relative to the directory dir
+// unless it is absolute.
+func absDir(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// load returns the requirements of the module m, read from its go.mod
+// file, or that of its replacement, and whether the file prunes the
+// module graph.
+func (l *buildListLoader) load(m module.Version) (reqs []module.Version, pruned bool, err error) {
+	var name string
+	if r, ok := l.replacement(m); ok && r.mod.Version == "" {
+		name = filepath.Join(absDir(r.dir, r.mod.Path), "go.mod")
+	} else {
+		if ok {
+			m = r.mod
+		}
+		name = modfs.ModFile(l.cacheDir, m.Path, m.Version)
+		if _, err := os.Stat(name); err != nil {
+			// The module may have been extracted without its go.mod
+			// file being kept in the download cache.
+			if dir := modfs.Dir(l.cacheDir, m.Path, m.Version); fileExists(filepath.Join(dir, "go.mod")) {
+				name = filepath.Join(dir, "go.mod")
+			}
+		}
+	}
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, fmt.Errorf("%s@%s: go.mod not in the module cache", m.Path, m.Version)
+		}
+		return nil, false, err
+	}
+	f, err := modfile.ParseLax(name, data, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	return l.requirements(f), goAtLeast(f, "1.17"), nil
+}
+
+// requirements returns the requirements of the go.mod file f, except
+// those on the main modules and their excluded versions.
+func (l *buildListLoader) requirements(f *modfile.File) []module.Version {
+	var reqs []module.Version
+	for _, r := range f.Require {
+		if l.main[r.Mod.Path] || l.exclude[r.Mod] {
+			continue
+		}
+		reqs = append(reqs, r.Mod)
+	}
+	return reqs
+}
+
+// module returns the module of the build list of the selected module m.
+func (l *buildListLoader) module(m module.Version) BuildListModule {
+	bm := BuildListModule{Path: m.Path, Version: m.Version}
+	r, ok := l.replacement(m)
+	switch {
+	case ok && r.mod.Version == "":
+		bm.Replace = r.mod.Path
+		bm.Dir = absDir(r.dir, r.mod.Path)
+		if fi, err := os.Stat(bm.Dir); err != nil || !fi.IsDir() {
+			bm.Err = "replacement directory not found"
+			bm.Dir = ""
+			return bm
+		}
+		bm.FS = vfs.OS(bm.Dir)
+		return bm
+	case ok:
+		bm.Replace = r.mod.Path + "@" + r.mod.Version
+		m = r.mod
+	}
+	fs, err := modfs.Open(l.cacheDir, m.Path, m.Version)
+	if err != nil {
+		bm.Err = "not in the module cache"
+		return bm
+	}
+	bm.FS = fs
+	bm.Dir = modfs.Dir(l.cacheDir, m.Path, m.Version)
+	if !fileExists(bm.Dir) {
+		bm.Dir = modfs.ZipFile(l.cacheDir, m.Path, m.Version)
+	}
+	return bm
+}
+
+// A WorkspaceModule is a main module of a workspace, whose packages are
+// grouped on the package root page.
+type WorkspaceModule struct {
+	Path     string
+	Dir      string       // directory of the module
+	Packages []*Directory // directory tree of the module; nil if it has no packages
+}
+
+// workspaceModules groups the packages of the directory tree root of /src
+// by main module of the workspace of c.BuildList. It returns the main
+// modules and a copy of root without their directories, or nil and root
+// if not in a workspace.
+func (c *Corpus) workspaceModules(root *Directory) ([]*WorkspaceModule, *Directory) {
+	b := c.BuildList
+	if b == nil || b.GoWork == "" || root == nil {
+		return nil, root
+	}
+	var mods []*WorkspaceModule
+	var dirs []*Directory // of mods; nil if the module has no packages
+	prune := make(map[string]bool)
+	for _, m := range b.Modules {
+		if !m.Main {
+			continue
+		}
+		d := root.lookup(pathpkg.Join("/src", m.Path))
+		if d != nil {
+			prune[d.Path] = true
+		}
+		mods = append(mods, &WorkspaceModule{Path: m.Path, Dir: m.Dir})
+		dirs = append(dirs, d)
+	}
+	// The tree of a module doesn't include the nested main modules,
+	// such as example.com/a/b in example.com/a.
+	for i, d := range dirs {
+		if d != nil {
+			mods[i].Packages = []*Directory{pruneDirectory(d, prune)}
+		}
+	}
+	return mods, pruneDirectory(root, prune)
+}
+
+// pruneDirectory returns a copy of the directory tree d without the
+// directories of the given paths, nor the directories left without
+// packages by their removal.
+func pruneDirectory(d *Directory, paths map[string]bool) *Directory {
+	pruned := *d
+	pruned.SubDirectories = nil
+	for _, sub := range d.SubDirectories {
+		if paths[sub.Path] {
+			continue
+		}
+		p := pruneDirectory(sub, paths)
+		if !p.HasPkg && len(p.SubDirectories) == 0 && len(sub.SubDirectories) > 0 {
+			continue
+		}
+		pruned.SubDirectories = append(pruned.SubDirectories, p)
+	}
+	return &pruned
+}
+
+// DiagnosticsResult is the data of the /diagnostics page.
+type DiagnosticsResult struct {
+	Alert     string // error or informational message
+	BuildList *BuildList
+	Missing   []BuildListModule // missing modules of the build list
+}
+
+// HandleDiagnostics serves the /diagnostics page describing the build
+// list of Corpus.BuildList and its missing modules.
+func (p *Presentation) HandleDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/diagnostics" {
+		http.NotFound(w, r)
+		return
+	}
+	result := DiagnosticsResult{BuildList: p.Corpus.BuildList}
+	if b := result.BuildList; b == nil {
+		result.Alert = "Not in module mode: there is no build list."
+	} else if result.Missing = b.Missing(); len(result.Missing) > 0 {
+		result.Alert = fmt.Sprintf("%d modules of the build list are missing; their documentation is not served.", len(result.Missing))
+	}
+	p.ServePage(w, Page{
+		Title:    "Module diagnostics",
+		Tabtitle: "Diagnostics",
+		Body:     applyTemplate(p.DiagnosticsHTML, "diagnosticsHTML", result),
+	})
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
@@ -0,0 +1,362 @@
+package godoc
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs"
+)
+
+// writeFiles writes the files, with slash-separated names relative to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadBuildList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-buildlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache := filepath.Join(dir, "modcache")
+	writeFiles(t, dir, map[string]string{
+		"main/go.mod": `module example.com/main
+
+require (
+	example.com/a v1.0.0
+	example.com/b v1.1.0
+	example.com/excluded v1.0.0
+	example.com/r v1.0.0
+	example.com/s v1.0.0
+	missing.com/m v1.0.0
+)
+
+replace example.com/r => ./local
+
+replace example.com/s v1.0.0 => example.com/s2 v1.0.0
+
+exclude example.com/excluded v1.0.0
+`,
+		"main/local/go.mod": "module example.com/r\n",
+		"main/local/r.go":   "package r",
+
+		"modcache/cache/download/example.com/a/@v/v1.0.0.mod": "module example.com/a\n\nrequire (\n\texample.com/b v1.2.0\n\texample.com/c v1.0.0\n\texample.com/main v0.1.0\n)\n",
+		"modcache/example.com/a@v1.0.0/a.go":                  "package a",
+		"modcache/cache/download/example.com/b/@v/v1.1.0.mod": "module example.com/b\n\nrequire example.com/c v1.1.0\n",
+		"modcache/cache/download/example.com/b/@v/v1.2.0.mod": "module example.com/b\n",
+		"modcache/cache/download/example.com/c/@v/v1.0.0.mod": "module example.com/c\n",
+		// The go.mod file of c v1.1.0 is only in its extracted directory.
+		"modcache/example.com/c@v1.1.0/go.mod":                 "module example.com/c\n",
+		"modcache/example.com/c@v1.1.0/c.go":                   "package c",
+		"modcache/cache/download/example.com/s2/@v/v1.0.0.mod": "module example.com/s2\n",
+		"modcache/example.com/s2@v1.0.0/s.go":                  "package s",
+	})
+	// b v1.2.0 is only downloaded.
+	zf, err := os.Create(filepath.Join(cache, "cache/download/example.com/b/@v/v1.2.0.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(zf)
+	if w, err := zw.Create("example.com/b@v1.2.0/b.go"); err != nil {
+		t.Fatal(err)
+	} else if _, err := w.Write([]byte("package b")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zf.Close()
+
+	b, err := LoadBuildList(filepath.Join(dir, "main", "go.mod"), cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type module struct {
+		path, version, replace, dir, err string
+	}
+	var got []module
+	for _, m := range b.Modules {
+		got = append(got, module{m.Path, m.Version, m.Replace, m.Dir, m.Err})
+		if (m.FS == nil) != (m.Err != "") {
+			t.Errorf("%s: FS = %v with error %q", m.Path, m.FS, m.Err)
+		}
+	}
+	want := []module{
+		{"example.com/main", "", "", filepath.Join(dir, "main"), ""},
+		{"example.com/a", "v1.0.0", "", filepath.Join(cache, "example.com/a@v1.0.0"), ""},
+		{"example.com/b", "v1.2.0", "", filepath.Join(cache, "cache/download/example.com/b/@v/v1.2.0.zip"), ""},
+		{"example.com/c", "v1.1.0", "", filepath.Join(cache, "example.com/c@v1.1.0"), ""},
+		{"example.com/r", "v1.0.0", "./local", filepath.Join(dir, "main", "local"), ""},
+		{"example.com/s", "v1.0.0", "example.com/s2@v1.0.0", filepath.Join(cache, "example.com/s2@v1.0.0"), ""},
+		{"missing.com/m", "v1.0.0", "", "", "not in the module cache"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("build list:\ngot  %q\nwant %q", got, want)
+	}
+	if want := []string{"missing.com/m@v1.0.0: go.mod not in the module cache"}; !reflect.DeepEqual(b.Errors, want) {
+		t.Errorf("errors = %q; want %q", b.Errors, want)
+	}
+	if missing := b.Missing(); len(missing) != 1 || missing[0].Path != "missing.com/m" {
+		t.Errorf("missing modules = %v; want missing.com/m", missing)
+	}
+	for _, m := range b.Modules[1:6] {
+		name := map[string]string{"example.com/a": "/a.go", "example.com/b": "/b.go", "example.com/c": "/c.go", "example.com/r": "/r.go", "example.com/s": "/s.go"}[m.Path]
+		if _, err := vfs.ReadFile(m.FS, name); err != nil {
+			t.Errorf("%s: %v", m.Path, err)
+		}
+	}
+
+	if _, err := LoadBuildList(filepath.Join(dir, "main", "local", "missing.mod"), cache); err == nil {
+		t.Error("LoadBuildList of a missing go.mod file succeeded")
+	}
+}
+
+func TestLoadBuildListPruned(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-buildlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache := filepath.Join(dir, "modcache")
+	writeFiles(t, dir, map[string]string{
+		"modcache/cache/download/example.com/a/@v/v1.0.0.mod": "module example.com/a\n\ngo 1.21\n\nrequire example.com/b v1.0.0\n",
+		"modcache/cache/download/example.com/b/@v/v1.0.0.mod": "module example.com/b\n\nrequire example.com/c v1.2.0\n",
+		"modcache/cache/download/example.com/c/@v/v1.0.0.mod": "module example.com/c\n",
+		"modcache/example.com/c@v1.0.0/c.go":                  "package c",
+	})
+
+	for _, tc := range []struct {
+		goVersion string
+		c         string // selected version of example.com/c
+		errors    int
+	}{
+		// The requirements of b are pruned out: its go.mod file is not loaded.
+		{"1.21", "v1.0.0", 0},
+		// The whole module graph is loaded: c v1.2.0 is missing.
+		{"1.16", "v1.2.0", 1},
+	} {
+		writeFiles(t, dir, map[string]string{
+			"main/go.mod": "module example.com/main\n\ngo " + tc.goVersion + "\n\nrequire (\n\texample.com/a v1.0.0\n\texample.com/c v1.0.0\n)\n",
+		})
+		b, err := LoadBuildList(filepath.Join(dir, "main", "go.mod"), cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions := make(map[string]string)
+		for _, m := range b.Modules {
+			versions[m.Path] = m.Version
+		}
+		if versions["example.com/b"] != "v1.0.0" || versions["example.com/c"] != tc.c {
+			t.Errorf("go %s: build list %v; want example.com/b v1.0.0 and example.com/c %s", tc.goVersion, versions, tc.c)
+		}
+		if len(b.Errors) != tc.errors {
+			t.Errorf("go %s: errors = %q; want %d", tc.goVersion, b.Errors, tc.errors)
+		}
+	}
+}
+
+func TestFindGoMod(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-gomod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{
+		"m/go.mod":       "module m\n",
+		"m/sub/pkg/p.go": "package p",
+		"other/go.mod/x": "", // a directory named go.mod
+		"other/pkg/p.go": "package p",
+	})
+	defer os.Setenv("GO111MODULE", os.Getenv("GO111MODULE"))
+	os.Setenv("GO111MODULE", "")
+	if got, want := FindGoMod(filepath.Join(dir, "m", "sub", "pkg")), filepath.Join(dir, "m", "go.mod"); got != want {
+		t.Errorf("FindGoMod in a module = %q; want %q", got, want)
+	}
+	if got := FindGoMod(filepath.Join(dir, "other", "pkg")); got == filepath.Join(dir, "other", "go.mod") {
+		t.Errorf("FindGoMod = %q, a directory", got)
+	}
+	os.Setenv("GO111MODULE", "off")
+	if got := FindGoMod(filepath.Join(dir, "m")); got != "" {
+		t.Errorf("FindGoMod with GO111MODULE=off = %q; want \"\"", got)
+	}
+}
+
+func TestLoadWorkspaceBuildList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache := filepath.Join(dir, "modcache")
+	writeFiles(t, dir, map[string]string{
+		"ws/go.work":         "go 1.18\n\nuse (\n\t./a\n\t./a/nested\n\t./b\n)\n\nreplace example.com/c => ./c\n",
+		"ws/a/go.mod":        "module corp/a\n\nrequire (\n\texample.com/b v1.0.0\n\texample.com/c v1.0.0\n)\n\nreplace example.com/c => example.com/c v1.1.0\n",
+		"ws/a/nested/go.mod": "module corp/a/nested\n",
+		"ws/b/go.mod":        "module example.com/b\n\nrequire example.com/d v1.0.0\n",
+		"ws/c/go.mod":        "module example.com/c\n",
+
+		"modcache/cache/download/example.com/d/@v/v1.0.0.mod": "module example.com/d\n",
+		"modcache/example.com/d@v1.0.0/d.go":                  "package d",
+	})
+
+	b, err := LoadWorkspaceBuildList(filepath.Join(dir, "ws", "go.work"), cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type module struct {
+		path, version, replace, dir string
+		main                        bool
+	}
+	var got []module
+	for _, m := range b.Modules {
+		got = append(got, module{m.Path, m.Version, m.Replace, m.Dir, m.Main})
+	}
+	want := []module{
+		{"corp/a", "", "", filepath.Join(dir, "ws", "a"), true},
+		{"corp/a/nested", "", "", filepath.Join(dir, "ws", "a", "nested"), true},
+		{"example.com/b", "", "", filepath.Join(dir, "ws", "b"), true},
+		// The replacement of the go.work file overrides that of corp/a.
+		{"example.com/c", "v1.0.0", "./c", filepath.Join(dir, "ws", "c"), false},
+		{"example.com/d", "v1.0.0", "", filepath.Join(cache, "example.com/d@v1.0.0"), false},
+	}
+	if !reflect.DeepEqual(got, want) || b.GoWork == "" || b.GoMod != "" || len(b.Errors) > 0 {
+		t.Errorf("build list:\ngot  %v (errors %q)\nwant %v", got, b.Errors, want)
+	}
+
+	c := NewCorpus(nil)
+	c.BuildList = b
+	src := &Directory{Path: "/src", SubDirectories: []*Directory{
+		{Path: "/src/corp", Name: "corp", SubDirectories: []*Directory{
+			{Path: "/src/corp/a", Name: "a", HasPkg: true, SubDirectories: []*Directory{
+				{Path: "/src/corp/a/nested", Name: "nested", HasPkg: true},
+				{Path: "/src/corp/a/sub", Name: "sub", HasPkg: true},
+			}},
+		}},
+		{Path: "/src/example.com", Name: "example.com", SubDirectories: []*Directory{
+			{Path: "/src/example.com/b", Name: "b", HasPkg: true},
+			{Path: "/src/example.com/d", Name: "d", HasPkg: true},
+		}},
+		{Path: "/src/fmt", Name: "fmt", HasPkg: true},
+	}}
+	mods, others := c.workspaceModules(src)
+	paths := func(d *Directory) []string {
+		var list []string
+		var walk func(d *Directory)
+		walk = func(d *Directory) {
+			list = append(list, d.Path)
+			for _, sub := range d.SubDirectories {
+				walk(sub)
+			}
+		}
+		walk(d)
+		return list
+	}
+	// The nested module corp/a/nested is not in the tree of corp/a.
+	wantMods := map[string][]string{
+		"corp/a":        {"/src/corp/a", "/src/corp/a/sub"},
+		"corp/a/nested": {"/src/corp/a/nested"},
+		"example.com/b": {"/src/example.com/b"},
+	}
+	if len(mods) != len(wantMods) {
+		t.Errorf("workspace modules = %v", mods)
+	}
+	for _, m := range mods {
+		if len(m.Packages) != 1 || !reflect.DeepEqual(paths(m.Packages[0]), wantMods[m.Path]) {
+			t.Errorf("packages of %s = %v; want %v", m.Path, m.Packages, wantMods[m.Path])
+		}
+	}
+	if got, want := paths(others), []string{"/src", "/src/example.com", "/src/example.com/d", "/src/fmt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("other packages = %v; want %v", got, want)
+	}
+	if len(src.SubDirectories) != 3 {
+		t.Error("workspaceModules modified the directory tree")
+	}
+}
+
+func TestLoadVendorBuildList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-vendor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{
+		"m/go.mod": "module example.com/m\n\nrequire example.com/a v1.0.0\n",
+		"m/vendor/modules.txt": `# example.com/a v1.0.0
+## explicit
+example.com/a
+example.com/a/sub
+# example.com/a/nested v0.1.0 => example.com/fork v0.2.0
+example.com/a/nested
+# example.com/b v1.2.0 => ../b
+## explicit; go 1.15
+example.com/b
+# example.com/gone v1.0.0
+example.com/gone
+# example.com/nopkg v1.0.0
+`,
+		"m/vendor/example.com/a/a.go":        "package a",
+		"m/vendor/example.com/a/sub/sub.go":  "package sub",
+		"m/vendor/example.com/a/nested/n.go": "package nested",
+		"m/vendor/example.com/b/b.go":        "package b",
+	})
+	goMod := filepath.Join(dir, "m", "go.mod")
+	if got, want := VendorModules(goMod), filepath.Join(dir, "m", "vendor", "modules.txt"); got != want {
+		t.Errorf("VendorModules = %q; want %q", got, want)
+	}
+	b, err := LoadVendorBuildList(goMod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendor := filepath.Join(dir, "m", "vendor")
+	type module struct {
+		path, version, replace, dir, err string
+	}
+	var got []module
+	for _, m := range b.Modules {
+		got = append(got, module{m.Path, m.Version, m.Replace, m.Dir, m.Err})
+	}
+	want := []module{
+		{"example.com/m", "", "", filepath.Join(dir, "m"), ""},
+		{"example.com/a", "v1.0.0", "", filepath.Join(vendor, "example.com/a"), ""},
+		{"example.com/a/nested", "v0.1.0", "example.com/fork@v0.2.0", filepath.Join(vendor, "example.com/a/nested"), ""},
+		{"example.com/b", "v1.2.0", "../b", filepath.Join(vendor, "example.com/b"), ""},
+		{"example.com/gone", "v1.0.0", "", "", "vendored packages not found"},
+		{"example.com/nopkg", "v1.0.0", "", "", ""},
+	}
+	if !reflect.DeepEqual(got, want) || len(b.Errors) > 0 {
+		t.Errorf("build list:\ngot  %q (errors %q)\nwant %q", got, b.Errors, want)
+	}
+	if missing := b.Missing(); len(missing) != 1 || missing[0].Path != "example.com/gone" {
+		t.Errorf("missing modules = %v; want example.com/gone", missing)
+	}
+
+	c := NewCorpus(nil)
+	c.BuildList = b
+	for path, want := range map[string]string{
+		"example.com/a/sub":      "example.com/a",
+		"example.com/a/nested/x": "example.com/a/nested",
+		"example.com/b":          "example.com/b",
+		"example.com/m":          "",
+		"example.com/another":    "",
+	} {
+		got := ""
+		if m := c.vendoredModule(path); m != nil {
+			got = m.Path
+		}
+		if got != want {
+			t.Errorf("vendoredModule(%q) = %q; want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+
+Apigen writes the API files of the released versions of a module, from
+which godoc shows when the declarations of the module's packages were
+added, as it does for the standard library.
+
+Usage:
+
+	apigen [flag] -module=path
+	apigen [flag] -git=dir
+
+The versions are those extracted in the module cache or, with -git,
+those tagged in a git repository. For each version after the first, the
+file <version>.txt of the output directory lists the exported
+declarations which first appeared in that version, in the format of the
+$GOROOT/api/go*.txt files. Godoc loads the files matching its -api_files
+patterns:
+
+	apigen -git=$HOME/src/mymod -o=$HOME/api/mymod
+	godoc -api_files=$HOME/api/mymod/*.txt
+
+The flags are:
+
+	-module=""
+		module path; with -git, it defaults to the module path of
+		the go.mod file of the module directory
+	-modcache=""
+		module cache directory; defaults to $GOMODCACHE or $GOPATH/pkg/mod
+	-git=""
+		git repository of the module; if set, the versions are its tags
+	-subdir=""
+		with -git, the directory of the module in the repository; its
+		tags are prefixed with the directory, as for the go command
+	-o="."
+		output directory
+	-tags=""
+		comma-separated list of build tags selecting the package files
+	-cgo=""
+		if 1 or 0, enable or disable cgo when selecting the package files
+
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/build"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/miclle/godoc"
+)
+
+var (
+	modulePath = flag.String("module", "", "module path; with -git, defaults to the module path of the go.mod file")
+	modCache   = flag.String("modcache", "", "module cache directory; defaults to $GOMODCACHE or $GOPATH/pkg/mod")
+	gitRepo    = flag.String("git", "", "git repository of the module; if set, the versions are its tags")
+	subdir     = flag.String("subdir", "", "with -git, the directory of the module in the repository")
+	outDir     = flag.String("o", ".", "output directory")
+	buildTags  = flag.String("tags", "", "comma-separated list of build tags selecting the package files")
+	cgoEnabled = flag.String("cgo", "", "if 1 or 0, enable or disable cgo when selecting the package files")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: apigen [flag] -module=path\n       apigen [flag] -git=dir\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+// goModPath returns the module path declared by the go.mod file of dir.
+func goModPath(dir string) (string, error) {
+	f, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s: no module path", f.Name())
+}
+
+// defaultModCache returns the module cache directory of the go command.
+func defaultModCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	return filepath.Join(filepath.SplitList(build.Default.GOPATH)[0], "pkg", "mod")
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 0 || *modulePath == "" && *gitRepo == "" {
+		usage()
+	}
+	if *cgoEnabled != "" && *cgoEnabled != "0" && *cgoEnabled != "1" {
+		log.Fatalf("invalid -cgo value %q: must be 0 or 1", *cgoEnabled)
+	}
+
+	var versions []godoc.ModuleVersion
+	var err error
+	if *gitRepo != "" {
+		if *modulePath == "" {
+			*modulePath, err = goModPath(filepath.Join(*gitRepo, filepath.FromSlash(*subdir)))
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		versions, err = godoc.GitVersions(*gitRepo, *subdir, *modulePath)
+	} else {
+		if *modCache == "" {
+			*modCache = defaultModCache()
+		}
+		versions, err = godoc.ModuleCacheVersions(*modCache, *modulePath)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(versions) == 0 {
+		log.Fatalf("no release versions of %s", *modulePath)
+	}
+
+	if err := os.MkdirAll(*outDir, 0777); err != nil {
+		log.Fatal(err)
+	}
+	cfg := godoc.BuildConfig{Tags: *buildTags, Cgo: *cgoEnabled}
+	if err := godoc.WriteModuleAPIFiles(*outDir, *modulePath, versions, cfg); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%s: API files of %d versions written (%s to %s)", *modulePath, len(versions)-1, versions[0].Version, versions[len(versions)-1].Version)
+}
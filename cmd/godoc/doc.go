@@ -1,5 +1,4 @@
 /*
-
 Godoc extracts and generates documentation for Go programs.
 
 It runs as a web server and presents the documentation as a
@@ -21,11 +20,70 @@ The flags are:
 		enable playground
 	-links=true
 		link identifiers to their declarations
+	-implements=false
+		list the interfaces each exported type implements and, for
+		interfaces, the types implementing them; all packages are
+		type-checked at startup and after changes
+	-typecheck=false
+		type-check packages (and, from source, the packages they import)
+		to link identifiers to their declarations exactly, including
+		methods, fields, renamed and dot imports; same as ?m=types
+	-tags=""
+		comma-separated list of build tags selecting the package files
+		documented on package pages by default (e.g., "integration,noasm")
+	-cgo=""
+		if 1 or 0, enable or disable cgo when selecting the package files
+		documented on package pages by default
+	-apidiff_roots=""
+		list of directories, separated as in $GOPATH, containing the
+		package trees compared by the /apidiff page; if empty, $GOROOT
+		and the $GOPATH directories, which hold the module cache
+	-api_files=""
+		list of glob patterns, separated as in $GOPATH, matching the
+		API files of modules written by the apigen command; package
+		pages show the module versions adding declarations as they do
+		the Go versions for the standard library
+	-workspace=""
+		go.work file of the workspace whose modules are served; if empty,
+		$GOWORK or the go.work file of the current directory or its parents
+	-modcache=false
+		serve all the module versions of the module cache ($GOMODCACHE,
+		or $GOPATH/pkg/mod) at /mod/, needing neither a go.mod file nor
+		network access
+	-platforms=""
+		comma-separated list of GOOS/GOARCH platforms for which
+		?m=platforms documents packages (e.g., "linux/amd64,darwin/arm64");
+		if empty, common platforms are used
 	-notes="BUG"
 		regular expression matching note markers to show
 		(e.g., "BUG|TODO", ".*")
 	-goroot=$GOROOT
 		Go root directory
+	-index
+		enable identifier and full text search index
+		(no search box is shown if -index is not set)
+	-index_throttle=0.75
+		index throttle value; a value of 0 means no time is allocated
+		to the indexer (the indexer will never finish), a value of 1.0
+		means that index creation is running at full throttle (other
+		goroutines may get no time while the index is built)
+	-index_files=""
+		glob pattern specifying index files; if not empty,
+		the index is read from these files in sorted order
+	-write_index=false
+		write index to a file; the file name must be specified with
+		-index_files
+	-maxresults=10000
+		maximum number of full text search results shown
+		(no full text index is built if maxresults <= 0)
+	-sync_interval=10s
+		interval between checks of the file system for changes;
+		the package tree (and the index, if enabled) is updated
+		when files change (0 disables checking)
+	-pageinfo_cache=64
+		maximum size in MB of the cache of analyzed packages for
+		package pages (0 disables caching); its hit and miss counters
+		are published at /debug/vars as "pageinfo_cache"
 	-http=addr
 		HTTP service address (e.g., '127.0.0.1:6060' or just ':6060')
 	-templates=""
@@ -37,21 +95,64 @@ The flags are:
 		an HTTP request for path
 	-zip=""
 		zip file providing the file system to serve; disabled if empty
+	-export=""
+		write the documentation as a static HTML site to the given
+		directory, rather than serving it; see below
+	-export_workers=0
+		number of pages rendered in parallel by -export; if 0, GOMAXPROCS
 
 By default, godoc looks at the packages it finds via $GOROOT and $GOPATH (if set).
 This behavior can be altered by providing an alternative $GOROOT with the -goroot
 flag.
 
 When the -index flag is set, a search index is maintained.
-The index is created at startup.
+The index is created at startup and searched via the /search?q= page.
+
+The index contains both identifier and full text search information. Identifier
+queries may be qualified with a package name, as in "fmt.Println". The maximum
+number of full text search results shown can be set with the -maxresults flag;
+if set to 0, no full text results are shown, and only an identifier index but
+no full text search index is created.
+
+The index also records the references to the exported package-level
+identifiers of each package, listed by the /refs/<importpath> page; the
+references to a single identifier are at /refs/<importpath>#<Name>.
+References are found syntactically: uses via dot imports, and of methods
+and fields, are not listed.
+
+Packages and declarations are deprecated by a paragraph starting with
+"Deprecated: " in their documentation. Package pages mark and collapse
+deprecated declarations, and the sidebar omits deprecated packages. The
+index also records the deprecated packages and exported declarations of
+all packages, listed by the /deprecated/ page.
+
+The directory tree of packages, their synopses and the search index (if -index
+is set) can be written to a file with -write_index and -index_files, and read
+back at startup by setting -index_files. For instance,
+
+	godoc -index -write_index -index_files=$HOME/godoc.index
+
+writes the file and
+
+	godoc -http=:6060 -index -index_files=$HOME/godoc.index
 
-The index contains both identifier and full text search information (searchable
-via regular expressions). The maximum number of full text search results shown
-can be set with the -maxresults flag; if set to 0, no full text results are
-shown, and only an identifier index but no full text search index is created.
+serves documentation from it without scanning all packages first. Directories
+which changed since the file was written are rescanned; the search index is
+rebuilt if any did.
+
+While serving, godoc periodically checks the file system for added, removed
+and modified package files and updates the package tree accordingly; only
+the changed directories are re-read. If the -index flag is set, the index is
+rebuilt after each change. The check interval is set with the -sync_interval
+flag.
 
 By default, godoc uses the system's GOOS/GOARCH. You can provide the URL parameters
 "GOOS" and "GOARCH" to set the output on the web page for the target system.
+Likewise, the URL parameter "tags" sets a comma-separated list of build
+tags and "cgo" (0 or 1) disables or enables cgo, overriding the -tags and
+-cgo flags; for instance, /pkg/example.com/app/?tags=integration,noasm&cgo=0.
+The links of package pages carry these parameters, like the "m" parameter
+described below.
 
 The presentation mode of web pages served by godoc can be controlled with the
 "m" URL parameter; it accepts a comma-separated list of flag names as value:
@@ -60,10 +161,96 @@ The presentation mode of web pages served by godoc can be controlled with the
 	methods	show all embedded methods, not just those of unexported anonymous fields
 	src	show the original source code rather than the extracted documentation
 	flat	present flat (not indented) directory listings using full paths
+	types	type-check the package to link identifiers to their declarations
+	importers	list all packages importing the package, directly or indirectly
+	platforms	show the declarations for all platforms of -platforms, marking those not available on all of them
+	md	render the package documentation as Markdown rather than HTML
 
 For instance, https://golang.org/pkg/math/big/?m=all shows the documentation
 for all (not just the exported) declarations of package big.
 
+In the md mode, package pages are served as Markdown documents, with the
+package documentation, index, declarations in fenced go code blocks,
+examples and notes, for inclusion in wikis and README files. Combined with
+-url, it prints the Markdown of a package; for instance,
+godoc -url=/pkg/math/big/?m=md.
+
+Package pages list the packages the package imports and the packages of the
+served directory tree importing it. The import graph is computed from the
+imports of all package files, regardless of build constraints, whenever the
+directory tree is built or synced.
+
+The /graph/<importpath> page renders the graph of the packages imported by a
+package, directly or indirectly, as SVG, or as Graphviz DOT with format=dot.
+Standard library and third-party packages are drawn in different colors. The
+URL parameters are:
+
+	depth	maximum distance of the packages from the package (default 2; 0 means no limit)
+	filter	regular expression the import paths of the packages must match
+	std	if false, exclude standard library packages
+	format	svg (default) or dot
+
+For instance, /graph/net/http?depth=1&format=dot describes the packages
+imported by net/http in DOT.
+
+godoc runs in module mode when the current directory or one of its parents
+contains a go.mod file, unless GO111MODULE is off. The build list of the
+main module is then computed from the go.mod files in the module cache
+($GOMODCACHE, or $GOPATH/pkg/mod), without running the go command or
+accessing the network: the replace and exclude directives of the main module
+are honored, and modules missing from the module cache are not downloaded but
+listed on the /diagnostics page, to be downloaded with go mod download.
+
+If the main module has a vendor/modules.txt file and declares go 1.14 or
+higher, godoc runs in vendor mode, as the go command does, unless GOFLAGS sets
+-mod to another mode than vendor; -mod=vendor selects it for any version: the
+build list is read from modules.txt, and the packages of the vendor directory
+are served under their import paths instead of those of the module cache.
+Their package pages show the module version they are vendored from.
+
+In a go.work workspace, found as by the go command or given by -workspace,
+the modules of its use directives are all served as main modules, under their
+module paths, and the package root page groups their packages by module.
+
+In module mode, the versions of the modules in the module cache are also
+served at version-qualified paths, side by side with the versions of the
+build list, as in
+
+	/pkg/example.com/lib@v1.8.0/
+	/pkg/example.com/lib@v2.3.0/sub/
+
+The package pages of the modules in the module cache link to the other
+versions of their module.
+
+With -modcache, godoc serves the module cache only, even in a module: the
+/mod/ page lists its modules with all their versions, extracted or only
+downloaded to the cache/download directory, and each version is browsable
+under /mod/, as in
+
+	/mod/example.com/lib@v1.8.0/sub/
+
+The /apidiff page compares the exported API of a package in two package
+trees, such as a module at two versions in the module cache, or GOROOT and
+a branch checkout. Its URL parameters are the roots of the old and new trees
+and the package directory in them, as in
+
+	/apidiff?old=$GOROOT/src&new=$HOME/go-branch/src&pkg=net/http
+
+Added, removed and changed declarations are shown and classified as
+compatible or incompatible with clients of the old API. The roots must be
+inside the -apidiff_roots directories.
+
+With -export, godoc writes the documentation as a self-contained static site
+to a directory, for publishing on a file server, as in
+
+	godoc -export=/tmp/docs
+
+The package and command pages of the directory tree are exported with the
+source files and static assets they link to, with their links rewritten to
+relative links; dynamic pages, such as search results, are not. Files whose
+content is unchanged are not rewritten, so that the site can be updated in
+place.
+
 By default, godoc serves files from the file system of the underlying OS.
 Instead, a .zip file may be provided via the -zip flag, which contains
 the file system to serve. The file paths stored in the .zip file must use
@@ -83,6 +270,5 @@ Godoc also shows example code that is runnable by the testing package;
 see https://golang.org/pkg/testing/#hdr-Examples for the conventions.
 See "Godoc: documenting Go code" for how to write good comments for godoc:
 https://golang.org/doc/articles/godoc_documenting_go_code.html
-
 */
 package main // import "golang.org/x/tools/cmd/godoc"
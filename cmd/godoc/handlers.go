@@ -66,6 +66,13 @@ func readTemplates(p *godoc.Presentation) {
 
 	p.PackageRootHTML = readTemplate("packageroot.html")
 	p.PackageHTML = readTemplate("package.html")
+	p.PackageMarkdown = readTemplate("package.md")
+	p.SearchHTML = readTemplate("search.html")
+	p.RefsHTML = readTemplate("refs.html")
+	p.DeprecatedHTML = readTemplate("deprecated.html")
+	p.APIDiffHTML = readTemplate("apidiff.html")
+	p.ModulesHTML = readTemplate("modules.html")
+	p.DiagnosticsHTML = readTemplate("diagnostics.html")
 
 	p.DirlistHTML = readTemplate("dirlist.html")
 	p.ErrorHTML = readTemplate("error.html")
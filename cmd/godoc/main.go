@@ -15,31 +15,32 @@ package main
 import (
 	"archive/zip"
 	"bytes"
-	"encoding/json"
-	_ "expvar" // to serve /debug/vars
+	"expvar" // also serves /debug/vars
 	"flag"
 	"fmt"
 	"go/build"
-	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	_ "net/http/pprof" // to serve /debug/pprof/*
 	"net/url"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
-	"golang.org/x/xerrors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
 
 	"github.com/miclle/godoc"
 	"github.com/miclle/godoc/static"
 	"github.com/miclle/godoc/vfs"
 	"github.com/miclle/godoc/vfs/gatefs"
 	"github.com/miclle/godoc/vfs/mapfs"
+	"github.com/miclle/godoc/vfs/modfs"
 	"github.com/miclle/godoc/vfs/zipfs"
 )
 
@@ -56,6 +57,10 @@ var (
 	// layout control
 	urlFlag = flag.String("url", "", "print HTML for named URL")
 
+	// static site export
+	exportDir     = flag.String("export", "", "write the documentation as a static HTML site to the given directory")
+	exportWorkers = flag.Int("export_workers", 0, "number of pages rendered in parallel by -export; if 0, GOMAXPROCS")
+
 	verbose = flag.Bool("v", false, "verbose mode")
 
 	// file system roots
@@ -67,9 +72,31 @@ var (
 	templateDir    = flag.String("templates", "", "load templates/JS/CSS from disk in this directory")
 	showPlayground = flag.Bool("play", false, "enable playground")
 	declLinks      = flag.Bool("links", true, "link identifiers to their declarations")
+	typeCheck      = flag.Bool("typecheck", false, "type-check packages to link identifiers to their declarations exactly")
+	implements     = flag.Bool("implements", false, "show the implements relations among the exported types of all packages")
+	platforms      = flag.String("platforms", "", "comma-separated list of GOOS/GOARCH platforms shown by ?m=platforms; if empty, common platforms are shown")
+	buildTags      = flag.String("tags", "", "comma-separated list of build tags selecting the package files documented by default")
+	cgoEnabled     = flag.String("cgo", "", "if 1 or 0, enable or disable cgo when selecting the package files documented by default")
+	apiDiffRoots   = flag.String("apidiff_roots", "", "list of directories containing the package trees compared by /apidiff; defaults to GOROOT and GOPATH")
+	workspace      = flag.String("workspace", "", "go.work file of the workspace whose modules are served; if empty, $GOWORK or the go.work file of the current directory or its parents")
+	modCacheMode   = flag.Bool("modcache", false, "serve all the module versions of the module cache at /mod/, needing neither a go.mod file nor network access")
+	apiFiles       = flag.String("api_files", "", "list of glob patterns, separated as in GOPATH, matching API files of modules written by apigen")
 
 	// source code notes
 	notesRx = flag.String("notes", "BUG", "regular expression matching note markers to show")
+
+	// search index
+	indexEnabled  = flag.Bool("index", false, "enable search index")
+	maxResults    = flag.Int("maxresults", 10000, "maximum number of full text search results shown")
+	indexThrottle = flag.Float64("index_throttle", 0.75, "index throttle value; 0.0 = no time allocated, 1.0 = full throttle")
+	indexFiles    = flag.String("index_files", "", "glob pattern specifying index files; if not empty, the index is read from these files in sorted order")
+	writeIndex    = flag.Bool("write_index", false, "write index to a file; the file name must be specified with -index_files")
+
+	// file system sync
+	syncInterval = flag.Duration("sync_interval", 10*time.Second, "interval between checks of the file system for changes; 0 disables")
+
+	// caching
+	pageInfoCacheSize = flag.Int64("pageinfo_cache", 64, "maximum size in MB of the cache of package information for package pages; 0 disables")
 )
 
 // An httpResponseRecorder is an http.ResponseWriter
@@ -132,11 +159,14 @@ func handleURLFlag() {
 	log.Fatalf("too many redirects")
 }
 
+// initCorpus initializes the corpus and waits until its search index
+// and implements analysis, if enabled, are built.
 func initCorpus(corpus *godoc.Corpus) {
 	err := corpus.Init()
 	if err != nil {
 		log.Fatal(err)
 	}
+	corpus.WaitIndex()
 }
 
 func main() {
@@ -148,8 +178,12 @@ func main() {
 		fmt.Fprintln(os.Stderr, `Unexpected arguments. Use "go doc" for command-line help output instead. For example, "go doc fmt.Printf".`)
 		usage()
 	}
-	if *httpAddr == "" && *urlFlag == "" {
-		fmt.Fprintln(os.Stderr, "At least one of -http, -url, or -write_index must be set to a non-zero value.")
+	if *httpAddr == "" && *urlFlag == "" && !*writeIndex && *exportDir == "" {
+		fmt.Fprintln(os.Stderr, "At least one of -http, -url, -export, or -write_index must be set to a non-zero value.")
+		usage()
+	}
+	if *cgoEnabled != "" && *cgoEnabled != "0" && *cgoEnabled != "1" {
+		fmt.Fprintln(os.Stderr, "The -cgo flag must be 0 or 1.")
 		usage()
 	}
 
@@ -178,40 +212,70 @@ func main() {
 		fs.Bind("/lib/godoc", mapfs.New(static.Files), "/", vfs.BindReplace)
 	}
 
-	// Get the GOMOD value, use it to determine if godoc is being invoked in module mode.
-	goModFile, err := goMod()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to determine go env GOMOD value: %v", err)
-		goModFile = "" // Fall back to GOPATH mode.
+	// Find the go.work file of the workspace or the go.mod file of the
+	// main module, if any, to determine if godoc is being invoked in
+	// module mode. The go command isn't run, so that godoc works offline.
+	var goWorkFile, goModFile string
+	if !*modCacheMode {
+		goWorkFile = *workspace
+		if wd, err := os.Getwd(); err == nil {
+			if goWorkFile == "" {
+				goWorkFile = godoc.FindGoWork(wd)
+			}
+			if goWorkFile == "" {
+				goModFile = godoc.FindGoMod(wd)
+			}
+		}
 	}
 
-	if goModFile != "" {
-		fmt.Printf("using module mode; GOMOD=%s\n", goModFile)
-
-		// Try to download dependencies that are not in the module cache in order to
-		// to show their documentation.
-		// This may fail if module downloading is disallowed (GOPROXY=off) or due to
-		// limited connectivity, in which case we print errors to stderr and show
-		// documentation only for packages that are available.
-		fillModuleCache(os.Stderr, goModFile)
-
-		// Determine modules in the build list.
-		mods, err := buildList(goModFile)
+	var modCache string // module cache whose module versions are served
+	var buildList *godoc.BuildList
+	switch {
+	case *modCacheMode:
+		// Serve the module versions of the module cache only.
+		modCache = moduleCacheDir()
+		fmt.Printf("using module cache mode; GOMODCACHE=%s\n", modCache)
+
+	case goWorkFile != "" || goModFile != "":
+		modCache = moduleCacheDir()
+
+		// Determine modules in the build list from the go.mod files in
+		// the module cache. Modules which are not in the module cache
+		// are not downloaded, but reported on the /diagnostics page.
+		var err error
+		if goWorkFile != "" {
+			fmt.Printf("using workspace mode; GOWORK=%s\n", goWorkFile)
+			buildList, err = godoc.LoadWorkspaceBuildList(goWorkFile, modCache)
+		} else if vendor := godoc.VendorModules(goModFile); vendor != "" && vendorMode(goModFile) {
+			// Serve the vendored packages rather than the module cache.
+			fmt.Printf("using vendor mode; %s\n", vendor)
+			buildList, err = godoc.LoadVendorBuildList(goModFile)
+		} else {
+			fmt.Printf("using module mode; GOMOD=%s\n", goModFile)
+			buildList, err = godoc.LoadBuildList(goModFile, modCache)
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "failed to determine the build list of the main module: %v", err)
+			fmt.Fprintf(os.Stderr, "failed to determine the build list of the main module: %v\n", err)
 			os.Exit(1)
 		}
+		for _, e := range buildList.Errors {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		if missing := buildList.Missing(); len(missing) > 0 {
+			fmt.Fprintf(os.Stderr, "%d modules of the build list are missing; see /diagnostics\n", len(missing))
+		}
 
 		// Bind module trees into Go root.
-		for _, m := range mods {
-			if m.Dir == "" {
+		for _, m := range buildList.Modules {
+			if m.FS == nil {
 				// Module is not available in the module cache, skip it.
 				continue
 			}
 			dst := path.Join("/src", m.Path)
-			fs.Bind(dst, gatefs.New(vfs.OS(m.Dir), fsGate), "/", vfs.BindAfter)
+			fs.Bind(dst, gatefs.New(m.FS, fsGate), "/", vfs.BindAfter)
 		}
-	} else {
+
+	default:
 		fmt.Println("using GOPATH mode")
 
 		// Bind $GOPATH trees into Go root.
@@ -221,17 +285,78 @@ func main() {
 	}
 
 	var corpus *godoc.Corpus
-	if goModFile != "" {
-		corpus = godoc.NewCorpus(moduleFS{fs})
-	} else {
+	switch {
+	case modCache != "":
+		// Serve the module versions of the module cache at
+		// /src/<module>@<version>, looked up on demand.
+		corpus = godoc.NewCorpus(newModuleFS(modfs.New(fs, modCache), buildList))
+		corpus.ModuleCache = modCache
+		corpus.BuildList = buildList
+	case buildList != nil:
+		corpus = godoc.NewCorpus(newModuleFS(fs, buildList))
+	default:
 		corpus = godoc.NewCorpus(fs)
 	}
 	corpus.Verbose = *verbose
+	corpus.MaxResults = *maxResults
+	corpus.IndexEnabled = *indexEnabled
+	if *maxResults <= 0 {
+		corpus.IndexFullText = false
+	}
+	corpus.IndexThrottle = *indexThrottle
+	corpus.ImplementsEnabled = *implements
+	if *platforms != "" {
+		corpus.Platforms = strings.Split(*platforms, ",")
+	}
+	corpus.BuildConfig = godoc.BuildConfig{Tags: *buildTags, Cgo: *cgoEnabled}
+	if *apiDiffRoots != "" {
+		corpus.APIDiffRoots = filepath.SplitList(*apiDiffRoots)
+	} else {
+		corpus.APIDiffRoots = append([]string{*goroot}, filepath.SplitList(build.Default.GOPATH)...)
+	}
+	if *apiFiles != "" {
+		corpus.APIFiles = filepath.SplitList(*apiFiles)
+	}
 
-	if *urlFlag != "" {
+	corpus.SyncInterval = *syncInterval
+	corpus.PageInfoCacheSize = *pageInfoCacheSize << 20
+	expvar.Publish("pageinfo_cache", expvar.Func(func() interface{} {
+		return corpus.PageInfoCacheStats()
+	}))
+
+	if *writeIndex {
+		if *indexFiles == "" {
+			log.Fatal("no index file specified")
+		}
+		corpus.IndexThrottle = 1.0
+		initCorpus(corpus)
+
+		log.Println("writing index file", *indexFiles)
+		f, err := os.Create(*indexFiles)
+		if err != nil {
+			log.Fatal(err)
+		}
+		n, err := corpus.WriteIndexTo(f)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("index file written (%d bytes)", n)
+		return
+	}
+	corpus.IndexFiles = *indexFiles
+
+	if *urlFlag != "" || *exportDir != "" {
 		initCorpus(corpus)
 	} else {
-		go initCorpus(corpus)
+		go func() {
+			initCorpus(corpus)
+			if *syncInterval > 0 {
+				corpus.RunSync()
+			}
+		}()
 	}
 
 	// Initialize the version info before readTemplates, which saves
@@ -242,6 +367,11 @@ func main() {
 	pres.ShowTimestamps = *showTimestamps
 	pres.ShowPlayground = *showPlayground
 	pres.DeclLinks = *declLinks
+	if *typeCheck {
+		pres.AdjustPageInfoMode = func(_ *http.Request, mode godoc.PageInfoMode) godoc.PageInfoMode {
+			return mode | godoc.TypeCheck
+		}
+	}
 	if *notesRx != "" {
 		pres.NotesRx = regexp.MustCompile(*notesRx)
 	}
@@ -255,6 +385,20 @@ func main() {
 		return
 	}
 
+	// Write the documentation as a static site to *exportDir.
+	if *exportDir != "" {
+		e := &godoc.Exporter{
+			Handler: http.DefaultServeMux,
+			Dir:     *exportDir,
+			Workers: *exportWorkers,
+			Raw:     pres.ServesRawFile,
+			Logf:    log.Printf,
+		}
+		stats := e.Export(corpus.ExportPaths())
+		log.Printf("exported %d pages to %s: %d files written, %d errors", stats.Pages, *exportDir, stats.Written, stats.Errors)
+		return
+	}
+
 	var handler http.Handler = http.DefaultServeMux
 	if *verbose {
 		log.Printf("Go Documentation Server")
@@ -275,121 +419,51 @@ func main() {
 	}
 }
 
-// goMod returns the go env GOMOD value in the current directory
-// by invoking the go command.
-//
-// GOMOD is documented at https://golang.org/cmd/go/#hdr-Environment_variables:
-//
-// 	The absolute path to the go.mod of the main module,
-// 	or the empty string if not using modules.
-//
-func goMod() (string, error) {
-	out, err := exec.Command("go", "env", "-json", "GOMOD").Output()
-	if ee := (*exec.ExitError)(nil); xerrors.As(err, &ee) {
-		return "", fmt.Errorf("go command exited unsuccessfully: %v\n%s", ee.ProcessState.String(), ee.Stderr)
-	} else if err != nil {
-		return "", err
-	}
-	var env struct {
-		GoMod string
+// vendorMode reports whether the vendored packages of the main module
+// of the go.mod file goMod are served. As for the go command, it is the
+// default when the main module has a vendor/modules.txt file and declares
+// go 1.14 or higher, unless GOFLAGS sets -mod to another mode than vendor.
+func vendorMode(goMod string) bool {
+	for _, f := range strings.Fields(os.Getenv("GOFLAGS")) {
+		if strings.HasPrefix(f, "-mod=") || strings.HasPrefix(f, "--mod=") {
+			return f[strings.Index(f, "=")+1:] == "vendor"
+		}
 	}
-	err = json.Unmarshal(out, &env)
+	data, err := ioutil.ReadFile(goMod)
 	if err != nil {
-		return "", err
+		return false
 	}
-	return env.GoMod, nil
-}
-
-// fillModuleCache does a best-effort attempt to fill the module cache
-// with all dependencies of the main module in the current directory
-// by invoking the go command. Module download logs are streamed to w.
-// If there are any problems encountered, they are also written to w.
-// It should only be used when operating in module mode.
-//
-// See https://golang.org/cmd/go/#hdr-Download_modules_to_local_cache.
-func fillModuleCache(w io.Writer, goMod string) {
-	if goMod == os.DevNull {
-		// No module requirements, nothing to do.
-		return
+	f, err := modfile.ParseLax(goMod, data, nil)
+	if err != nil || f.Go == nil {
+		return false
 	}
-
-	cmd := exec.Command("go", "mod", "download", "-json")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = w
-	err := cmd.Run()
-	if ee := (*exec.ExitError)(nil); xerrors.As(err, &ee) && ee.ExitCode() == 1 {
-		// Exit code 1 from this command means there were some
-		// non-empty Error values in the output. Print them to w.
-		fmt.Fprintf(w, "documentation for some packages is not shown:\n")
-		for dec := json.NewDecoder(&out); ; {
-			var m struct {
-				Path    string // Module path.
-				Version string // Module version.
-				Error   string // Error loading module.
-			}
-			err := dec.Decode(&m)
-			if err == io.EOF {
-				break
-			} else if err != nil {
-				fmt.Fprintf(w, "error decoding JSON object from go mod download -json: %v\n", err)
-				continue
-			}
-			if m.Error == "" {
-				continue
-			}
-			fmt.Fprintf(w, "\tmodule %s@%s is not in the module cache and there was a problem downloading it: %s\n", m.Path, m.Version, m.Error)
-		}
-	} else if err != nil {
-		fmt.Fprintf(w, "there was a problem filling module cache: %v\n", err)
+	v := f.Go.Version
+	if i := strings.IndexAny(v, "abcdefghijklmnopqrstuvwxyz"); i >= 0 {
+		v = v[:i] // pre-release, such as 1.21rc1
 	}
+	return semver.Compare("v"+v, "v1.14") >= 0
 }
 
-// buildList determines the build list in the current directory
-// by invoking the go command. It should only be used when operating
-// in module mode.
-//
-// See https://golang.org/cmd/go/#hdr-The_main_module_and_the_build_list.
-func buildList(goMod string) ([]mod, error) {
-	if goMod == os.DevNull {
-		// Empty build list.
-		return nil, nil
-	}
-
-	out, err := exec.Command("go", "list", "-m", "-json", "all").Output()
-	if ee := (*exec.ExitError)(nil); xerrors.As(err, &ee) {
-		return nil, fmt.Errorf("go command exited unsuccessfully: %v\n%s", ee.ProcessState.String(), ee.Stderr)
-	} else if err != nil {
-		return nil, err
-	}
-	var mods []mod
-	for dec := json.NewDecoder(bytes.NewReader(out)); ; {
-		var m mod
-		err := dec.Decode(&m)
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		mods = append(mods, m)
+// moduleCacheDir returns the module cache directory of the go command,
+// $GOMODCACHE or else $GOPATH/pkg/mod, without running the go command.
+func moduleCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
 	}
-	return mods, nil
-}
-
-type mod struct {
-	Path string // Module path.
-	Dir  string // Directory holding files for this module, if any.
+	return filepath.Join(filepath.SplitList(build.Default.GOPATH)[0], "pkg", "mod")
 }
 
 // moduleFS is a vfs.FileSystem wrapper used when godoc is running
 // in module mode. It's needed so that packages inside modules are
 // considered to be third party.
 //
-// It overrides the RootType method of the underlying filesystem
-// and implements it using a heuristic based on the import path.
-// If the first element of the import path does not contain a dot,
-// that package is considered to be inside GOROOT. If it contains
-// a dot, then that package is considered to be third party.
+// It overrides the RootType method of the underlying filesystem.
+// The packages of the modules of the build list, such as those of
+// the modules of a workspace, are third party. For the others, it
+// uses a heuristic based on the import path. If the first element
+// of the import path does not contain a dot, that package is
+// considered to be inside GOROOT. If it contains a dot, then that
+// package is considered to be third party.
 //
 // TODO(dmitshur): The RootType abstraction works well when GOPATH
 // workspaces are bound at their roots, but scales poorly in the
@@ -398,13 +472,37 @@ type mod struct {
 //
 type moduleFS struct {
 	vfs.FileSystem
+	modules map[string]bool // paths of the modules of the build list
 }
 
-func (moduleFS) RootType(path string) vfs.RootType {
+// newModuleFS returns a moduleFS for the file system fs serving the
+// modules of the build list b, which may be nil.
+func newModuleFS(fs vfs.FileSystem, b *godoc.BuildList) moduleFS {
+	modules := make(map[string]bool)
+	if b != nil {
+		for _, m := range b.Modules {
+			modules[m.Path] = true
+		}
+	}
+	return moduleFS{fs, modules}
+}
+
+func (fs moduleFS) RootType(path string) vfs.RootType {
 	if !strings.HasPrefix(path, "/src/") {
 		return ""
 	}
 
+	for p := path[len("/src/"):]; ; {
+		if fs.modules[p] {
+			return vfs.RootTypeGoPath
+		}
+		i := strings.LastIndex(p, "/")
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+
 	domain := path[len("/src/"):]
 	if i := strings.Index(domain, "/"); i >= 0 {
 		domain = domain[:i]
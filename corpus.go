@@ -3,7 +3,6 @@ package godoc
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"go/ast"
 	"go/build"
 	"go/doc"
@@ -15,6 +14,8 @@ import (
 	"path"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -45,16 +46,108 @@ type Corpus struct {
 	// package listing.
 	SummarizePackage func(pkg string) (summary string, showList, ok bool)
 
+	// IndexEnabled controls whether indexing is enabled.
+	IndexEnabled bool
+
+	// IndexFullText controls whether a full text index is built
+	// in addition to the identifier index.
+	IndexFullText bool
+
+	// IndexThrottle specifies the indexing throttle value
+	// between 0.0 and 1.0. At 0.0, the indexer always sleeps.
+	// At 1.0, the indexer never sleeps. Because 0.0 is useless
+	// and redundant with setting IndexEnabled to false, the
+	// zero value for IndexThrottle means 0.9.
+	IndexThrottle float64
+
+	// MaxResults optionally specifies the maximum results for indexing.
+	MaxResults int
+
+	// ImplementsEnabled controls whether the implements relations
+	// of the exported named types of the corpus are computed (see
+	// Implementations). This type-checks all packages.
+	ImplementsEnabled bool
+
+	// IndexFiles optionally specifies a glob pattern of files
+	// written by WriteIndexTo. If set, Init reads the directory
+	// tree and search index from the matching files, in sorted
+	// order, instead of scanning the file system. Directories
+	// which changed since the files were written are rescanned,
+	// and the search index is rebuilt in that case. If the files
+	// can't be read, Init scans the file system as usual.
+	IndexFiles string
+
+	// PageInfoCacheSize is the maximum estimated memory size in bytes
+	// of the package information cached for package pages; see
+	// PageInfoCacheStats. Zero disables the cache.
+	PageInfoCacheSize int64
+
+	// BuildConfig specifies the default build configuration selecting
+	// the package files documented on package pages. The settings of
+	// a request override it; unset fields default to build.Default.
+	BuildConfig BuildConfig
+
+	// Platforms optionally specifies the platforms, as "GOOS/GOARCH",
+	// for which packages are documented in the AllPlatforms mode.
+	// If empty, a list of common platforms is used.
+	Platforms []string
+
+	// APIDiffRoots lists the directories of the operating system's
+	// file system which may contain the package trees compared by
+	// APIDiff and the /apidiff page. If empty, API diffs are disabled.
+	APIDiffRoots []string
+
+	// APIFiles optionally lists glob patterns of the operating
+	// system's file system matching API files of modules, such as
+	// those written by WriteModuleAPIFiles. InitVersionInfo loads
+	// them with $GOROOT/api/go*.txt.
+	APIFiles []string
+
+	// ModuleCache optionally specifies the module cache directory,
+	// such as $GOPATH/pkg/mod, whose module versions are documented
+	// at version-qualified paths, such as /pkg/example.com/lib@v1.8.0/,
+	// with a version switcher on the package pages of their modules.
+	// The corpus file system must serve the module versions at
+	// /src/<module>@<version>, as modfs does.
+	ModuleCache string
+
+	// BuildList optionally specifies the build list of the main module
+	// in module mode, whose missing modules are reported on the
+	// /diagnostics page.
+	BuildList *BuildList
+
+	// SyncInterval specifies the time between two checks of the
+	// file system for changes by RunSync. The zero value means
+	// 10 seconds.
+	SyncInterval time.Duration
+
 	// file system information
-	fsTree      util.RWValue // *Directory tree of packages, updated with each sync (but sync code is removed now)
+	fsTree      util.RWValue // *Directory tree of packages, updated with each sync
+	importGraph util.RWValue // *importGraph of the packages in fsTree; see setFSTree
 	docMetadata util.RWValue // mapping from paths to *Metadata
 
+	// sync state
+	syncMu   sync.Mutex
+	fsStamps map[string]dirStamp // stamps of the directories read for fsTree
+
+	// search index
+	searchIndex util.RWValue // *Index
+
+	// implements analysis
+	implements util.RWValue // implementsIndex
+
+	pageInfoCache pageInfoCache
+
+	// modules and module versions read from ModuleCache, for a sync interval
+	moduleCache moduleCache
+
 	// flag to check whether a corpus is initialized or not
 	initMu   sync.RWMutex
 	initDone bool
+	indexed  chan struct{} // closed once the indexes started by Init are built
 
 	// pkgAPIInfo contains the information about which package API
-	// features were added in which version of Go.
+	// features were added in which version of Go or of their module.
 	pkgAPIInfo apiVersions
 }
 
@@ -64,32 +157,83 @@ type Corpus struct {
 func NewCorpus(fs vfs.FileSystem) *Corpus {
 	c := &Corpus{
 		fs: fs,
+
+		MaxResults:        1000,
+		IndexEnabled:      true,
+		IndexFullText:     true,
+		PageInfoCacheSize: 64 << 20,
+
+		indexed: make(chan struct{}),
 	}
 	return c
 }
 
 // Init initializes Corpus, once options on Corpus are set.
 // It must be called before any subsequent method calls.
+//
+// If IndexEnabled is set, Init starts building the search index in
+// the background once the directory tree is complete, and so for
+// the implements analysis if ImplementsEnabled is set. Package
+// documentation is served while they are being built; WaitIndex
+// waits for them.
 func (c *Corpus) Init() error {
-	if err := c.initFSTree(); err != nil {
-		return err
+	loaded, indexed := false, false
+	if c.IndexFiles != "" {
+		var err error
+		if indexed, err = c.readIndexFiles(); err != nil {
+			log.Printf("reading index files %s: %v; scanning file system", c.IndexFiles, err)
+		} else {
+			loaded = true
+		}
+	}
+	if !loaded {
+		if err := c.initFSTree(); err != nil {
+			return err
+		}
 	}
 
 	c.initMu.Lock()
 	c.initDone = true
 	c.initMu.Unlock()
+
+	go func() {
+		defer close(c.indexed)
+		if c.IndexEnabled && !indexed {
+			c.updateIndex()
+		}
+		if c.ImplementsEnabled {
+			c.updateImplements()
+		}
+	}()
 	return nil
 }
 
+// WaitIndex waits until the search index and the implements analysis
+// started by Init, if enabled, are built. It must be called after Init.
+func (c *Corpus) WaitIndex() {
+	<-c.indexed
+}
+
 func (c *Corpus) initFSTree() error {
-	dir := c.newDirectory("/", -1)
+	stamps := make(map[string]dirStamp)
+	dir := c.buildDirectory("/", -1, stamps)
 	if dir == nil {
 		return errors.New("godoc: corpus fstree is nil")
 	}
-	c.fsTree.Set(dir)
+	c.syncMu.Lock()
+	c.fsStamps = stamps
+	c.syncMu.Unlock()
+	c.setFSTree(dir)
 	return nil
 }
 
+// setFSTree installs tree as the directory tree of the corpus
+// and updates the import graph.
+func (c *Corpus) setFSTree(tree *Directory) {
+	c.fsTree.Set(tree)
+	c.importGraph.Set(newImportGraph(tree))
+}
+
 // Directory return tree with abspath
 func (c *Corpus) Directory(abspath string) (*Directory, time.Time) {
 
@@ -121,21 +265,33 @@ func (c *Corpus) Directory(abspath string) (*Directory, time.Time) {
 	return directory, timestamp
 }
 
-// SyntaxAnalysis parse dir
-func (c *Corpus) SyntaxAnalysis(abspath string) error {
-
-	mode := NoFiltering
+// SyntaxAnalysis parses the package in the directory abspath and
+// returns its model. Only the package files that would be used when
+// building the package for goos and goarch with the build tags and
+// cgo setting of c.BuildConfig are considered; if goos and goarch are
+// empty, those of c.BuildConfig or the current binary's are used. The mode
+// controls the package information extracted, as for package pages:
+// with ShowSource set, Package.PAst holds the (export-filtered) ASTs
+// of the package files instead of the package documentation.
+//
+// If the directory contains no Go files, SyntaxAnalysis returns a
+// Package with no documentation and no error.
+func (c *Corpus) SyntaxAnalysis(abspath string, mode PageInfoMode, goos, goarch string) (*Package, error) {
+	return c.syntaxAnalysis(abspath, srcImportPath(abspath), mode, BuildConfig{GOOS: goos, GOARCH: goarch})
+}
 
-	p := &Package{
-		Dir: abspath,
-	}
+// srcImportPath returns the import path of the package in the
+// directory abspath of the corpus file system.
+func srcImportPath(abspath string) string {
+	rel := strings.TrimPrefix(path.Clean(abspath), "/src")
+	return path.Clean(strings.TrimPrefix(rel, "/"))
+}
 
-	// Restrict to the package files that would be used when building
-	// the package on this system.  This makes sure that if there are
-	// separate implementations for, say, Windows vs Unix, we don't
-	// jumble them all together.
-	// Note: If goos/goarch aren't set, the current binary's GOOS/GOARCH
-	// are used.
+// buildContext returns the build context used to select the package
+// files for cfg, reading from the corpus file system. The settings of
+// cfg override those of c.BuildConfig. Directories named "internal" are
+// hidden unless mode has NoFiltering set.
+func (c *Corpus) buildContext(mode PageInfoMode, cfg BuildConfig) build.Context {
 	ctxt := build.Default
 	ctxt.IsAbsPath = path.IsAbs
 	ctxt.IsDir = func(path string) bool {
@@ -159,31 +315,63 @@ func (c *Corpus) SyntaxAnalysis(abspath string) error {
 		}
 		return ioutil.NopCloser(bytes.NewReader(data)), nil
 	}
+	c.BuildConfig.override(cfg).apply(&ctxt)
+	return ctxt
+}
+
+// syntaxAnalysis implements SyntaxAnalysis for the package with the
+// given import path. The keys of Package.PAst are the file names
+// joined with importPath.
+func (c *Corpus) syntaxAnalysis(abspath, importPath string, mode PageInfoMode, cfg BuildConfig) (*Package, error) {
+	importPath = path.Clean(importPath) // no trailing '/' in importpath
 
 	// Make the syscall/js package always visible by default.
 	// It defaults to the host's GOOS/GOARCH, and golang.org's
 	// linux/amd64 means the wasm syscall/js package was blank.
 	// And you can't run godoc on js/wasm anyway, so host defaults
 	// don't make sense here.
-	var goos, goarch string
-	if goos != "" {
-		ctxt.GOOS = goos
-	}
-	if goarch != "" {
-		ctxt.GOARCH = goarch
+	if importPath == "syscall/js" {
+		if def := c.BuildConfig.override(cfg); def.GOOS == "" && def.GOARCH == "" {
+			cfg.GOOS, cfg.GOARCH = "js", "wasm"
+		}
 	}
 
-	pkginfo, err := ctxt.ImportDir(abspath, 0)
+	// Restrict to the package files that would be used when building
+	// the package on this system.  This makes sure that if there are
+	// separate implementations for, say, Windows vs Unix, we don't
+	// jumble them all together.
+	ctxt := c.buildContext(mode, cfg)
+	var (
+		pkginfo   *build.Package
+		platforms *PlatformInfo
+		err       error
+	)
+	if mode&AllPlatforms != 0 {
+		// use the package files of all platforms instead
+		pkginfo, platforms, err = c.mergePlatforms(abspath, mode, cfg)
+	}
+	if pkginfo == nil {
+		perr := err
+		pkginfo, err = ctxt.ImportDir(abspath, 0)
+		if perr != nil && err == nil {
+			// a package, but none for the platforms of c
+			log.Printf("%s: documenting the package files for %s/%s only: %v", abspath, ctxt.GOOS, ctxt.GOARCH, perr)
+		}
+	}
 	// continue if there are no Go source files; we still want the directory info
 	if _, nogo := err.(*build.NoGoError); err != nil && !nogo {
-		return err
+		return nil, err
+	}
+
+	p := &Package{
+		Dir:        abspath,
+		ImportPath: importPath,
+		Imports:    pkginfo.Imports,
+		ImportedBy: c.Importers(srcImportPath(abspath)),
 	}
 
 	// collect package files
 	pkgname := pkginfo.Name
-
-	fmt.Println("pkgname: ", pkgname)
-
 	pkgfiles := append(pkginfo.GoFiles, pkginfo.CgoFiles...)
 	if len(pkgfiles) == 0 {
 		// Commands written in C have no .go files in the build.
@@ -196,77 +384,141 @@ func (c *Corpus) SyntaxAnalysis(abspath string) error {
 	}
 
 	// get package information, if any
-	if len(pkgfiles) > 0 {
-		// build package AST
-		fset := token.NewFileSet()
-		files, err := c.parseFiles(fset, "", abspath, pkgfiles)
-		if err != nil {
-			return err
+	if len(pkgfiles) == 0 {
+		return p, nil
+	}
+
+	// build package AST
+	fset := token.NewFileSet()
+	files, err := c.parseFiles(fset, importPath, abspath, pkgfiles)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Name = pkgname
+	p.Filenames = pkgfiles
+	p.FSet = fset
+	p.IsMain = pkgname == "main"
+	p.ImportComment = importComment(fset, files)
+	if platforms != nil {
+		for name, file := range files {
+			platforms.addDecls(path.Base(name), file)
 		}
+		p.Platforms = platforms
+	}
+
+	// type-check before go/doc edits the ASTs; the files
+	// of several platforms don't make a valid package
+	if mode&TypeCheck != 0 && platforms == nil {
+		p.TypesPkg, p.TypesInfo = c.typeCheck(&ctxt, fset, importPath, files)
+	}
 
-		// ignore any errors - they are due to unresolved identifiers
-		pkg, _ := ast.NewPackage(fset, files, poorMansImporter, nil)
+	// ignore any errors - they are due to unresolved identifiers
+	pkg, _ := ast.NewPackage(fset, files, poorMansImporter, nil)
 
-		// extract package documentation
-		p.FSet = fset
-		if mode&ShowSource == 0 {
-			// show extracted documentation
-			var m doc.Mode
-			if mode&NoFiltering != 0 {
-				m |= doc.AllDecls
-			}
-			if mode&AllMethods != 0 {
-				m |= doc.AllMethods
-			}
+	if mode&ShowSource != 0 {
+		// show source code
+		p.Doc = packageDoc(files)
+		// TODO(gri) Consider eliminating export filtering in this mode,
+		//           or perhaps eliminating the mode altogether.
+		if mode&NoFiltering == 0 {
+			packageExports(fset, pkg)
+		}
+		p.PAst = files
+		return p, nil
+	}
 
-			importPath := path.Clean("") // no trailing '/' in importpath
-			p.DocPackage = doc.New(pkg, importPath, m)
-			if mode&NoTypeAssoc != 0 {
-				for _, t := range p.DocPackage.Types {
-					p.DocPackage.Consts = append(p.DocPackage.Consts, t.Consts...)
-					p.DocPackage.Vars = append(p.DocPackage.Vars, t.Vars...)
-					p.DocPackage.Funcs = append(p.DocPackage.Funcs, t.Funcs...)
-					t.Consts = nil
-					t.Vars = nil
-					t.Funcs = nil
+	// extract package documentation
+	var m doc.Mode
+	if mode&NoFiltering != 0 {
+		m |= doc.AllDecls
+	}
+	if mode&AllMethods != 0 {
+		m |= doc.AllMethods
+	}
+	dpkg := doc.New(pkg, importPath, m)
+	if mode&NoTypeAssoc != 0 {
+		for _, t := range dpkg.Types {
+			dpkg.Consts = append(dpkg.Consts, t.Consts...)
+			dpkg.Vars = append(dpkg.Vars, t.Vars...)
+			dpkg.Funcs = append(dpkg.Funcs, t.Funcs...)
+			t.Consts = nil
+			t.Vars = nil
+			t.Funcs = nil
+		}
+		// for now we cannot easily sort consts and vars since
+		// go/doc.Value doesn't export the order information
+		sort.Sort(funcsByName(dpkg.Funcs))
+	}
+	p.DocPackage = dpkg
+	p.Doc = dpkg.Doc
+	p.Consts = dpkg.Consts
+	p.Types = dpkg.Types
+	p.Vars = dpkg.Vars
+	p.Funcs = dpkg.Funcs
+	p.Notes = dpkg.Notes
+	p.Deprecations = newDeprecations(dpkg)
+
+	// collect examples
+	testfiles := append(pkginfo.TestGoFiles, pkginfo.XTestGoFiles...)
+	files, err = c.parseFiles(fset, importPath, abspath, testfiles)
+	if err != nil {
+		log.Println("parsing examples:", err)
+	}
+	p.Examples = collectExamples(c, pkg, files)
+
+	return p, nil
+}
+
+// packageDoc returns the package comments of files, concatenated in
+// file name order as go/doc does.
+func packageDoc(files map[string]*ast.File) string {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var text string
+	for _, name := range names {
+		if d := files[name].Doc; d != nil {
+			if t := d.Text(); t != "" {
+				if text != "" {
+					text += "\n"
 				}
-				// for now we cannot easily sort consts and vars since
-				// go/doc.Value doesn't export the order information
-				sort.Sort(funcsByName(p.DocPackage.Funcs))
+				text += t
 			}
+		}
+	}
+	return text
+}
 
-			// collect examples
-			testfiles := append(pkginfo.TestGoFiles, pkginfo.XTestGoFiles...)
-			files, err = c.parseFiles(fset, "", abspath, testfiles)
-			if err != nil {
-				log.Println("parsing examples:", err)
+// importComment returns the path of the import comment on the package
+// clause of files (// import "path"), or "" if there is none.
+func importComment(fset *token.FileSet, files map[string]*ast.File) string {
+	for _, f := range files {
+		line := fset.Position(f.Package).Line
+		for _, g := range f.Comments {
+			if g.Pos() < f.Name.End() {
+				continue
 			}
-			p.Examples = collectExamples(c, pkg, files)
-
-			// collect any notes that we want to show
-			if p.DocPackage.Notes != nil {
-				for m, n := range p.DocPackage.Notes {
-					if p.Notes == nil {
-						p.Notes = make(map[string][]*doc.Note)
-					}
-					p.Notes[m] = n
-				}
+			if fset.Position(g.Pos()).Line != line {
+				break
 			}
-
-		} else {
-			// show source code
-			// TODO(gri) Consider eliminating export filtering in this mode,
-			//           or perhaps eliminating the mode altogether.
-			if mode&NoFiltering == 0 {
-				packageExports(fset, pkg)
+			text := g.List[0].Text
+			if strings.HasPrefix(text, "//") {
+				text = text[2:]
+			} else {
+				text = strings.TrimSuffix(text[2:], "*/")
+			}
+			text = strings.TrimSpace(text)
+			if strings.HasPrefix(text, "import ") {
+				if path, err := strconv.Unquote(strings.TrimSpace(text[len("import "):])); err == nil {
+					return path
+				}
 			}
-			p.PAst = files
+			break
 		}
-
-		p.IsMain = pkgname == "main"
 	}
-
-	fmt.Printf("package: %#v", p)
-
-	return nil
+	return ""
 }
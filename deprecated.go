@@ -0,0 +1,368 @@
+// This file contains the support for deprecated packages and
+// declarations.
+//
+// By convention, the documentation of a deprecated package or
+// declaration contains a paragraph starting with "Deprecated: ".
+// Package pages badge and collapse the deprecated declarations of
+// their package (see Deprecations), and the /deprecated/ page lists
+// the deprecated packages and declarations of the search index.
+
+package godoc
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"net/http"
+	pathpkg "path"
+	"strings"
+)
+
+// deprecationNotice returns the "Deprecated:" paragraph of the
+// documentation text, or "" if there is none.
+func deprecationNotice(text string) string {
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if strings.HasPrefix(para, "Deprecated:") {
+			return strings.Join(strings.Fields(para), " ")
+		}
+	}
+	return ""
+}
+
+// commentNotice returns the deprecation notice of the comments in
+// list, in order, or "" if there is none.
+func commentNotice(list ...*ast.CommentGroup) string {
+	for _, g := range list {
+		if notice := deprecationNotice(g.Text()); notice != "" {
+			return notice
+		}
+	}
+	return ""
+}
+
+// Deprecations describes the deprecated declarations of a package.
+type Deprecations struct {
+	Package string              // notice of the package; "" if the package is not deprecated
+	Decls   map[string]string   // notices by "Name", and by "Type.Name" for methods and fields
+	Fields  map[string][]string // names of the deprecated fields and interface methods, by type name
+}
+
+// newDeprecations returns the deprecations of the documented package
+// pkg, or nil if nothing is deprecated.
+func newDeprecations(pkg *doc.Package) *Deprecations {
+	d := &Deprecations{
+		Package: deprecationNotice(pkg.Doc),
+		Decls:   make(map[string]string),
+		Fields:  make(map[string][]string),
+	}
+	add := func(key, notice string) {
+		if notice != "" {
+			d.Decls[key] = notice
+		}
+	}
+	values := func(list []*doc.Value) {
+		for _, v := range list {
+			group := deprecationNotice(v.Doc)
+			for _, spec := range v.Decl.Specs {
+				s := spec.(*ast.ValueSpec)
+				notice := commentNotice(s.Doc, s.Comment)
+				if notice == "" {
+					notice = group
+				}
+				for _, name := range s.Names {
+					add(name.Name, notice)
+				}
+			}
+		}
+	}
+	funcs := func(list []*doc.Func) {
+		for _, f := range list {
+			add(f.Name, deprecationNotice(f.Doc))
+		}
+	}
+
+	values(pkg.Consts)
+	values(pkg.Vars)
+	funcs(pkg.Funcs)
+	for _, t := range pkg.Types {
+		add(t.Name, deprecationNotice(t.Doc))
+		values(t.Consts)
+		values(t.Vars)
+		funcs(t.Funcs)
+		for _, m := range t.Methods {
+			add(t.Name+"."+m.Name, deprecationNotice(m.Doc))
+		}
+		for _, spec := range t.Decl.Specs {
+			if s, ok := spec.(*ast.TypeSpec); ok && s.Name.Name == t.Name {
+				for _, m := range typeMembers(s) {
+					if notice := commentNotice(m.field.Doc, m.field.Comment); notice != "" {
+						add(t.Name+"."+m.name, notice)
+						d.Fields[t.Name] = append(d.Fields[t.Name], m.name)
+					}
+				}
+			}
+		}
+	}
+
+	if d.Package == "" && len(d.Decls) == 0 {
+		return nil
+	}
+	return d
+}
+
+type typeMember struct {
+	name  string
+	field *ast.Field
+}
+
+// typeMembers returns the named fields of the struct type or the
+// methods of the interface type declared by s.
+func typeMembers(s *ast.TypeSpec) []typeMember {
+	var list *ast.FieldList
+	switch t := s.Type.(type) {
+	case *ast.StructType:
+		list = t.Fields
+	case *ast.InterfaceType:
+		list = t.Methods
+	}
+	if list == nil {
+		return nil
+	}
+	var members []typeMember
+	for _, f := range list.List {
+		for _, name := range f.Names {
+			members = append(members, typeMember{name.Name, f})
+		}
+		if len(f.Names) == 0 {
+			if name := embeddedName(f.Type); name != "" {
+				members = append(members, typeMember{name, f})
+			}
+		}
+	}
+	return members
+}
+
+// embeddedName returns the name of the embedded field of type x, or
+// "" if x is not a (possibly qualified) type name.
+func embeddedName(x ast.Expr) string {
+	for {
+		switch t := x.(type) {
+		case *ast.StarExpr:
+			x = t.X
+		case *ast.SelectorExpr:
+			return t.Sel.Name
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// deprecatedFunc returns the deprecation notice of the declaration
+// with the given key in Deprecations.Decls, or "" if it is not
+// deprecated.
+func deprecatedFunc(info *PageInfo, key string) string {
+	if info.Deprecations == nil {
+		return ""
+	}
+	return info.Deprecations.Decls[key]
+}
+
+// deprecatedNamesFunc returns the deprecated names of names.
+func deprecatedNamesFunc(info *PageInfo, names []string) []string {
+	var list []string
+	for _, name := range names {
+		if deprecatedFunc(info, name) != "" {
+			list = append(list, name)
+		}
+	}
+	return list
+}
+
+// deprecatedFieldsFunc returns the deprecated fields and interface
+// methods of the type with the given name.
+func deprecatedFieldsFunc(info *PageInfo, typeName string) []string {
+	if info.Deprecations == nil {
+		return nil
+	}
+	return info.Deprecations.Fields[typeName]
+}
+
+// A valueListing is a constant or variable declaration of the
+// package page info, as rendered by the "values" template.
+type valueListing struct {
+	Info  *PageInfo
+	Value *doc.Value
+}
+
+func valueListingFunc(info *PageInfo, v *doc.Value) valueListing {
+	return valueListing{info, v}
+}
+
+// ----------------------------------------------------------------------------
+// Corpus-wide deprecations
+
+// A Deprecation describes a deprecated package or exported declaration
+// in the search index.
+type Deprecation struct {
+	ImportPath string
+	Kind       string // "package", "const", "var", "type", "func", "method", "field" or "interface method"
+	Name       string // "Name", or "Type.Name" for methods and fields; "" for packages
+	Notice     string // the "Deprecated:" paragraph
+	Path       string // path of the declaring file
+	Line       int
+}
+
+// Fragment returns the URL fragment identifying d on package pages.
+func (d Deprecation) Fragment() string {
+	if d.Kind == "field" || d.Kind == "interface method" {
+		return d.Name[:strings.IndexByte(d.Name, '.')] // members have no ids
+	}
+	return d.Name
+}
+
+// visitDeprecations records the deprecated package clause and
+// exported declarations of file.
+func (x *Indexer) visitDeprecations(file *ast.File) {
+	pak := x.current.Pak
+	if pak.Name == "main" || strings.HasSuffix(x.current.Name, "_test.go") {
+		return
+	}
+	add := func(kind, name, notice string, pos token.Pos) {
+		key := pak.Path + "." + name
+		if notice == "" || x.deprecatedSeen[key] {
+			return
+		}
+		x.deprecatedSeen[key] = true
+		x.deprecated = append(x.deprecated, Deprecation{
+			ImportPath: srcImportPath(pak.Path),
+			Kind:       kind,
+			Name:       name,
+			Notice:     notice,
+			Path:       x.current.Path(),
+			Line:       x.fset.Position(pos).Line,
+		})
+	}
+
+	add("package", "", commentNotice(file.Doc), file.Package)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			key := funcDeclKey(d)
+			if !ast.IsExported(d.Name.Name) || d.Recv != nil && !ast.IsExported(strings.TrimSuffix(key, "."+d.Name.Name)) {
+				break
+			}
+			kind := "func"
+			if d.Recv != nil {
+				kind = "method"
+			}
+			add(kind, key, commentNotice(d.Doc), d.Pos())
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if !s.Name.IsExported() {
+						break
+					}
+					add("type", s.Name.Name, commentNotice(s.Doc, d.Doc), s.Pos())
+					kind := "field"
+					if _, ok := s.Type.(*ast.InterfaceType); ok {
+						kind = "interface method"
+					}
+					for _, m := range typeMembers(s) {
+						if ast.IsExported(m.name) {
+							add(kind, s.Name.Name+"."+m.name, commentNotice(m.field.Doc, m.field.Comment), m.field.Pos())
+						}
+					}
+				case *ast.ValueSpec:
+					notice := commentNotice(s.Doc, s.Comment, d.Doc)
+					for _, name := range s.Names {
+						if name.IsExported() {
+							add(d.Tok.String(), name.Name, notice, name.Pos())
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+type deprecationsByName []Deprecation
+
+func (s deprecationsByName) Len() int      { return len(s) }
+func (s deprecationsByName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s deprecationsByName) Less(i, j int) bool {
+	if s[i].ImportPath != s[j].ImportPath {
+		return s[i].ImportPath < s[j].ImportPath
+	}
+	return s[i].Name < s[j].Name
+}
+
+// Deprecations returns the deprecated packages and exported
+// declarations of the index, sorted by import path and name.
+func (x *Index) Deprecations() []Deprecation {
+	return x.deprecated
+}
+
+// DeprecatedResult is the result of Corpus.LookupDeprecated.
+type DeprecatedResult struct {
+	Alert    string // error or informational message
+	Packages []*DeprecatedPackage
+}
+
+// DeprecatedPackage lists the deprecations of a package.
+type DeprecatedPackage struct {
+	ImportPath string
+	Notice     string // deprecation notice of the package; "" if the package is not deprecated
+	Decls      []Deprecation
+}
+
+// LookupDeprecated returns the deprecated packages and declarations
+// of the search index, by package.
+func (c *Corpus) LookupDeprecated() *DeprecatedResult {
+	result := &DeprecatedResult{}
+
+	index, _ := c.CurrentIndex()
+	switch {
+	case !c.IndexEnabled:
+		result.Alert = "Search index disabled: no deprecations available"
+		return result
+	case index == nil:
+		result.Alert = "Indexing in progress: deprecations are not available yet"
+		return result
+	}
+
+	var pkg *DeprecatedPackage
+	for _, d := range index.Deprecations() {
+		if pkg == nil || pkg.ImportPath != d.ImportPath {
+			pkg = &DeprecatedPackage{ImportPath: d.ImportPath}
+			result.Packages = append(result.Packages, pkg)
+		}
+		if d.Kind == "package" {
+			pkg.Notice = d.Notice
+		} else {
+			pkg.Decls = append(pkg.Decls, d)
+		}
+	}
+	if len(result.Packages) == 0 {
+		result.Alert = "No deprecated packages or declarations"
+	}
+	return result
+}
+
+// HandleDeprecated serves the /deprecated/ page, listing the
+// deprecated packages and declarations of the corpus.
+func (p *Presentation) HandleDeprecated(w http.ResponseWriter, r *http.Request) {
+	if pathpkg.Clean(r.URL.Path) != "/deprecated" {
+		http.NotFound(w, r)
+		return
+	}
+	result := p.Corpus.LookupDeprecated()
+	p.ServePage(w, Page{
+		Title:    "Deprecated packages and declarations",
+		Tabtitle: "Deprecated",
+		Body:     applyTemplate(p.DeprecatedHTML, "deprecatedHTML", result),
+	})
+}
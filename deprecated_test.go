@@ -0,0 +1,152 @@
+package godoc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func newDeprecatedTestCorpus(t *testing.T) *Corpus {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/old/old.go": `// Package old is an old package.
+//
+// Deprecated: Use package new instead.
+package old
+
+func F() {}
+`,
+		"src/p/p.go": `// Package p is a package.
+package p
+
+// Deprecated: Use B.
+const A = 1
+
+const (
+	B = 2
+	// C is deprecated.
+	//
+	// Deprecated: Use B.
+	C = 3
+)
+
+// F does something.
+//
+// Deprecated: Use G.
+func F() {}
+
+func G() {}
+
+// T is a type.
+type T struct {
+	X int // Deprecated: Use Y.
+	Y int
+}
+
+// M does something.
+//
+// Deprecated: Use N.
+func (T) M() {}
+
+func (T) N() {}
+
+type I interface {
+	// Deprecated: Use J.
+	I()
+	J()
+}
+
+// Deprecated: unexported.
+func f() {}
+`,
+		"src/p/p_test.go": `package p
+
+// Deprecated: test only.
+func Helper() {}
+`,
+	}))
+	c.IndexEnabled = true
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	c.WaitIndex()
+	return c
+}
+
+func TestDeprecations(t *testing.T) {
+	c := newDeprecatedTestCorpus(t)
+
+	pkg, err := c.syntaxAnalysis("/src/p", "p", 0, BuildConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := pkg.Deprecations
+	if d == nil {
+		t.Fatal("no deprecations")
+	}
+	if d.Package != "" {
+		t.Errorf("package notice = %q; want none", d.Package)
+	}
+	want := map[string]string{
+		"A":   "Deprecated: Use B.",
+		"C":   "Deprecated: Use B.",
+		"F":   "Deprecated: Use G.",
+		"T.M": "Deprecated: Use N.",
+		"T.X": "Deprecated: Use Y.",
+		"I.I": "Deprecated: Use J.",
+	}
+	if !reflect.DeepEqual(d.Decls, want) {
+		t.Errorf("deprecated declarations = %v; want %v", d.Decls, want)
+	}
+	if want := map[string][]string{"T": {"X"}, "I": {"I"}}; !reflect.DeepEqual(d.Fields, want) {
+		t.Errorf("deprecated fields = %v; want %v", d.Fields, want)
+	}
+
+	pkg, err = c.syntaxAnalysis("/src/old", "old", 0, BuildConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Deprecations == nil || pkg.Deprecations.Package != "Deprecated: Use package new instead." {
+		t.Errorf("deprecations of package old = %+v", pkg.Deprecations)
+	}
+
+	tree, _ := c.fsTree.Get()
+	for path, want := range map[string]bool{"/src/old": true, "/src/p": false} {
+		if dir := tree.(*Directory).lookup(path); dir == nil || dir.Deprecated != want {
+			t.Errorf("directory %s: %+v; want Deprecated = %v", path, dir, want)
+		}
+	}
+}
+
+func TestLookupDeprecated(t *testing.T) {
+	c := newDeprecatedTestCorpus(t)
+	result := c.LookupDeprecated()
+	if result.Alert != "" {
+		t.Fatal(result.Alert)
+	}
+	type decl struct{ kind, name string }
+	got := make(map[string][]decl)
+	notices := make(map[string]string)
+	for _, pkg := range result.Packages {
+		notices[pkg.ImportPath] = pkg.Notice
+		for _, d := range pkg.Decls {
+			got[pkg.ImportPath] = append(got[pkg.ImportPath], decl{d.Kind, d.Name})
+		}
+	}
+	want := map[string][]decl{
+		"p": {
+			{"const", "A"},
+			{"const", "C"},
+			{"func", "F"},
+			{"interface method", "I.I"},
+			{"method", "T.M"},
+			{"field", "T.X"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("deprecated declarations = %v; want %v", got, want)
+	}
+	if want := map[string]string{"old": "Deprecated: Use package new instead.", "p": ""}; !reflect.DeepEqual(notices, want) {
+		t.Errorf("package notices = %v; want %v", notices, want)
+	}
+}
@@ -3,6 +3,7 @@
 package godoc
 
 import (
+	"go/ast"
 	"go/doc"
 	"go/parser"
 	"go/token"
@@ -11,7 +12,9 @@ import (
 	pathpkg "path"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/miclle/godoc/vfs"
 )
@@ -30,6 +33,8 @@ type Directory struct {
 	ImportPath     string       // import path
 	HasPkg         bool         // true if the directory contains at least one package
 	Synopsis       string       // package documentation, if any
+	Deprecated     bool         // true if the package documentation has a "Deprecated:" paragraph
+	Imports        []string     // sorted import paths of the package files, for all platforms; nil if SummarizePackage summarized the package
 	RootType       vfs.RootType // root type of the filesystem containing the directory, GOPATH: hasThirdParty, GOROOT: standard library
 	SubDirectories []*Directory // subdirectories
 }
@@ -46,6 +51,22 @@ func isPkgFile(fi os.FileInfo) bool {
 		!strings.HasSuffix(fi.Name(), "_test.go") // ignore test files
 }
 
+// isIgnoredFile reports whether file is excluded from all builds
+// by an "ignore" build constraint, as generator programs usually are.
+func isIgnoredFile(file *ast.File) bool {
+	for _, g := range file.Comments {
+		if g.Pos() >= file.Package {
+			break
+		}
+		for _, c := range g.List {
+			if c.Text == "//go:build ignore" || c.Text == "// +build ignore" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func isPkgDir(fi os.FileInfo) bool {
 	name := fi.Name()
 	return fi.IsDir() && len(name) > 0 &&
@@ -56,6 +77,19 @@ func isPkgDir(fi os.FileInfo) bool {
 type TreeBuilder struct {
 	corpus   *Corpus
 	maxDepth int
+
+	mu     sync.Mutex
+	stamps map[string]dirStamp // if non-nil, the stamps of all directories read
+}
+
+func (builder *TreeBuilder) recordStamp(path string, list []os.FileInfo) {
+	if builder.stamps == nil {
+		return
+	}
+	stamp := newDirStamp(list)
+	builder.mu.Lock()
+	builder.stamps[path] = stamp
+	builder.mu.Unlock()
 }
 
 // ioGate is a semaphore controlling VFS activity (ReadDir, parseFile, etc).
@@ -89,10 +123,12 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 	}
 
 	var synopses [3]string // prioritized package documentation (0 == highest priority)
+	var deprecated [3]bool // deprecation of the package documentation of synopses
 
 	show := true // show in package listing
 	hasPkgFiles := false
 	haveSummary := false
+	summarized := false // package summarized by SummarizePackage
 
 	if hook := builder.corpus.SummarizePackage; hook != nil {
 		if summary, show0, ok := hook(strings.TrimPrefix(path, "/src/")); ok {
@@ -100,6 +136,7 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 			show = show0
 			synopses[0] = summary
 			haveSummary = true
+			summarized = true
 		}
 	}
 
@@ -113,10 +150,12 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 			log.Printf("newDirTree reading %s: %v", path, err)
 		}
 	}
+	builder.recordStamp(path, list)
 
 	// determine number of subdirectories and if there are package files
 	var dirchs []chan *Directory
 	var directories []*Directory
+	imports := make(map[string]bool)
 
 	for _, d := range list {
 		filename := pathpkg.Join(path, d.Name())
@@ -138,13 +177,17 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 					directories = append(directories, dir)
 				}
 			}
-		case !haveSummary && isPkgFile(d):
+		case !summarized && isPkgFile(d):
 			// looks like a package file, but may just be a file ending in ".go";
 			// don't just count it yet (otherwise we may end up with hasPkgFiles even
 			// though the directory doesn't contain any real package files - was bug)
-			// no "optimal" package synopsis yet; continue to collect synopses
+			// all package files are parsed for their imports; as long as there is
+			// no "optimal" package synopsis yet, continue to collect synopses.
+			// Parsing up to the imports costs no more than up to the package
+			// clause: reading the files is what costs, about a third more time
+			// for GOROOT than stopping at the first synopsis.
 			ioGate <- struct{}{}
-			const flags = parser.ParseComments | parser.PackageClauseOnly
+			const flags = parser.ParseComments | parser.ImportsOnly
 			file, err := builder.corpus.parseFile(fset, filename, flags)
 			<-ioGate
 			if err != nil {
@@ -155,7 +198,14 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 			}
 
 			hasPkgFiles = true
-			if file.Doc != nil {
+			if !isIgnoredFile(file) {
+				for _, spec := range file.Imports {
+					if ipath, err := strconv.Unquote(spec.Path.Value); err == nil && ipath != "C" {
+						imports[ipath] = true
+					}
+				}
+			}
+			if !haveSummary && file.Doc != nil {
 				// prioritize documentation
 				i := -1
 				switch file.Name.Name {
@@ -167,7 +217,9 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 					i = 2 // none of the above
 				}
 				if 0 <= i && i < len(synopses) && synopses[i] == "" {
-					synopses[i] = doc.Synopsis(file.Doc.Text())
+					text := file.Doc.Text()
+					synopses[i] = doc.Synopsis(text)
+					deprecated[i] = deprecationNotice(text) != ""
 				}
 			}
 			haveSummary = synopses[0] != ""
@@ -195,8 +247,10 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 
 	// select the highest-priority synopsis for the directory entry, if any
 	synopsis := ""
-	for _, synopsis = range synopses {
-		if synopsis != "" {
+	isDeprecated := false
+	for i := range synopses {
+		if synopses[i] != "" {
+			synopsis, isDeprecated = synopses[i], deprecated[i]
 			break
 		}
 	}
@@ -204,6 +258,12 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 	// importPath := path.Clean(relpath) // no trailing '/' in importpath
 	// pageInfo.DocPackage = doc.New(pkg, importPath, m)
 
+	var importList []string
+	for ipath := range imports {
+		importList = append(importList, ipath)
+	}
+	sort.Strings(importList)
+
 	return &Directory{
 		Dir:            path,
 		Depth:          depth,
@@ -212,6 +272,8 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 		ImportPath:     importPath,
 		HasPkg:         hasPkgFiles && show, // TODO(bradfitz): add proper Hide field?
 		Synopsis:       synopsis,
+		Deprecated:     isDeprecated,
+		Imports:        importList,
 		RootType:       builder.corpus.fs.RootType(path),
 		SubDirectories: directories,
 	}
@@ -228,6 +290,12 @@ func (builder *TreeBuilder) newDirTree(fset *token.FileSet, path, name string, d
 // (i.e., in this case the tree may contain directories w/o any package files).
 //
 func (c *Corpus) newDirectory(root string, maxDepth int) *Directory {
+	return c.buildDirectory(root, maxDepth, nil)
+}
+
+// buildDirectory is like newDirectory but additionally records
+// the stamps of all directories read in stamps, if stamps is not nil.
+func (c *Corpus) buildDirectory(root string, maxDepth int, stamps map[string]dirStamp) *Directory {
 	// The root could be a symbolic link so use Stat not Lstat.
 	d, err := c.fs.Stat(root)
 	// If we fail here, report detailed error messages; otherwise
@@ -250,6 +318,7 @@ func (c *Corpus) newDirectory(root string, maxDepth int) *Directory {
 	treeBuilder := TreeBuilder{
 		corpus:   c,
 		maxDepth: maxDepth,
+		stamps:   stamps,
 	}
 
 	// the file set provided is only for local parsing, no position
@@ -0,0 +1,333 @@
+// This file contains the export of the documentation as a static site,
+// which can be published on a file server without a godoc server.
+//
+// The Exporter renders the package and source directory pages of the
+// directory tree, and those they link to under /pkg/, /cmd/, /src/ and
+// /lib/godoc/, with the godoc handler, and writes them to the export
+// directory. The pages are named after their URL paths: directory pages
+// are written to index.html files, and file pages get an .html suffix,
+// while the files served raw, such as the static assets of /lib/godoc/
+// and the images of /src/, keep their names. The links of the HTML pages
+// and the url() references of the style sheets are rewritten to relative
+// links to the exported files, and query strings are dropped. The other
+// pages, such as /search, are dynamic and not exported.
+
+package godoc
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/miclle/godoc/vfs"
+)
+
+// exportPrefixes lists the URL path prefixes of the exported pages.
+var exportPrefixes = []string{"/pkg/", "/cmd/", "/src/", "/lib/godoc/"}
+
+// An Exporter writes the pages served by Handler to the directory Dir.
+type Exporter struct {
+	Handler http.Handler // godoc handler serving the pages
+	Dir     string       // export directory
+	Workers int          // maximum number of pages rendered in parallel; if 0, GOMAXPROCS
+
+	// Raw optionally reports whether the file of a URL path is served
+	// raw rather than as an HTML page, like Presentation.ServesRawFile.
+	// If nil, the files of /lib/godoc/ are.
+	Raw func(path string) bool
+
+	// Logf optionally specifies a function logging the errors.
+	Logf func(format string, args ...interface{})
+
+	mu    sync.Mutex
+	seen  map[string]bool // URL paths of the pages queued for export
+	raw   map[string]bool // cached results of Raw
+	stats ExportStats
+	wg    sync.WaitGroup
+	queue chan string
+}
+
+// ExportStats reports the outcome of an export.
+type ExportStats struct {
+	Pages   int // pages exported
+	Written int // files written, because they were new or changed
+	Errors  int // pages which could not be exported
+}
+
+// ExportPaths returns the URL paths of the package, command and source
+// directory pages of the directory tree of c, which seed the crawl of an
+// export.
+func (c *Corpus) ExportPaths() []string {
+	paths := []string{"/pkg/", "/src/"}
+	root, _ := c.Directory("/src")
+	if root == nil {
+		return paths
+	}
+	var walk func(d *Directory)
+	walk = func(d *Directory) {
+		if d.RootType == vfs.RootTypeGoRoot && (d.ImportPath == "cmd" || strings.HasPrefix(d.ImportPath, "cmd/")) {
+			paths = append(paths, "/"+d.ImportPath+"/")
+		} else {
+			paths = append(paths, "/pkg/"+d.ImportPath+"/")
+		}
+		paths = append(paths, "/src/"+d.ImportPath+"/")
+		for _, sub := range d.SubDirectories {
+			walk(sub)
+		}
+	}
+	for _, d := range root.SubDirectories {
+		walk(d)
+	}
+	return paths
+}
+
+// Export exports the pages of the given URL paths and those they link
+// to. Files whose content is unchanged are not rewritten, so that an
+// export directory can be updated incrementally.
+func (e *Exporter) Export(paths []string) ExportStats {
+	e.seen = make(map[string]bool)
+	e.raw = make(map[string]bool)
+	e.stats = ExportStats{}
+	workers := e.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	queue := make(chan string, workers)
+	e.queue = queue
+	for i := 0; i < workers; i++ {
+		go func() {
+			for p := range queue {
+				e.export(p)
+				e.wg.Done()
+			}
+		}()
+	}
+	for _, p := range paths {
+		e.add(p)
+	}
+	e.wg.Wait()
+	close(queue)
+	return e.stats
+}
+
+// add queues the page of the URL path p for export, unless it was already.
+func (e *Exporter) add(p string) {
+	e.mu.Lock()
+	if e.seen[p] {
+		e.mu.Unlock()
+		return
+	}
+	e.seen[p] = true
+	e.wg.Add(1)
+	e.mu.Unlock()
+	select {
+	case e.queue <- p:
+	default:
+		// The workers are busy and may be the ones adding pages:
+		// don't block them.
+		go func() { e.queue <- p }()
+	}
+}
+
+func (e *Exporter) logf(format string, args ...interface{}) {
+	if e.Logf != nil {
+		e.Logf(format, args...)
+	}
+}
+
+// export exports the page of the URL path p.
+func (e *Exporter) export(p string) {
+	w := &exportRecorder{header: make(http.Header), code: http.StatusOK}
+	defer func() {
+		// Like the HTTP server, don't let a page crash the export.
+		if err := recover(); err != nil {
+			e.fail(p, fmt.Errorf("panic: %v", err))
+		}
+	}()
+	e.Handler.ServeHTTP(w, &http.Request{Method: "GET", URL: &url.URL{Path: p}, Header: make(http.Header)})
+
+	var data []byte
+	switch w.code {
+	case http.StatusOK:
+		data = w.body.Bytes()
+		switch {
+		case !e.isRaw(p):
+			data = e.rewriteLinks(p, linkRx, data)
+		case pathpkg.Ext(p) == ".css":
+			data = e.rewriteLinks(p, cssURLRx, data)
+		}
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect:
+		// Write a page redirecting to the exported target.
+		u, err := url.Parse(w.header.Get("Location"))
+		if err != nil || u.Path == "" || !exported(u.Path) {
+			e.fail(p, fmt.Errorf("HTTP %d to %q", w.code, w.header.Get("Location")))
+			return
+		}
+		target := relativeLink(e.exportFile(p), e.exportFile(u.Path))
+		data = []byte(fmt.Sprintf("<!DOCTYPE html>\n<meta http-equiv=\"refresh\" content=\"0; url=%s\">\n", target))
+		e.add(u.Path)
+	default:
+		e.fail(p, fmt.Errorf("HTTP error %d", w.code))
+		return
+	}
+
+	name := filepath.Join(e.Dir, filepath.FromSlash(e.exportFile(p)))
+	written, err := writeFileIfChanged(name, data)
+	if err != nil {
+		e.fail(p, err)
+		return
+	}
+	e.mu.Lock()
+	e.stats.Pages++
+	if written {
+		e.stats.Written++
+	}
+	e.mu.Unlock()
+}
+
+func (e *Exporter) fail(p string, err error) {
+	e.logf("export %s: %v", p, err)
+	e.mu.Lock()
+	e.stats.Errors++
+	e.mu.Unlock()
+}
+
+var (
+	// linkRx matches the links of href and src attributes.
+	linkRx = regexp.MustCompile(`(href|src)="([^"]*)"`)
+
+	// cssURLRx matches the url() references of style sheets.
+	cssURLRx = regexp.MustCompile(`(url)\(\s*['"]?([^'")]*?)['"]?\s*\)`)
+)
+
+// rewriteLinks returns the data of the page of the URL path p with the
+// links matched by rx made relative, if they refer to exported pages, and
+// queues these pages for export. The first submatch of rx is the kind of
+// reference and the second one the link.
+func (e *Exporter) rewriteLinks(p string, rx *regexp.Regexp, data []byte) []byte {
+	from := e.exportFile(p)
+	base := &url.URL{Path: p}
+	return rx.ReplaceAllFunc(data, func(m []byte) []byte {
+		sub := rx.FindSubmatch(m)
+		// The links of HTML pages are HTML-escaped.
+		link := string(sub[2])
+		if rx == linkRx {
+			link = strings.Replace(link, "&amp;", "&", -1)
+		}
+		u, err := url.Parse(link)
+		if err != nil || u.Scheme != "" || u.Host != "" || u.Opaque != "" || (u.Path == "" && u.RawQuery == "") {
+			// external, fragment-only or empty link
+			return m
+		}
+		target := base.ResolveReference(u).Path
+		if target == "/" {
+			target = "/pkg/"
+		}
+		if !exported(target) {
+			return m
+		}
+		if (strings.HasPrefix(target, "/pkg/") || strings.HasPrefix(target, "/cmd/")) && !strings.HasSuffix(target, "/") {
+			target += "/"
+		}
+		e.add(target)
+		rel := relativeLink(from, e.exportFile(target))
+		if u.Fragment != "" {
+			rel += "#" + u.Fragment
+		}
+		if rx == cssURLRx {
+			return []byte(fmt.Sprintf(`url(%s)`, rel))
+		}
+		return []byte(fmt.Sprintf(`%s="%s"`, sub[1], rel))
+	})
+}
+
+// exported reports whether the page of the URL path p is exported.
+func exported(p string) bool {
+	for _, prefix := range exportPrefixes {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRaw reports whether the file of the URL path p is served raw.
+func (e *Exporter) isRaw(p string) bool {
+	if strings.HasSuffix(p, "/") {
+		return false
+	}
+	if e.Raw == nil {
+		return strings.HasPrefix(p, "/lib/godoc/")
+	}
+	e.mu.Lock()
+	raw, ok := e.raw[p]
+	e.mu.Unlock()
+	if !ok {
+		raw = e.Raw(p)
+		e.mu.Lock()
+		e.raw[p] = raw
+		e.mu.Unlock()
+	}
+	return raw
+}
+
+// exportFile returns the slash-separated name of the exported file of the
+// page of the URL path p, relative to the export directory.
+func (e *Exporter) exportFile(p string) string {
+	name := strings.TrimPrefix(p, "/")
+	switch {
+	case strings.HasSuffix(p, "/"):
+		return name + "index.html"
+	case e.isRaw(p):
+		return name
+	default:
+		return name + ".html"
+	}
+}
+
+// relativeLink returns the link from the exported file from to the
+// exported file to.
+func relativeLink(from, to string) string {
+	dir := strings.Split(pathpkg.Dir(from), "/")
+	if dir[0] == "." {
+		dir = nil
+	}
+	elems := strings.Split(to, "/")
+	i := 0
+	for i < len(dir) && i < len(elems)-1 && dir[i] == elems[i] {
+		i++
+	}
+	return strings.Repeat("../", len(dir)-i) + strings.Join(elems[i:], "/")
+}
+
+// writeFileIfChanged writes data to the file name unless it already holds
+// data, and reports whether it wrote it.
+func writeFileIfChanged(name string, data []byte) (bool, error) {
+	if old, err := ioutil.ReadFile(name); err == nil && bytes.Equal(old, data) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return false, err
+	}
+	return true, ioutil.WriteFile(name, data, 0644)
+}
+
+// An exportRecorder is an http.ResponseWriter recording a response.
+type exportRecorder struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (w *exportRecorder) Header() http.Header         { return w.header }
+func (w *exportRecorder) Write(b []byte) (int, error) { return w.body.Write(b) }
+func (w *exportRecorder) WriteHeader(code int)        { w.code = code }
@@ -0,0 +1,169 @@
+package godoc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestRelativeLink(t *testing.T) {
+	for _, tc := range []struct {
+		from, to, want string
+	}{
+		{"pkg/index.html", "pkg/fmt/index.html", "fmt/index.html"},
+		{"pkg/fmt/index.html", "pkg/index.html", "../index.html"},
+		{"pkg/fmt/index.html", "pkg/fmt/index.html", "index.html"},
+		{"pkg/net/http/index.html", "src/net/http/server.go.html", "../../../src/net/http/server.go.html"},
+		{"pkg/fmt/index.html", "lib/godoc/style.css", "../../lib/godoc/style.css"},
+		{"index.html", "pkg/index.html", "pkg/index.html"},
+	} {
+		if got := relativeLink(tc.from, tc.to); got != tc.want {
+			t.Errorf("relativeLink(%q, %q) = %q; want %q", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	version := "1"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pkg/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pkg/":
+			fmt.Fprint(w, `<link href="/lib/godoc/style.css"><a href="/pkg/a">a</a> <a href="/search?q=a">search</a> <a href="//example.com/">ext</a>`)
+		case "/pkg/a/":
+			fmt.Fprintf(w, `<a href="/">root</a> <a href="/src/a/a.go?s=1:2#L3">a.go</a> <a href="/pkg/a/?m=all&amp;x=1">all</a> v%s`, version)
+		case "/pkg/panic/":
+			panic("broken page")
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("/src/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/src/a":
+			http.Redirect(w, r, "/src/a/", http.StatusMovedPermanently)
+		case "/src/a/":
+			fmt.Fprint(w, `<a href="/src/a/a.go">a.go</a>`)
+		case "/src/a/a.go":
+			fmt.Fprint(w, `<a href="/src/a">dir</a> <a href="/pkg/missing/">missing</a>`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("/lib/godoc/style.css", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `a[href="/pkg/"] {}`)
+	})
+
+	e := &Exporter{Handler: mux, Dir: dir, Workers: 2}
+	stats := e.Export([]string{"/pkg/", "/pkg/panic/"})
+	if want := (ExportStats{Pages: 6, Written: 6, Errors: 2}); stats != want {
+		t.Errorf("stats = %+v; want %+v", stats, want)
+	}
+	for name, want := range map[string]string{
+		"pkg/index.html":      `<link href="../lib/godoc/style.css"><a href="a/index.html">a</a> <a href="/search?q=a">search</a> <a href="//example.com/">ext</a>`,
+		"pkg/a/index.html":    `<a href="../index.html">root</a> <a href="../../src/a/a.go.html#L3">a.go</a> <a href="index.html">all</a> v1`,
+		"src/a/index.html":    `<a href="a.go.html">a.go</a>`,
+		"src/a/a.go.html":     `<a href="../a.html">dir</a> <a href="../../pkg/missing/index.html">missing</a>`,
+		"src/a.html":          "<!DOCTYPE html>\n<meta http-equiv=\"refresh\" content=\"0; url=a/index.html\">\n",
+		"lib/godoc/style.css": `a[href="/pkg/"] {}`,
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(data) != want {
+			t.Errorf("%s:\ngot  %s\nwant %s", name, data, want)
+		}
+	}
+
+	// Only the changed page is written again.
+	version = "2"
+	stats = e.Export([]string{"/pkg/"})
+	if want := (ExportStats{Pages: 6, Written: 1, Errors: 1}); stats != want {
+		t.Errorf("stats of the second export = %+v; want %+v", stats, want)
+	}
+}
+
+// newTestPresentation returns a Presentation serving files with the
+// templates of the static directory.
+func newTestPresentation(t *testing.T, files map[string]string) *Presentation {
+	c := NewCorpus(mapfs.New(files))
+	c.IndexEnabled = false
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	p := NewPresentation(c)
+	for name, tmpl := range map[string]**template.Template{
+		"layout.html":      &p.LayoutHTML,
+		"sidebar.html":     &p.SidebarHTML,
+		"package.html":     &p.PackageHTML,
+		"package.md":       &p.PackageMarkdown,
+		"packageroot.html": &p.PackageRootHTML,
+		"dirlist.html":     &p.DirlistHTML,
+		"error.html":       &p.ErrorHTML,
+		"example.html":     &p.ExampleHTML,
+	} {
+		data, err := ioutil.ReadFile(filepath.Join("static", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		*tmpl = template.Must(template.New(name).Funcs(p.FuncMap()).Parse(string(data)))
+	}
+	return p
+}
+
+func TestExportPresentation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	logo := "\x89PNG\r\n\x1a\n\x00\x00<a href=\"/pkg/\">"
+	p := newTestPresentation(t, map[string]string{
+		"src/p/p.go":                "// Package p is a package.\npackage p\n",
+		"src/p/README":              "Read me.\n",
+		"src/p/logo.png":            logo,
+		"lib/godoc/style.css":       "a { background: url(/lib/godoc/images/icon.svg) no-repeat; }\n",
+		"lib/godoc/images/icon.svg": "<svg></svg>\n",
+	})
+	e := &Exporter{Handler: p, Dir: dir, Raw: p.ServesRawFile}
+	e.Export(p.Corpus.ExportPaths())
+
+	read := func(name string) string {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Error(err)
+		}
+		return string(data)
+	}
+	listing := read("src/p/index.html")
+	for _, link := range []string{`href="p.go.html"`, `href="README.html"`, `href="logo.png"`, `href="../index.html"`} {
+		if !strings.Contains(listing, link) {
+			t.Errorf("src/p/index.html does not link %s:\n%s", link, listing)
+		}
+	}
+	read("src/p/p.go.html")
+	read("src/p/README.html")
+	read("lib/godoc/images/icon.svg")
+	if got := read("src/p/logo.png"); got != logo {
+		t.Errorf("src/p/logo.png = %q; want %q", got, logo)
+	}
+	if css := read("lib/godoc/style.css"); !strings.Contains(css, "url(images/icon.svg)") {
+		t.Errorf("lib/godoc/style.css does not refer to images/icon.svg:\n%s", css)
+	}
+}
@@ -1,11 +1,11 @@
 module github.com/miclle/godoc
 
-go 1.15
+go 1.18
 
 require (
 	github.com/wellington/go-libsass v0.9.2
 	github.com/yosssi/gohtml v0.0.0-20200519115854-476f5b4b8047
-	golang.org/x/net v0.0.0-20200822124328-c89045814202
-	golang.org/x/tools v0.0.0-20200827010519-17fd2f27a9e3
-	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1
+	golang.org/x/mod v0.11.0
+	golang.org/x/net v0.11.0
+	golang.org/x/tools v0.10.0
 )
@@ -14,6 +14,7 @@ import (
 	"go/format"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"io"
 	"log"
 	"os"
@@ -57,9 +58,15 @@ func (p *Presentation) initFuncMap() {
 	}
 	p.funcMap = template.FuncMap{
 		// various helpers
-		"filename": filenameFunc,
-		"repeat":   strings.Repeat,
-		"since":    p.Corpus.pkgAPIInfo.sinceVersionFunc,
+		"filename":   filenameFunc,
+		"repeat":     strings.Repeat,
+		"since":      p.Corpus.pkgAPIInfo.sinceVersionFunc,
+		"sinceLabel": sinceLabelFunc,
+
+		// access to search result information
+		"infoKind_html":    infoKind_htmlFunc,
+		"infoLine":         p.infoLineFunc,
+		"infoSnippet_html": p.infoSnippet_htmlFunc,
 
 		// access to FileInfos (directory listings)
 		"fileInfoName": fileInfoNameFunc,
@@ -71,21 +78,38 @@ func (p *Presentation) initFuncMap() {
 		"comment_html": comment_htmlFunc,
 		"sanitize":     sanitizeFunc,
 
+		// platforms of declarations
+		"platforms_html": platforms_htmlFunc,
+
+		// deprecated declarations
+		"deprecated":       deprecatedFunc,
+		"deprecatedNames":  deprecatedNamesFunc,
+		"deprecatedFields": deprecatedFieldsFunc,
+		"valueListing":     valueListingFunc,
+
 		// support for URL attributes
-		"pkgLink":       pkgLinkFunc,
-		"srcLink":       srcLinkFunc,
-		"srcID":         srcIDFunc,
-		"posLink_url":   newPosLink_urlFunc(srcPosLinkFunc),
-		"docLink":       docLinkFunc,
-		"queryLink":     queryLinkFunc,
-		"srcBreadcrumb": srcBreadcrumbFunc,
-		"srcToPkgLink":  srcToPkgLinkFunc,
+		"pkgLink":        pkgLinkFunc,
+		"srcLink":        srcLinkFunc,
+		"srcID":          srcIDFunc,
+		"posLink_url":    newPosLink_urlFunc(srcPosLinkFunc),
+		"srcPosLink_url": srcPosLinkFunc,
+		"docLink":        docLinkFunc,
+		"queryLink":      queryLinkFunc,
+		"srcBreadcrumb":  srcBreadcrumbFunc,
+		"srcToPkgLink":   srcToPkgLinkFunc,
 
 		// formatting of Examples
 		"example_html":   p.example_htmlFunc,
 		"example_name":   p.example_nameFunc,
 		"example_suffix": p.example_suffixFunc,
 
+		// formatting of Markdown
+		"comment_md": comment_mdFunc,
+		"text_md":    text_mdFunc,
+		"code_md":    code_mdFunc,
+		"anchor_md":  anchor_mdFunc,
+		"example_md": p.example_mdFunc,
+
 		// formatting of Notes
 		"noteTitle": noteTitle,
 
@@ -94,12 +118,16 @@ func (p *Presentation) initFuncMap() {
 
 		// formatting of PageInfoMode query string
 		"modeQueryString": modeQueryString,
+		"queryString":     queryString,
+		"withMode":        withModeFunc,
+		"dirListing":      dirListingFunc,
 	}
 	if p.URLForSrc != nil {
 		p.funcMap["srcLink"] = p.URLForSrc
 	}
 	if p.URLForSrcPos != nil {
 		p.funcMap["posLink_url"] = newPosLink_urlFunc(p.URLForSrcPos)
+		p.funcMap["srcPosLink_url"] = p.URLForSrcPos
 	}
 	if p.URLForSrcQuery != nil {
 		p.funcMap["queryLink"] = p.URLForSrcQuery
@@ -140,6 +168,37 @@ var infoKinds = [nKinds]string{
 	Use:           "use",
 }
 
+func infoKind_htmlFunc(info SpotInfo) string {
+	return infoKinds[info.Kind()] // infoKind entries are html-escaped
+}
+
+func (p *Presentation) infoLineFunc(info SpotInfo) int {
+	if !info.IsIndex() {
+		return info.Lori()
+	}
+	// no line information is available if we don't
+	// have an index - this should never happen; be
+	// conservative and don't crash
+	if index, _ := p.Corpus.CurrentIndex(); index != nil {
+		if s := index.Snippet(info.Lori()); s != nil {
+			return s.Line
+		}
+	}
+	return 0
+}
+
+func (p *Presentation) infoSnippet_htmlFunc(info SpotInfo) string {
+	if info.IsIndex() {
+		if index, _ := p.Corpus.CurrentIndex(); index != nil {
+			// Snippet.Text was HTML-escaped when it was generated
+			if s := index.Snippet(info.Lori()); s != nil {
+				return s.Text
+			}
+		}
+	}
+	return `<span class="alert">no snippet text available</span>`
+}
+
 func (p *Presentation) nodeFunc(info *PageInfo, node interface{}) string {
 	var buf bytes.Buffer
 	p.writeNode(&buf, info, info.FSet, node)
@@ -152,7 +211,7 @@ func (p *Presentation) node_htmlFunc(info *PageInfo, node interface{}, linkify b
 
 	var buf2 bytes.Buffer
 	if n, _ := node.(ast.Node); n != nil && linkify && p.DeclLinks {
-		LinkifyText(&buf2, buf1.Bytes(), n)
+		LinkifyTypedText(&buf2, buf1.Bytes(), n, info.TypesPkg, info.TypesInfo, queryString(info.Mode, info.Build))
 		if st, name := isStructTypeDecl(n); st != nil {
 			addStructFieldIDAttributes(&buf2, name, st)
 		}
@@ -350,7 +409,8 @@ type PageInfo struct {
 	Dirname string // directory containing the package
 	Err     error  // error or nil
 
-	Mode PageInfoMode // display metadata from query string
+	Mode  PageInfoMode // display metadata from query string
+	Build BuildConfig  // build configuration from query string
 
 	// package info
 	FSet       *token.FileSet         // nil if no package documentation
@@ -360,6 +420,32 @@ type PageInfo struct {
 	PAst       map[string]*ast.File   // nil if no AST with package exports
 	IsMain     bool                   // true for package main
 	IsFiltered bool                   // true if results were filtered
+	TypesPkg   *types.Package         // nil if not type-checked
+	TypesInfo  *types.Info            // type information for the package files; nil if not type-checked
+	Platforms  *PlatformInfo          // platforms of the declarations; nil unless in AllPlatforms mode
+
+	Deprecations *Deprecations // deprecated declarations; nil if nothing is deprecated
+
+	// versions of the module of the package in Corpus.ModuleCache;
+	// nil if not available
+	ModuleVersions *ModuleVersions
+
+	// vendored module of the package in vendor mode; nil if the
+	// package isn't vendored
+	Vendored *BuildListModule
+
+	// implements relations of the package types, by type name;
+	// nil if not available
+	Implements map[string]*Implementations
+
+	// import graph of the package
+	Imports    []string // packages imported for the selected platform
+	ImportedBy []string // packages of the corpus importing the package
+	Importers  []string // packages importing the package directly or indirectly; ShowImporters mode only
+
+	// main modules of the workspace, with their packages, on the
+	// package root page; nil if not in a workspace
+	Workspace []*WorkspaceModule
 
 	// directory info
 	Directory     *Directory
@@ -527,16 +613,9 @@ func (p *Presentation) example_htmlFunc(info *PageInfo, funcName string) string
 		wholeFile := true
 
 		// Additional formatting if this is a function body.
-		if n := len(code); n >= 2 && code[0] == '{' && code[n-1] == '}' {
+		if body, ok := p.exampleBody(code); ok {
 			wholeFile = false
-			// remove surrounding braces
-			code = code[1 : n-1]
-			// unindent
-			code = replaceLeadingIndentation(code, strings.Repeat(" ", p.TabWidth), "")
-			// remove output comment
-			if loc := exampleOutputRx.FindStringIndex(code); loc != nil {
-				code = strings.TrimSpace(code[:loc[0]])
-			}
+			code = body
 		}
 
 		// Write out the playground code in standard Go style
@@ -544,8 +623,9 @@ func (p *Presentation) example_htmlFunc(info *PageInfo, funcName string) string
 		play := ""
 		if eg.Play != nil && p.ShowPlayground {
 			var buf bytes.Buffer
-			eg.Play.Comments = filterOutBuildAnnotations(eg.Play.Comments)
-			if err := format.Node(&buf, info.FSet, eg.Play); err != nil {
+			file := *eg.Play // don't modify the example: it may be cached and shared
+			file.Comments = filterOutBuildAnnotations(file.Comments)
+			if err := format.Node(&buf, info.FSet, &file); err != nil {
 				log.Print(err)
 			} else {
 				play = buf.String()
@@ -572,6 +652,25 @@ func (p *Presentation) example_htmlFunc(info *PageInfo, funcName string) string
 	return buf.String()
 }
 
+// exampleBody returns the code of an example function body without its
+// surrounding braces, indentation and output comment. It reports false
+// if the code is a whole file instead.
+func (p *Presentation) exampleBody(code string) (string, bool) {
+	n := len(code)
+	if n < 2 || code[0] != '{' || code[n-1] != '}' {
+		return code, false
+	}
+	// remove surrounding braces
+	code = code[1 : n-1]
+	// unindent
+	code = replaceLeadingIndentation(code, strings.Repeat(" ", p.TabWidth), "")
+	// remove output comment
+	if loc := exampleOutputRx.FindStringIndex(code); loc != nil {
+		code = strings.TrimSpace(code[:loc[0]])
+	}
+	return code, true
+}
+
 func filterOutBuildAnnotations(cg []*ast.CommentGroup) []*ast.CommentGroup {
 	if len(cg) == 0 {
 		return cg
@@ -732,8 +831,9 @@ func replaceLeadingIndentation(body, oldIndent, newIndent string) string {
 // writeNode writes the AST node x to w.
 //
 // The provided fset must be non-nil. The pageInfo is optional. If
-// present, the pageInfo is used to add comments to struct fields to
-// say which version of Go introduced them.
+// present, the pageInfo is used to add comments to struct fields,
+// interface methods, constants and variables to say which version of
+// Go introduced them.
 func (p *Presentation) writeNode(w io.Writer, pageInfo *PageInfo, fset *token.FileSet, x interface{}) {
 	// convert trailing tabs into spaces using a tconv filter
 	// to ensure a good outcome in most browsers (there may still
@@ -744,23 +844,30 @@ func (p *Presentation) writeNode(w io.Writer, pageInfo *PageInfo, fset *token.Fi
 	//           with an another printer mode (which is more efficiently
 	//           implemented in the printer than here with another layer)
 
-	var pkgName, structName string
-	var apiInfo pkgAPIVersions
+	var since map[string]string // versions of the names declared by x
+	var typeSince string        // for types, the version of the type
 	if gd, ok := x.(*ast.GenDecl); ok && pageInfo != nil && pageInfo.DocPackage != nil &&
-		p.Corpus != nil &&
-		gd.Tok == token.TYPE && len(gd.Specs) != 0 {
-		pkgName = pageInfo.DocPackage.ImportPath
-		if ts, ok := gd.Specs[0].(*ast.TypeSpec); ok {
-			if _, ok := ts.Type.(*ast.StructType); ok {
-				structName = ts.Name.Name
+		p.Corpus != nil && len(gd.Specs) != 0 {
+		apiInfo := p.Corpus.pkgAPIInfo[pageInfo.DocPackage.ImportPath]
+		switch gd.Tok {
+		case token.TYPE:
+			if ts, ok := gd.Specs[0].(*ast.TypeSpec); ok {
+				switch ts.Type.(type) {
+				case *ast.StructType, *ast.InterfaceType:
+					since = apiInfo.fieldSince[ts.Name.Name]
+					typeSince = apiInfo.typeSince[ts.Name.Name]
+				}
 			}
+		case token.CONST:
+			since = apiInfo.constSince
+		case token.VAR:
+			since = apiInfo.varSince
 		}
-		apiInfo = p.Corpus.pkgAPIInfo[pkgName]
 	}
 
 	var out = w
 	var buf bytes.Buffer
-	if structName != "" {
+	if len(since) != 0 {
 		out = &buf
 	}
 
@@ -770,38 +877,36 @@ func (p *Presentation) writeNode(w io.Writer, pageInfo *PageInfo, fset *token.Fi
 		log.Print(err)
 	}
 
-	// Add comments to struct fields saying which Go version introduced them.
-	if structName != "" {
-		fieldSince := apiInfo.fieldSince[structName]
-		typeSince := apiInfo.typeSince[structName]
-		// Add/rewrite comments on struct fields to note which Go version added them.
+	// Add comments to struct fields, interface methods, constants and
+	// variables saying which Go version introduced them.
+	if len(since) != 0 {
+		// Add/rewrite comments on the declaring lines to note which Go version added them.
 		var buf2 bytes.Buffer
 		buf2.Grow(buf.Len() + len(" // Added in Go 1.n")*10)
+		seen := make(map[string]bool) // only the first line declaring a name is annotated
 		bs := bufio.NewScanner(&buf)
 		for bs.Scan() {
 			line := bs.Bytes()
-			field := firstIdent(line)
-			var since string
-			if field != "" {
-				since = fieldSince[field]
-				if since != "" && since == typeSince {
-					// Don't highlight field versions if they were the
-					// same as the struct itself.
-					since = ""
-				}
+			name := declaredName(line)
+			version := since[name]
+			if version != "" && (seen[name] || version == typeSince) {
+				// Don't highlight member versions if they were the
+				// same as the type itself.
+				version = ""
 			}
-			if since == "" {
+			seen[name] = true
+			if version == "" {
 				buf2.Write(line)
 			} else {
 				if bytes.Contains(line, slashSlash) {
 					line = bytes.TrimRight(line, " \t.")
 					buf2.Write(line)
-					buf2.WriteString("; added in Go ")
+					buf2.WriteString("; added in ")
 				} else {
 					buf2.Write(line)
-					buf2.WriteString(" // Go ")
+					buf2.WriteString(" // ")
 				}
-				buf2.WriteString(since)
+				buf2.WriteString(sinceLabelFunc(version))
 			}
 			buf2.WriteByte('\n')
 		}
@@ -817,6 +922,23 @@ func (p *Presentation) WriteNode(w io.Writer, fset *token.FileSet, x interface{}
 	p.writeNode(w, nil, fset, x)
 }
 
+// declaredName returns the name declared by the line of a printed
+// declaration: the first name of a constant or variable specification,
+// or the name of a struct field, an embedded field or an interface
+// method.
+func declaredName(line []byte) string {
+	line = bytes.TrimSpace(line)
+	for _, keyword := range [][]byte{[]byte("const "), []byte("var ")} {
+		line = bytes.TrimPrefix(line, keyword) // not grouped
+	}
+	line = bytes.TrimPrefix(line, []byte("*"))
+	name := firstIdent(line)
+	if rest := line[len(name):]; len(rest) > 0 && rest[0] == '.' {
+		name = firstIdent(rest[1:]) // qualified embedded field
+	}
+	return name
+}
+
 // firstIdent returns the first identifier in x.
 // This actually parses "identifiers" that begin with numbers too, but we
 // never feed it such input, so it's fine.
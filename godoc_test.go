@@ -2,10 +2,14 @@ package godoc
 
 import (
 	"bytes"
+	"go/doc"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"strings"
 	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
 )
 
 func TestPkgLinkFunc(t *testing.T) {
@@ -220,6 +224,68 @@ func (h Header) Get(key string) string`))
 	}
 }
 
+// Test that we add comments to the members and value specs added
+// after the first version of their package.
+func TestSinceComments(t *testing.T) {
+	src := `
+package foo
+
+type S struct {
+	Old int
+	New string // New has a comment.
+	*Embedded
+}
+
+type I interface {
+	M()
+	N() int
+}
+
+const (
+	A = iota
+	B
+)
+
+var V = 1
+`
+	fset := token.NewFileSet()
+	af, err := parser.ParseFile(fset, "foo.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewCorpus(nil)
+	c.pkgAPIInfo = apiVersions{"foo": {
+		typeSince:  map[string]string{"I": "1.2"},
+		fieldSince: map[string]map[string]string{"S": {"New": "1.3", "Embedded": "1.4"}, "I": {"M": "1.2", "N": "1.5"}},
+		constSince: map[string]string{"B": "1.6"},
+		varSince:   map[string]string{"V": "v1.1.0"},
+	}}
+	p := &Presentation{Corpus: c, TabWidth: 4}
+	pi := &PageInfo{FSet: fset, DocPackage: &doc.Package{ImportPath: "foo"}}
+	var buf bytes.Buffer
+	for _, decl := range af.Decls {
+		p.writeNode(&buf, pi, fset, decl) // annotated lines end in newlines
+	}
+	want := `type S struct {
+    Old int
+    New string // New has a comment; added in Go 1.3
+    *Embedded // Go 1.4
+}
+type I interface {
+    M()
+    N() int // Go 1.5
+}
+const (
+    A = iota
+    B // Go 1.6
+)
+var V = 1 // v1.1.0
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
 func linkifySource(t *testing.T, src []byte) string {
 	p := &Presentation{
 		DeclLinks: true,
@@ -364,3 +430,96 @@ func TestFilterOutBuildAnnotations(t *testing.T) {
 		t.Errorf("filterOutBuildAnnotations should not remove non-build tag comment")
 	}
 }
+
+func TestLinkifyTypedText(t *testing.T) {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/example.com/a/a.go": `package a
+
+type A struct{ F int }
+
+func New() *A { return nil }
+
+func (*A) M() int { return 0 }
+
+const Other = 1
+`,
+		"src/example.com/b/b.go": `package b
+
+import (
+	aa "example.com/a"
+	. "example.com/a"
+)
+
+var V = aa.New().M()
+
+var W = Other
+
+var X = aa.A{F: 1}
+
+func Use(x *aa.A) int { return x.F }
+`}))
+	pkg, err := c.SyntaxAnalysis("/src/example.com/b", ShowSource|NoFiltering|TypeCheck, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.TypesInfo == nil {
+		t.Fatal("package not type-checked")
+	}
+
+	p := &Presentation{DeclLinks: true}
+	pi := &PageInfo{FSet: pkg.FSet, TypesPkg: pkg.TypesPkg, TypesInfo: pkg.TypesInfo}
+	decls := pkg.PAst["example.com/b/b.go"].Decls
+	var buf bytes.Buffer
+	for _, decl := range decls {
+		buf.WriteString(p.node_htmlFunc(pi, decl, true))
+		buf.WriteString("\n")
+	}
+	got := buf.String()
+	for _, want := range []string{
+		`<a href="/pkg/example.com/a/">aa</a>.<a href="/pkg/example.com/a/#New">New</a>().<a href="/pkg/example.com/a/#A.M">M</a>()`,
+		`var <span id="W">W</span> = <a href="/pkg/example.com/a/#Other">Other</a>`,
+		`<a href="/pkg/example.com/a/#A.F">F</a>: 1`,
+		`return x.<a href="/pkg/example.com/a/#A.F">F</a>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %s in:\n%s", want, got)
+		}
+	}
+
+	// The links to other packages carry the build configuration.
+	pi.Build = BuildConfig{Tags: "integration", Cgo: "0"}
+	got = p.node_htmlFunc(pi, decls[len(decls)-1], true) // func Use
+	if want := `<a href="/pkg/example.com/a/?tags=integration&amp;cgo=0#A">A</a>`; !strings.Contains(got, want) {
+		t.Errorf("missing %s in:\n%s", want, got)
+	}
+}
+
+func TestTypeCheckStdVendor(t *testing.T) {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/vendor/golang.org/x/net/http/httpguts/guts.go": `package httpguts
+
+func ValidHeaderFieldName(v string) bool { return v != "" }
+`,
+		"src/net/http/header.go": `package http
+
+import "golang.org/x/net/http/httpguts"
+
+var Valid = httpguts.ValidHeaderFieldName("Accept")
+`}))
+	pkg, err := c.SyntaxAnalysis("/src/net/http", ShowSource|NoFiltering|TypeCheck, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.TypesInfo == nil {
+		t.Fatal("package not type-checked")
+	}
+	for id, obj := range pkg.TypesInfo.Uses {
+		if id.Name == "ValidHeaderFieldName" {
+			if _, ok := obj.(*types.Func); !ok || obj.Pkg() == nil || obj.Pkg().Name() != "httpguts" {
+				t.Errorf("ValidHeaderFieldName resolved to %v; want the function of the vendored httpguts", obj)
+			}
+			return
+		}
+	}
+	t.Error("ValidHeaderFieldName not resolved")
+}
@@ -0,0 +1,310 @@
+// This file contains the /graph/ handler, which renders the graph of
+// the packages imported by a package, directly or indirectly, as
+// Graphviz DOT or as SVG. The graph is taken from the import graph
+// of the corpus (see importgraph.go).
+//
+// The SVG layout is a simple layered layout: packages are placed in
+// rows by their distance from the root package; each row is ordered
+// by the mean position of the importing packages in the rows above,
+// which keeps most edges short and avoids many crossings.
+
+package godoc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	pathpkg "path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/miclle/godoc/vfs"
+)
+
+// defaultGraphDepth is the depth of a graph if none is specified.
+const defaultGraphDepth = 2
+
+// A depGraph is the graph of the packages imported by a root package,
+// directly or indirectly.
+type depGraph struct {
+	nodes []*depNode // root first, then by depth
+	edges []depEdge
+	query string // query string of the package links; see queryString
+}
+
+type depNode struct {
+	importPath string
+	depth      int // distance from the root
+	rootType   vfs.RootType
+
+	// SVG layout
+	order float64 // position in the row, while ordering
+	x, y  float64 // top left corner
+	width float64
+}
+
+type depEdge struct {
+	from, to *depNode
+}
+
+// dependencyGraph returns the graph of the packages imported by the
+// package with the given import path, up to maxDepth edges away from
+// it (without limit if maxDepth <= 0). If filter is not nil, only the
+// packages with an import path matching filter are included; if std is
+// not set, the packages in the GOROOT are not included. Excluded packages
+// are not followed. It returns nil if there is no such package.
+func (c *Corpus) dependencyGraph(importPath string, maxDepth int, filter *regexp.Regexp, std bool) *depGraph {
+	v, _ := c.fsTree.Get()
+	tree, _ := v.(*Directory)
+	if tree == nil {
+		return nil
+	}
+	root := tree.lookup(pathpkg.Join("/src", importPath))
+	if root == nil || !root.HasPkg {
+		return nil
+	}
+
+	g := new(depGraph)
+	nodes := make(map[string]*depNode)
+	add := func(d *Directory, depth int) *depNode {
+		n := &depNode{importPath: d.ImportPath, depth: depth, rootType: d.RootType}
+		nodes[n.importPath] = n
+		g.nodes = append(g.nodes, n)
+		return n
+	}
+	add(root, 0)
+
+	graph := c.currentImportGraph()
+	for i := 0; i < len(g.nodes); i++ { // g.nodes grows while walking
+		n := g.nodes[i]
+		if maxDepth > 0 && n.depth >= maxDepth {
+			continue
+		}
+		for _, path := range graph.imports[n.importPath] {
+			m := nodes[path]
+			if m == nil {
+				if filter != nil && !filter.MatchString(path) {
+					continue
+				}
+				d := tree.lookup(pathpkg.Join("/src", path))
+				if d == nil || !std && d.RootType == vfs.RootTypeGoRoot {
+					continue
+				}
+				m = add(d, n.depth+1)
+			}
+			g.edges = append(g.edges, depEdge{n, m})
+		}
+	}
+	return g
+}
+
+// nodeColor returns the fill color of a package node by the
+// root type of its file system.
+func nodeColor(n *depNode) string {
+	switch n.rootType {
+	case vfs.RootTypeGoRoot:
+		return "#e0ebf5" // standard library
+	case vfs.RootTypeGoPath:
+		return "#fff3d6" // third party
+	}
+	return "#ffffff"
+}
+
+// writeDOT writes g in the Graphviz DOT language to w.
+func (g *depGraph) writeDOT(w io.Writer) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "digraph %s {\n", strconv.Quote(g.nodes[0].importPath))
+	fmt.Fprintf(&buf, "\tnode [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	for _, n := range g.nodes {
+		attrs := fmt.Sprintf("fillcolor=%q, URL=%q", nodeColor(n), "/pkg/"+n.importPath+"/"+g.query)
+		if n.depth == 0 {
+			attrs += ", penwidth=2"
+		}
+		fmt.Fprintf(&buf, "\t%s [%s];\n", strconv.Quote(n.importPath), attrs)
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&buf, "\t%s -> %s;\n", strconv.Quote(e.from.importPath), strconv.Quote(e.to.importPath))
+	}
+	buf.WriteString("}\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// SVG layout parameters, in pixels
+const (
+	svgCharWidth  = 7  // approximate width of a character of the node labels
+	svgNodeHeight = 28 // height of a node
+	svgNodePad    = 10 // horizontal padding of a node label
+	svgNodeGap    = 16 // horizontal space between two nodes
+	svgRowGap     = 64 // vertical space between two rows
+	svgMargin     = 10
+)
+
+// layout computes the positions of the nodes of g for writeSVG and
+// returns the size of the drawing.
+func (g *depGraph) layout() (width, height float64) {
+	var rows [][]*depNode
+	for _, n := range g.nodes {
+		for len(rows) <= n.depth {
+			rows = append(rows, nil)
+		}
+		rows[n.depth] = append(rows[n.depth], n)
+		n.width = float64(len(n.importPath)*svgCharWidth + 2*svgNodePad)
+	}
+
+	// order each row by the mean position of the importing nodes in the rows above
+	parents := make(map[*depNode][]*depNode)
+	for _, e := range g.edges {
+		if e.from.depth < e.to.depth {
+			parents[e.to] = append(parents[e.to], e.from)
+		}
+	}
+	for _, row := range rows {
+		for _, n := range row {
+			if ps := parents[n]; len(ps) > 0 {
+				sum := 0.0
+				for _, p := range ps {
+					sum += p.order
+				}
+				n.order = sum / float64(len(ps))
+			}
+		}
+		sort.SliceStable(row, func(i, j int) bool {
+			if row[i].order != row[j].order {
+				return row[i].order < row[j].order
+			}
+			return row[i].importPath < row[j].importPath
+		})
+		for i, n := range row {
+			n.order = float64(i) / float64(len(row))
+		}
+	}
+
+	// place the rows, centered
+	rowWidths := make([]float64, len(rows))
+	for i, row := range rows {
+		for _, n := range row {
+			rowWidths[i] += n.width + svgNodeGap
+		}
+		rowWidths[i] -= svgNodeGap
+		if rowWidths[i] > width {
+			width = rowWidths[i]
+		}
+	}
+	for i, row := range rows {
+		x := svgMargin + (width-rowWidths[i])/2
+		for _, n := range row {
+			n.x = x
+			n.y = float64(svgMargin + i*(svgNodeHeight+svgRowGap))
+			x += n.width + svgNodeGap
+		}
+	}
+	width += 2 * svgMargin
+	height = float64(2*svgMargin + len(rows)*svgNodeHeight + (len(rows)-1)*svgRowGap)
+	return width, height
+}
+
+// writeSVG writes an SVG drawing of g to w.
+func (g *depGraph) writeSVG(w io.Writer) error {
+	width, height := g.layout()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Helvetica, Arial, sans-serif" font-size="12">`+"\n", width, height, width, height)
+	buf.WriteString(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="#666"/></marker></defs>` + "\n")
+
+	// edges first, so that the nodes are drawn on top of them
+	for _, e := range g.edges {
+		x1, y1 := e.from.x+e.from.width/2, e.from.y+svgNodeHeight
+		x2, y2 := e.to.x+e.to.width/2, e.to.y
+		if e.to.depth <= e.from.depth {
+			// an edge to the same row or back up: leave and enter from the side
+			x1, y1 = e.from.x+e.from.width, e.from.y+svgNodeHeight/2
+			x2, y2 = e.to.x+e.to.width, e.to.y+svgNodeHeight/2
+			bend := float64(svgRowGap)
+			fmt.Fprintf(&buf, `<path d="M%.1f,%.1f C%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="none" stroke="#999" stroke-dasharray="4,3" marker-end="url(#arrow)"/>`+"\n",
+				x1, y1, x1+bend, y1, x2+bend, y2, x2, y2)
+			continue
+		}
+		dy := (y2 - y1) / 2
+		fmt.Fprintf(&buf, `<path d="M%.1f,%.1f C%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="none" stroke="#666" marker-end="url(#arrow)"/>`+"\n",
+			x1, y1, x1, y1+dy, x2, y2-dy, x2, y2)
+	}
+
+	for _, n := range g.nodes {
+		strokeWidth := 1
+		if n.depth == 0 {
+			strokeWidth = 2
+		}
+		path := template.HTMLEscapeString(n.importPath)
+		fmt.Fprintf(&buf, `<a href="/pkg/%s/%s"><title>%s</title>`, path, template.HTMLEscapeString(g.query), path)
+		fmt.Fprintf(&buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%d" rx="6" fill="%s" stroke="#375eab" stroke-width="%d"/>`,
+			n.x, n.y, n.width, svgNodeHeight, nodeColor(n), strokeWidth)
+		fmt.Fprintf(&buf, `<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="central" fill="#222">%s</text></a>`+"\n",
+			n.x+n.width/2, n.y+svgNodeHeight/2, path)
+	}
+	buf.WriteString("</svg>\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// HandleGraph serves the graph of the packages imported by the package
+// with the import path given by the URL path /graph/<importpath>.
+// The form values are:
+//
+//	depth   maximum distance of the packages from the package (default 2; 0 means no limit)
+//	filter  regular expression the import paths of the packages must match
+//	std     if "0" or "false", exclude the packages in the GOROOT
+//	format  "svg" (default) or "dot"
+//
+// The links to the package pages carry the m and build configuration
+// values of the request, as the links of the package pages do.
+func (p *Presentation) HandleGraph(w http.ResponseWriter, r *http.Request) {
+	importPath := pathpkg.Clean(strings.TrimPrefix(r.URL.Path, "/graph/"))
+
+	depth := defaultGraphDepth
+	if s := r.FormValue("depth"); s != "" {
+		var err error
+		if depth, err = strconv.Atoi(s); err != nil || depth < 0 {
+			http.Error(w, "invalid depth: "+s, http.StatusBadRequest)
+			return
+		}
+	}
+	var filter *regexp.Regexp
+	if s := r.FormValue("filter"); s != "" {
+		var err error
+		if filter, err = regexp.Compile(s); err != nil {
+			http.Error(w, "invalid filter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	std := true
+	if s := r.FormValue("std"); s != "" {
+		var err error
+		if std, err = strconv.ParseBool(s); err != nil {
+			http.Error(w, "invalid std: "+s, http.StatusBadRequest)
+			return
+		}
+	}
+
+	g := p.Corpus.dependencyGraph(importPath, depth, filter, std)
+	if g == nil {
+		p.ServeError(w, r, importPath, fmt.Errorf("package %s not found", importPath))
+		return
+	}
+	g.query = queryString(p.GetPageInfoMode(r), getBuildConfig(r))
+	switch format := r.FormValue("format"); format {
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		g.writeDOT(w)
+	case "", "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		g.writeSVG(w)
+	default:
+		http.Error(w, "invalid format: "+format, http.StatusBadRequest)
+	}
+}
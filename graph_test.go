@@ -0,0 +1,135 @@
+package godoc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func newGraphTestCorpus(t *testing.T) *Corpus {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/a/a.go":   "package a\n\nimport (\n\t\"b\"\n\t\"c\"\n)\n",
+		"src/b/b.go":   "package b\n\nimport \"d\"\n",
+		"src/c/c.go":   "package c\n\nimport (\n\t\"d\"\n\t\"x/e\"\n)\n",
+		"src/d/d.go":   "package d\n\nimport \"x/e\"\n",
+		"src/x/e/e.go": "package e\n\nimport \"a\"\n",
+		"src/z/z.go":   "package z\n",
+	}))
+	c.IndexEnabled = false
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestDependencyGraph(t *testing.T) {
+	c := newGraphTestCorpus(t)
+
+	tests := []struct {
+		path   string
+		depth  int
+		filter string
+		nodes  []string
+		edges  []string
+	}{
+		{"z", 0, "", []string{"z"}, nil},
+		{"a", 1, "", []string{"a", "b", "c"}, []string{"a->b", "a->c"}},
+		{"a", 2, "", []string{"a", "b", "c", "d", "x/e"}, []string{"a->b", "a->c", "b->d", "c->d", "c->x/e"}},
+		{"a", 0, "", []string{"a", "b", "c", "d", "x/e"}, []string{"a->b", "a->c", "b->d", "c->d", "c->x/e", "d->x/e", "x/e->a"}},
+		{"a", 0, "^[a-d]$", []string{"a", "b", "c", "d"}, []string{"a->b", "a->c", "b->d", "c->d"}},
+	}
+	for _, test := range tests {
+		var filter *regexp.Regexp
+		if test.filter != "" {
+			filter = regexp.MustCompile(test.filter)
+		}
+		g := c.dependencyGraph(test.path, test.depth, filter, true)
+		if g == nil {
+			t.Fatalf("dependencyGraph(%s) = nil", test.path)
+		}
+		var nodes, edges []string
+		for _, n := range g.nodes {
+			nodes = append(nodes, n.importPath)
+		}
+		for _, e := range g.edges {
+			edges = append(edges, e.from.importPath+"->"+e.to.importPath)
+		}
+		if !reflect.DeepEqual(nodes, test.nodes) || !reflect.DeepEqual(edges, test.edges) {
+			t.Errorf("dependencyGraph(%s, %d, %q) = %q, %q; want %q, %q", test.path, test.depth, test.filter, nodes, edges, test.nodes, test.edges)
+		}
+	}
+
+	if g := c.dependencyGraph("missing", 0, nil, true); g != nil {
+		t.Errorf("dependencyGraph(missing) = %v; want nil", g)
+	}
+}
+
+func TestHandleGraph(t *testing.T) {
+	p := &Presentation{Corpus: newGraphTestCorpus(t)}
+
+	rec := httptest.NewRecorder()
+	p.HandleGraph(rec, httptest.NewRequest("GET", "/graph/a?format=dot&depth=1", nil))
+	want := `digraph "a" {
+	node [shape=box, style="rounded,filled", fontname="Helvetica"];
+	"a" [fillcolor="#ffffff", URL="/pkg/a/", penwidth=2];
+	"b" [fillcolor="#ffffff", URL="/pkg/b/"];
+	"c" [fillcolor="#ffffff", URL="/pkg/c/"];
+	"a" -> "b";
+	"a" -> "c";
+}
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("DOT graph:\n%s\nwant:\n%s", got, want)
+	}
+
+	rec = httptest.NewRecorder()
+	p.HandleGraph(rec, httptest.NewRequest("GET", "/graph/a?depth=0", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "image/svg+xml" {
+		t.Errorf("SVG content type = %q", ct)
+	}
+	// the SVG must be well-formed XML with a node per package
+	dec := xml.NewDecoder(bytes.NewReader(rec.Body.Bytes()))
+	rects := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid SVG: %v\n%s", err, rec.Body.String())
+		}
+		if el, ok := tok.(xml.StartElement); ok && el.Name.Local == "rect" {
+			rects++
+		}
+	}
+	if rects != 5 {
+		t.Errorf("SVG graph has %d nodes; want 5", rects)
+	}
+
+	// The package links carry the build configuration.
+	rec = httptest.NewRecorder()
+	p.HandleGraph(rec, httptest.NewRequest("GET", "/graph/a?depth=1&tags=integration&cgo=0", nil))
+	if want := `<a href="/pkg/b/?tags=integration&amp;cgo=0">`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("SVG graph does not link %s:\n%s", want, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	p.HandleGraph(rec, httptest.NewRequest("GET", "/graph/a?depth=1&format=dot&tags=integration", nil))
+	if want := `URL="/pkg/b/?tags=integration"`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("DOT graph does not link %s:\n%s", want, rec.Body.String())
+	}
+
+	for _, query := range []string{"depth=x", "filter=(", "format=png", "std=maybe"} {
+		rec = httptest.NewRecorder()
+		p.HandleGraph(rec, httptest.NewRequest("GET", "/graph/a?"+query, nil))
+		if rec.Code != 400 || !strings.HasPrefix(rec.Body.String(), "invalid") {
+			t.Errorf("%s: got %d %q; want 400", query, rec.Code, rec.Body.String())
+		}
+	}
+}
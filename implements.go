@@ -0,0 +1,201 @@
+// This file contains the corpus-wide implements analysis: for
+// each exported named type of the packages in the directory tree,
+// the interfaces it implements and, for interfaces, the types
+// implementing them.
+//
+// Algorithm:
+// - type-check all packages of the directory tree from source,
+//   without function bodies, sharing the imported packages
+// - collect the exported, non-generic named types; ignore the
+//   empty interfaces, which all types implement
+// - index the interfaces by the name of one of their methods;
+//   a type can only implement the interfaces indexed by the
+//   names in the method set of its pointer type
+// - check these candidates with types.Implements, for the type
+//   and for the pointer to it
+
+package godoc
+
+import (
+	"go/types"
+	"log"
+	"sort"
+	"time"
+)
+
+// A TypeRef refers to an exported named type of the corpus.
+type TypeRef struct {
+	ImportPath string // import path of the declaring package
+	Package    string // name of the declaring package
+	Name       string // type name
+	Pointer    bool   // the relation holds for pointers only (see Implementations)
+}
+
+// Implementations describes the implements relation of a named type T
+// with the other exported named types of the corpus. A TypeRef in
+// Implements with Pointer set is an interface implemented by *T but
+// not by T; a TypeRef in ImplementedBy with Pointer set is a type
+// whose pointer type implements T but not the type itself.
+type Implementations struct {
+	Implements    []TypeRef // interfaces implemented by the type
+	ImplementedBy []TypeRef // for interfaces: types implementing the interface
+}
+
+// implementsIndex maps import paths and type names to the
+// implementations of the type.
+type implementsIndex map[string]map[string]*Implementations
+
+// Implementations returns the implements relations of the exported
+// named types of the package with the given import path, by type
+// name. It returns nil if ImplementsEnabled is not set or if the
+// analysis is not complete yet.
+func (c *Corpus) Implementations(importPath string) map[string]*Implementations {
+	v, _ := c.implements.Get()
+	x, _ := v.(implementsIndex)
+	return x[importPath]
+}
+
+func (c *Corpus) updateImplements() {
+	if c.Verbose {
+		log.Printf("updating implements analysis...")
+	}
+	start := time.Now()
+	x := c.analyzeImplements()
+	c.implements.Set(x)
+	if c.Verbose {
+		log.Printf("implements analysis updated (%gs, %d packages)", time.Since(start).Seconds(), len(x))
+	}
+}
+
+// analyzeImplements computes the implements relations of the
+// packages in the directory tree.
+func (c *Corpus) analyzeImplements() implementsIndex {
+	v, _ := c.fsTree.Get()
+	tree, _ := v.(*Directory)
+	if tree == nil {
+		return nil
+	}
+	src := tree.lookup("/src")
+	if src == nil {
+		return nil
+	}
+
+	// collect the exported named types
+	ctxt := c.buildContext(0, BuildConfig{})
+	imp := newVFSImporter(c, &ctxt)
+	var ifaces, others []*types.TypeName
+	var walk func(d *Directory)
+	walk = func(d *Directory) {
+		for _, sub := range d.SubDirectories {
+			walk(sub)
+		}
+		if !d.HasPkg {
+			return
+		}
+		pkg, _ := imp.importDir(d.Path)
+		if pkg == nil {
+			return
+		}
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			obj, _ := scope.Lookup(name).(*types.TypeName)
+			if obj == nil || !obj.Exported() || obj.IsAlias() || isGeneric(obj) {
+				continue
+			}
+			if iface, _ := obj.Type().Underlying().(*types.Interface); iface != nil {
+				if iface.NumMethods() > 0 {
+					ifaces = append(ifaces, obj)
+				}
+				continue
+			}
+			others = append(others, obj)
+		}
+	}
+	walk(src)
+
+	// index the interfaces by the name of their first method
+	byMethod := make(map[string][]*types.TypeName)
+	for _, obj := range ifaces {
+		iface := obj.Type().Underlying().(*types.Interface)
+		name := iface.Method(0).Name()
+		byMethod[name] = append(byMethod[name], obj)
+	}
+
+	x := make(implementsIndex)
+	lookup := func(obj *types.TypeName) *Implementations {
+		path := obj.Pkg().Path()
+		m := x[path]
+		if m == nil {
+			m = make(map[string]*Implementations)
+			x[path] = m
+		}
+		impls := m[obj.Name()]
+		if impls == nil {
+			impls = new(Implementations)
+			m[obj.Name()] = impls
+		}
+		return impls
+	}
+	record := func(typ, iface *types.TypeName, pointer bool) {
+		t := lookup(typ)
+		t.Implements = append(t.Implements, typeRef(iface, pointer))
+		i := lookup(iface)
+		i.ImplementedBy = append(i.ImplementedBy, typeRef(typ, pointer))
+	}
+
+	for _, obj := range append(others, ifaces...) {
+		typ := obj.Type()
+		isIface := types.IsInterface(typ)
+		mset := types.NewMethodSet(typ)
+		if !isIface {
+			mset = types.NewMethodSet(types.NewPointer(typ))
+		}
+		for i := 0; i < mset.Len(); i++ {
+			for _, iobj := range byMethod[mset.At(i).Obj().Name()] {
+				if iobj == obj {
+					continue
+				}
+				iface := iobj.Type().Underlying().(*types.Interface)
+				switch {
+				case types.Implements(typ, iface):
+					record(obj, iobj, false)
+				case !isIface && types.Implements(types.NewPointer(typ), iface):
+					record(obj, iobj, true)
+				}
+			}
+		}
+	}
+
+	for _, m := range x {
+		for _, impls := range m {
+			sortTypeRefs(impls.Implements)
+			sortTypeRefs(impls.ImplementedBy)
+		}
+	}
+	return x
+}
+
+// isGeneric reports whether obj is a generic type. go/types leaves the
+// behavior of types.Implements unspecified for uninstantiated types.
+func isGeneric(obj *types.TypeName) bool {
+	named, ok := obj.Type().(*types.Named)
+	return ok && named.TypeParams().Len() > 0
+}
+
+func typeRef(obj *types.TypeName, pointer bool) TypeRef {
+	return TypeRef{
+		ImportPath: obj.Pkg().Path(),
+		Package:    obj.Pkg().Name(),
+		Name:       obj.Name(),
+		Pointer:    pointer,
+	}
+}
+
+func sortTypeRefs(list []TypeRef) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ImportPath != list[j].ImportPath {
+			return list[i].ImportPath < list[j].ImportPath
+		}
+		return list[i].Name < list[j].Name
+	})
+}
@@ -0,0 +1,92 @@
+package godoc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestImplementations(t *testing.T) {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/io/io.go": `package io
+
+type Reader interface{ Read(p []byte) (int, error) }
+
+type ReadCloser interface {
+	Reader
+	Close() error
+}
+
+type Empty interface{}
+`,
+		"src/foo/foo.go": `package foo
+
+import "io"
+
+type File struct{}
+
+func (*File) Read(p []byte) (int, error) { return 0, nil }
+
+func (File) Close() error { return nil }
+
+type Value struct{}
+
+func (Value) Read(p []byte) (int, error) { return 0, nil }
+
+type unexported struct{}
+
+func (unexported) Read(p []byte) (int, error) { return 0, nil }
+
+type List[T any] struct{}
+
+func (List[T]) Read(p []byte) (int, error) { return 0, nil }
+
+var _ io.Reader = Value{}
+`,
+	}))
+	c.IndexEnabled = false
+	c.ImplementsEnabled = true
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	c.WaitIndex()
+
+	reader := TypeRef{ImportPath: "io", Package: "io", Name: "Reader"}
+	readCloser := TypeRef{ImportPath: "io", Package: "io", Name: "ReadCloser"}
+	file := TypeRef{ImportPath: "foo", Package: "foo", Name: "File"}
+	value := TypeRef{ImportPath: "foo", Package: "foo", Name: "Value"}
+	ptr := func(r TypeRef) TypeRef {
+		r.Pointer = true
+		return r
+	}
+
+	io := c.Implementations("io")
+	if got, want := io["Reader"], (&Implementations{
+		ImplementedBy: []TypeRef{ptr(file), value, readCloser},
+	}); !reflect.DeepEqual(got, want) {
+		t.Errorf("io.Reader: got %+v; want %+v", got, want)
+	}
+	if got, want := io["ReadCloser"], (&Implementations{
+		Implements:    []TypeRef{reader},
+		ImplementedBy: []TypeRef{ptr(file)},
+	}); !reflect.DeepEqual(got, want) {
+		t.Errorf("io.ReadCloser: got %+v; want %+v", got, want)
+	}
+	if got := io["Empty"]; got != nil {
+		t.Errorf("io.Empty: got %+v; want nil", got)
+	}
+
+	foo := c.Implementations("foo")
+	if got, want := foo["File"], (&Implementations{
+		Implements: []TypeRef{ptr(readCloser), ptr(reader)},
+	}); !reflect.DeepEqual(got, want) {
+		t.Errorf("foo.File: got %+v; want %+v", got, want)
+	}
+	if got := foo["unexported"]; got != nil {
+		t.Errorf("foo.unexported: got %+v; want nil", got)
+	}
+	if got := foo["List"]; got != nil {
+		t.Errorf("generic foo.List: got %+v; want nil", got)
+	}
+}
@@ -0,0 +1,131 @@
+// This file contains the import graph of the packages in the
+// directory tree of a corpus. It is computed from the imports
+// recorded in the directory tree (Directory.Imports) whenever
+// the tree is set, that is when it is built, read from index
+// files or synced.
+//
+// Import paths are resolved as the go command does in GOPATH
+// mode: the closest vendor directory of the importing package
+// takes precedence over /src. Imports of packages which are not
+// in the tree are ignored.
+
+package godoc
+
+import (
+	pathpkg "path"
+	"sort"
+	"strings"
+)
+
+// An importGraph is the import graph of the packages in a directory
+// tree. Packages are identified by the import paths of their
+// directories (Directory.ImportPath).
+type importGraph struct {
+	imports    map[string][]string // imported packages, by importing package
+	importedBy map[string][]string // importing packages, by imported package
+}
+
+// newImportGraph returns the import graph of the packages in tree.
+func newImportGraph(tree *Directory) *importGraph {
+	g := &importGraph{
+		imports:    make(map[string][]string),
+		importedBy: make(map[string][]string),
+	}
+	if tree != nil {
+		tree = tree.lookup("/src")
+	}
+	if tree == nil {
+		return g
+	}
+
+	// collect the package directories
+	packages := make(map[string]*Directory) // by path
+	var walk func(d *Directory)
+	walk = func(d *Directory) {
+		if d.HasPkg {
+			packages[d.Path] = d
+		}
+		for _, sub := range d.SubDirectories {
+			walk(sub)
+		}
+	}
+	walk(tree)
+
+	// resolve returns the package imported by importPath in dir, or nil
+	resolve := func(dir, importPath string) *Directory {
+		for ; dir == "/src" || strings.HasPrefix(dir, "/src/"); dir = pathpkg.Dir(dir) {
+			if d := packages[pathpkg.Join(dir, "vendor", importPath)]; d != nil {
+				return d
+			}
+		}
+		return packages[pathpkg.Join("/src", importPath)]
+	}
+
+	for _, d := range packages {
+		for _, importPath := range d.Imports {
+			imported := resolve(d.Path, importPath)
+			if imported == nil || imported == d {
+				continue
+			}
+			g.imports[d.ImportPath] = append(g.imports[d.ImportPath], imported.ImportPath)
+			g.importedBy[imported.ImportPath] = append(g.importedBy[imported.ImportPath], d.ImportPath)
+		}
+	}
+	for _, list := range g.imports {
+		sort.Strings(list)
+	}
+	for _, list := range g.importedBy {
+		sort.Strings(list)
+	}
+	return g
+}
+
+// transitiveImporters returns the sorted import paths of the packages
+// importing the package with the given import path, directly or indirectly.
+func (g *importGraph) transitiveImporters(importPath string) []string {
+	seen := map[string]bool{importPath: true}
+	queue := []string{importPath}
+	var list []string
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		for _, importer := range g.importedBy[path] {
+			if !seen[importer] {
+				seen[importer] = true
+				list = append(list, importer)
+				queue = append(queue, importer)
+			}
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+func (c *Corpus) currentImportGraph() *importGraph {
+	v, _ := c.importGraph.Get()
+	g, _ := v.(*importGraph)
+	if g == nil {
+		g = newImportGraph(nil)
+	}
+	return g
+}
+
+// Imports returns the sorted import paths of the packages in the
+// directory tree imported by the package with the given import path,
+// for all platforms.
+func (c *Corpus) Imports(importPath string) []string {
+	return c.currentImportGraph().imports[importPath]
+}
+
+// Importers returns the sorted import paths of the packages in the
+// directory tree importing the package with the given import path.
+func (c *Corpus) Importers(importPath string) []string {
+	return c.currentImportGraph().importedBy[importPath]
+}
+
+// TransitiveImporters returns the sorted import paths of the packages
+// in the directory tree importing the package with the given import
+// path, directly or indirectly.
+func (c *Corpus) TransitiveImporters(importPath string) []string {
+	return c.currentImportGraph().transitiveImporters(importPath)
+}
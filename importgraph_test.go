@@ -0,0 +1,65 @@
+package godoc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestImportGraph(t *testing.T) {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/a/a.go":                         "package a\n",
+		"src/b/b.go":                         "package b\n\nimport (\n\t\"a\"\n\t\"C\"\n)\n",
+		"src/b/b_test.go":                    "package b\n\nimport \"d\"\n",
+		"src/c/c.go":                         "package c\n\nimport _ \"b\"\n",
+		"src/c/gen.go":                       "// +build ignore\n\npackage main\n\nimport \"d\"\n",
+		"src/d/d.go":                         "package d\n\nimport (\n\t\"a\"\n\t\"missing\"\n)\n",
+		"src/e/e.go":                         "package e\n\nimport \"a\"\n",
+		"src/e/vendor/a/a.go":                "package a\n",
+		"src/e/sub/sub.go":                   "package sub\n\nimport \"a\"\n",
+		"src/f/f_windows.go":                 "package f\n\nimport \"c\"\n",
+		"src/f/f_linux.go":                   "package f\n\nimport \"d\"\n",
+		"src/g/g.go":                         "package g\n\nimport \"golang.org/x/net/h\"\n",
+		"src/vendor/golang.org/x/net/h/h.go": "package h\n",
+	}))
+	c.IndexEnabled = false
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path                string
+		imports, importers  []string
+		transitiveImporters []string
+	}{
+		{"a", nil, []string{"b", "d"}, []string{"b", "c", "d", "f"}},
+		{"b", []string{"a"}, []string{"c"}, []string{"c", "f"}},
+		{"c", []string{"b"}, []string{"f"}, []string{"f"}},
+		{"e", []string{"e/vendor/a"}, nil, nil},
+		{"e/sub", []string{"e/vendor/a"}, nil, nil},
+		{"e/vendor/a", nil, []string{"e", "e/sub"}, []string{"e", "e/sub"}},
+		{"f", []string{"c", "d"}, nil, nil},
+		{"g", []string{"vendor/golang.org/x/net/h"}, nil, nil},
+		{"vendor/golang.org/x/net/h", nil, []string{"g"}, []string{"g"}},
+	}
+	for _, test := range tests {
+		if got := c.Imports(test.path); !reflect.DeepEqual(got, test.imports) {
+			t.Errorf("Imports(%s) = %q; want %q", test.path, got, test.imports)
+		}
+		if got := c.Importers(test.path); !reflect.DeepEqual(got, test.importers) {
+			t.Errorf("Importers(%s) = %q; want %q", test.path, got, test.importers)
+		}
+		if got := c.TransitiveImporters(test.path); !reflect.DeepEqual(got, test.transitiveImporters) {
+			t.Errorf("TransitiveImporters(%s) = %q; want %q", test.path, got, test.transitiveImporters)
+		}
+	}
+
+	p, err := c.Package("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"b", "d"}; !reflect.DeepEqual(p.ImportedBy, want) {
+		t.Errorf("Package(a).ImportedBy = %q; want %q", p.ImportedBy, want)
+	}
+}
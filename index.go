@@ -0,0 +1,574 @@
+// This file contains the infrastructure to create an
+// identifier and full-text index for a set of Go files.
+//
+// Algorithm for identifier index:
+// - traverse all .go files of the package directories in the
+//   corpus directory tree
+// - for each identifier (word) encountered, collect all occurrences
+//   (spots) into a list; this produces a list of spots for each word
+// - declarations get a snippet of the declaring source; uses only
+//   record the line on which they occur
+// - sort each list of spots by file and line so that lookups can
+//   group them by SpotKind without further sorting
+//
+// Algorithm for full text index:
+// - concatenate all source code in a byte buffer (in memory)
+// - record the start and end offset of each file in the byte buffer
+// - create a suffix array from the concatenated sources
+//
+// String lookup in full text index:
+// - use the suffix array to lookup a string's offsets
+// - translate the offsets back into file and line information and
+//   sort the result
+
+package godoc
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"index/suffixarray"
+	"log"
+	pathpkg "path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miclle/godoc/util"
+	"github.com/miclle/godoc/vfs"
+)
+
+// ----------------------------------------------------------------------------
+// Pak, File, Spot
+
+// A Pak describes a Go package.
+type Pak struct {
+	Path string // path of directory containing the package
+	Name string // package name as declared by package clause
+}
+
+// A File describes a Go file.
+type File struct {
+	Name string // directory-local file name
+	Pak  *Pak   // the package to which the file belongs
+}
+
+// Path returns the file path of f.
+func (f *File) Path() string {
+	return pathpkg.Join(f.Pak.Path, f.Name)
+}
+
+// A Spot describes a single occurrence of a word.
+type Spot struct {
+	File *File
+	Info SpotInfo
+}
+
+// A SpotRun is a list of spots of the same SpotKind,
+// sorted by file path and line.
+type SpotRun struct {
+	Kind  SpotKind
+	Spots []Spot
+}
+
+// ----------------------------------------------------------------------------
+// Indexer
+
+// Statistics provides statistics information for an index.
+type Statistics struct {
+	Bytes int // total size of indexed source files
+	Files int // number of indexed source files
+	Lines int // number of lines (all files)
+	Words int // number of different identifiers
+	Spots int // number of identifier occurrences
+}
+
+// An Indexer maintains the data structures and provides the machinery
+// for indexing .go files under a directory tree. It implements the
+// ast.Visitor interface for walking Go ASTs.
+type Indexer struct {
+	c        *Corpus
+	fset     *token.FileSet    // file set for the current file
+	throttle *util.Throttle    // controls the indexing speed
+	packages map[Pak]*Pak      // maps package directory and name to *Pak; interned
+	words    map[string][]Spot // maps identifiers to their spots
+	snippets []*Snippet        // all snippets
+	sources  bytes.Buffer      // concatenated sources, for full text index
+	files    []indexedFile     // files in the order they were added to sources
+	current  *File             // current file
+	decl     ast.Decl          // current decl
+	stats    Statistics
+
+	// references to exported identifiers (see refs.go)
+	imports  map[string]string           // package names of the imports of the current file
+	scope    *ast.Scope                  // package-level scope of the current file
+	declared map[string]bool             // exported package-level identifiers of the current package
+	pending  []pendingRef                // unresolved references of the current directory
+	refs     map[string]map[string][]Ref // by import path and identifier
+
+	// deprecated packages and declarations (see deprecated.go)
+	deprecated     []Deprecation
+	deprecatedSeen map[string]bool // by directory and name; files for several platforms may repeat them
+}
+
+// An indexedFile records where the source of a file
+// starts and ends in the concatenated sources.
+type indexedFile struct {
+	file       *File
+	start, end int
+}
+
+func (x *Indexer) addSnippet(s *Snippet) int {
+	index := len(x.snippets)
+	x.snippets = append(x.snippets, s)
+	return index
+}
+
+func (x *Indexer) visitIdent(kind SpotKind, id *ast.Ident) {
+	if id == nil {
+		return
+	}
+
+	var info SpotInfo
+	if kind == Use || x.decl == nil {
+		// not a declaration or no snippet required
+		info = makeSpotInfo(kind, x.fset.Position(id.Pos()).Line, false)
+	} else {
+		// a declaration with snippet
+		index := x.addSnippet(NewSnippet(x.fset, x.decl, id))
+		info = makeSpotInfo(kind, index, true)
+	}
+
+	x.words[id.Name] = append(x.words[id.Name], Spot{x.current, info})
+	x.stats.Spots++
+}
+
+func (x *Indexer) visitFieldList(kind SpotKind, flist *ast.FieldList) {
+	for _, f := range flist.List {
+		x.decl = nil // no snippets for fields
+		for _, name := range f.Names {
+			x.visitIdent(kind, name)
+		}
+		ast.Walk(x, f.Type)
+		// ignore tag - not indexed at the moment
+	}
+}
+
+func (x *Indexer) visitSpec(kind SpotKind, spec ast.Spec) {
+	switch n := spec.(type) {
+	case *ast.ImportSpec:
+		x.visitIdent(ImportDecl, n.Name)
+
+	case *ast.ValueSpec:
+		for _, n := range n.Names {
+			x.visitIdent(kind, n)
+		}
+		ast.Walk(x, n.Type)
+		for _, v := range n.Values {
+			ast.Walk(x, v)
+		}
+
+	case *ast.TypeSpec:
+		x.visitIdent(TypeDecl, n.Name)
+		ast.Walk(x, n.Type)
+	}
+}
+
+func (x *Indexer) visitGenDecl(decl *ast.GenDecl) {
+	kind := VarDecl
+	if decl.Tok == token.CONST {
+		kind = ConstDecl
+	}
+	for _, s := range decl.Specs {
+		x.visitSpec(kind, s)
+	}
+}
+
+// Visit implements ast.Visitor.
+func (x *Indexer) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case nil:
+		// nothing to do
+
+	case *ast.Ident:
+		x.visitIdent(Use, n)
+		x.visitLocalRef(n)
+
+	case *ast.SelectorExpr:
+		if path := x.importPathOf(n.X); path != "" {
+			x.visitIdent(Use, n.X.(*ast.Ident))
+			x.visitIdent(Use, n.Sel)
+			x.addRef(path, n.Sel.Name, x.newRef(n.Sel))
+			break
+		}
+		ast.Walk(x, n.X)
+		x.visitIdent(Use, n.Sel) // a field or method: not a reference
+
+	case *ast.KeyValueExpr:
+		if key, ok := n.Key.(*ast.Ident); ok {
+			x.visitIdent(Use, key) // possibly a field name: not a reference
+		} else {
+			ast.Walk(x, n.Key)
+		}
+		ast.Walk(x, n.Value)
+
+	case *ast.FieldList:
+		x.visitFieldList(VarDecl, n)
+
+	case *ast.InterfaceType:
+		x.visitFieldList(MethodDecl, n.Methods)
+
+	case *ast.DeclStmt:
+		// local declarations should only be *ast.GenDecls;
+		// ignore incorrect ASTs
+		if decl, ok := n.Decl.(*ast.GenDecl); ok {
+			x.decl = nil // no snippets for local declarations
+			x.visitGenDecl(decl)
+		}
+
+	case *ast.GenDecl:
+		x.decl = n
+		x.visitGenDecl(n)
+
+	case *ast.FuncDecl:
+		kind := FuncDecl
+		if n.Recv != nil {
+			kind = MethodDecl
+			ast.Walk(x, n.Recv)
+		}
+		x.decl = n
+		x.visitIdent(kind, n.Name)
+		ast.Walk(x, n.Type)
+		if n.Body != nil {
+			ast.Walk(x, n.Body)
+		}
+
+	case *ast.File:
+		x.decl = nil
+		x.imports = fileImports(n)
+		x.scope = n.Scope
+		x.declare(n)
+		x.visitDeprecations(n)
+		x.visitIdent(PackageClause, n.Name)
+		for _, d := range n.Decls {
+			ast.Walk(x, d)
+		}
+
+	default:
+		return x
+	}
+
+	return nil
+}
+
+// indexFile parses and indexes the Go file with the given path
+// in directory dir.
+func (x *Indexer) indexFile(dir *Directory, filename string) {
+	path := pathpkg.Join(dir.Path, filename)
+	src, err := vfs.ReadFile(x.c.fs, path)
+	if err != nil {
+		if x.c.Verbose {
+			log.Printf("indexing %s: %v", path, err)
+		}
+		return
+	}
+
+	// Record the original source for the full text index before
+	// parsing; the parser works on a (modified) copy.
+	start := x.sources.Len()
+	if x.c.IndexFullText {
+		x.sources.Write(src)
+		x.sources.WriteByte('\n')
+	}
+	end := x.sources.Len()
+
+	// Temporary ad-hoc fix for issue 5247, see Corpus.parseFile.
+	src = append([]byte(nil), src...)
+	replaceLinePrefixCommentsWithBlankLine(src)
+
+	x.fset = token.NewFileSet()
+	file, err := parser.ParseFile(x.fset, path, src, parser.ParseComments)
+	if err != nil {
+		if x.c.Verbose {
+			log.Printf("indexing %s: %v", path, err)
+		}
+		x.sources.Truncate(start)
+		return
+	}
+
+	// Intern the package; test files of external test packages
+	// live in the same directory under a different package name.
+	key := Pak{Path: dir.Path, Name: file.Name.Name}
+	pak := x.packages[key]
+	if pak == nil {
+		pak = &key
+		x.packages[key] = pak
+	}
+	x.current = &File{Name: filename, Pak: pak}
+
+	if x.c.IndexFullText {
+		x.files = append(x.files, indexedFile{x.current, start, end})
+	}
+
+	ast.Walk(x, file)
+
+	x.stats.Bytes += len(src)
+	x.stats.Files++
+	x.stats.Lines += bytes.Count(src, []byte{'\n'}) + 1
+}
+
+// indexDirectory indexes all Go files in the package directories of
+// the directory tree dir.
+func (x *Indexer) indexDirectory(dir *Directory) {
+	if dir == nil {
+		return
+	}
+
+	if dir.HasPkg {
+		ioGate <- struct{}{}
+		list, err := x.c.fs.ReadDir(dir.Path)
+		<-ioGate
+		if err != nil && x.c.Verbose {
+			log.Printf("indexing %s: %v", dir.Path, err)
+		}
+		for _, fi := range list {
+			if isGoFile(fi) {
+				x.throttle.Throttle()
+				x.indexFile(dir, fi.Name())
+			}
+		}
+		x.resolveRefs(dir)
+	}
+
+	for _, d := range dir.SubDirectories {
+		x.indexDirectory(d)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Index
+
+// An Index provides identifier and full text lookup for the
+// Go files of a corpus.
+type Index struct {
+	words      map[string][]Spot // maps identifiers to their spots
+	snippets   []*Snippet        // all snippets, indexed by SpotInfo.Lori
+	fulltext   *suffixarray.Index
+	files      []indexedFile               // files of the full text index, sorted by start offset
+	refs       map[string]map[string][]Ref // see Refs
+	deprecated []Deprecation               // see Deprecations
+	stats      Statistics
+}
+
+// NewIndex creates a new index for the .go files provided by the corpus.
+func (c *Corpus) NewIndex() *Index {
+	throttle := c.IndexThrottle
+	if throttle == 0 {
+		throttle = 0.9
+	}
+
+	x := &Indexer{
+		c:        c,
+		throttle: util.NewThrottle(throttle, 100*time.Millisecond), // run at least 0.1s at a time
+		packages: make(map[Pak]*Pak),
+		words:    make(map[string][]Spot),
+		declared: make(map[string]bool),
+		refs:     make(map[string]map[string][]Ref),
+
+		deprecatedSeen: make(map[string]bool),
+	}
+
+	// index all files in the directories given by dirnames
+	if tree, _ := c.fsTree.Get(); tree != nil {
+		x.indexDirectory(tree.(*Directory).lookup("/src"))
+	}
+
+	// sort the spots of each word by file and line
+	for _, spots := range x.words {
+		sort.Sort(spotsByPosition{spots, x.snippets})
+	}
+	x.stats.Words = len(x.words)
+
+	// sort the references of each identifier by file and position
+	for _, m := range x.refs {
+		for _, refs := range m {
+			sort.Sort(refsByPosition(refs))
+		}
+	}
+
+	sort.Sort(deprecationsByName(x.deprecated))
+
+	// create text index
+	var fulltext *suffixarray.Index
+	if c.IndexFullText {
+		fulltext = suffixarray.New(x.sources.Bytes())
+	}
+
+	return &Index{
+		words:      x.words,
+		snippets:   x.snippets,
+		fulltext:   fulltext,
+		files:      x.files,
+		refs:       x.refs,
+		deprecated: x.deprecated,
+		stats:      x.stats,
+	}
+}
+
+// Stats returns index statistics.
+func (x *Index) Stats() Statistics {
+	return x.stats
+}
+
+// Snippet returns the i'th snippet of the index.
+func (x *Index) Snippet(i int) *Snippet {
+	// handle illegal snippet indices gracefully
+	if 0 <= i && i < len(x.snippets) {
+		return x.snippets[i]
+	}
+	return nil
+}
+
+// spotLine returns the line number of the spot described by info.
+func spotLine(info SpotInfo, snippets []*Snippet) int {
+	if info.IsIndex() {
+		if i := info.Lori(); 0 <= i && i < len(snippets) {
+			return snippets[i].Line
+		}
+		return 0
+	}
+	return info.Lori()
+}
+
+type spotsByPosition struct {
+	spots    []Spot
+	snippets []*Snippet
+}
+
+func (s spotsByPosition) Len() int      { return len(s.spots) }
+func (s spotsByPosition) Swap(i, j int) { s.spots[i], s.spots[j] = s.spots[j], s.spots[i] }
+func (s spotsByPosition) Less(i, j int) bool {
+	pi, pj := s.spots[i].File.Path(), s.spots[j].File.Path()
+	if pi != pj {
+		return pi < pj
+	}
+	return spotLine(s.spots[i].Info, s.snippets) < spotLine(s.spots[j].Info, s.snippets)
+}
+
+// LookupWord returns the spots of the identifier name, grouped by
+// SpotKind. If pkg is not empty, only spots in packages with that
+// name or import path are returned. At most max spots are returned
+// if max > 0.
+func (x *Index) LookupWord(pkg, name string, max int) []*SpotRun {
+	var runs [nKinds]*SpotRun
+	n := 0
+	for _, s := range x.words[name] {
+		if pkg != "" && !matchPackage(s.File.Pak, pkg) {
+			continue
+		}
+		if max > 0 && n >= max {
+			break
+		}
+		kind := s.Info.Kind()
+		if runs[kind] == nil {
+			runs[kind] = &SpotRun{Kind: kind}
+		}
+		runs[kind].Spots = append(runs[kind].Spots, s)
+		n++
+	}
+
+	var list []*SpotRun
+	for _, r := range runs {
+		if r != nil {
+			list = append(list, r)
+		}
+	}
+	return list
+}
+
+// matchPackage reports whether pak has the package name pkg or
+// an import path ending in pkg.
+func matchPackage(pak *Pak, pkg string) bool {
+	if pak.Name == pkg {
+		return true
+	}
+	importPath := strings.TrimPrefix(pak.Path, "/src/")
+	return importPath == pkg || strings.HasSuffix(importPath, "/"+pkg)
+}
+
+// FileLines describes the lines of a file in which a textual
+// search result was found.
+type FileLines struct {
+	Filename string
+	Lines    []int
+}
+
+// LookupText returns the file lines containing the literal text s.
+// At most max occurrences are reported; complete reports whether
+// all occurrences were found.
+func (x *Index) LookupText(s string, max int) (found int, result []FileLines, complete bool) {
+	if x.fulltext == nil || s == "" || max <= 0 {
+		return
+	}
+
+	offsets := x.fulltext.Lookup([]byte(s), max+1)
+	complete = len(offsets) <= max
+	if !complete {
+		offsets = offsets[:max]
+	}
+	sort.Ints(offsets)
+
+	data := x.fulltext.Bytes()
+	var current *FileLines
+	lastLine := 0
+	for _, offs := range offsets {
+		// find file containing offs
+		i := sort.Search(len(x.files), func(i int) bool { return x.files[i].end > offs })
+		if i >= len(x.files) || offs < x.files[i].start || offs+len(s) > x.files[i].end {
+			continue // match spans file boundaries
+		}
+		f := x.files[i]
+		filename := f.file.Path()
+		line := bytes.Count(data[f.start:offs], []byte{'\n'}) + 1
+
+		if current == nil || current.Filename != filename {
+			result = append(result, FileLines{Filename: filename})
+			current = &result[len(result)-1]
+			lastLine = 0
+		}
+		if line != lastLine {
+			current.Lines = append(current.Lines, line)
+			lastLine = line
+		}
+		found++
+	}
+	return
+}
+
+// ----------------------------------------------------------------------------
+// Corpus
+
+// CurrentIndex returns the current search index and the time
+// it was built. The index is nil if it has not been built yet.
+func (c *Corpus) CurrentIndex() (*Index, time.Time) {
+	v, t := c.searchIndex.Get()
+	idx, _ := v.(*Index)
+	return idx, t
+}
+
+func (c *Corpus) updateIndex() {
+	if c.Verbose {
+		log.Printf("updating index...")
+	}
+	start := time.Now()
+	index := c.NewIndex()
+	stop := time.Now()
+	c.searchIndex.Set(index)
+	if c.Verbose {
+		secs := stop.Sub(start).Seconds()
+		stats := index.Stats()
+		log.Printf("index updated (%gs, %d bytes of source, %d files, %d lines, %d unique words, %d spots)",
+			secs, stats.Bytes, stats.Files, stats.Lines, stats.Words, stats.Spots)
+	}
+}
@@ -0,0 +1,106 @@
+package godoc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func newIndexTestCorpus(t *testing.T) *Corpus {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/foo/foo.go": `// Package foo is great.
+package foo
+
+// Bar is a type.
+type Bar struct{}
+
+// Frob frobs the bar.
+func (b *Bar) Frob() {}
+
+// NewBar returns a new Bar.
+func NewBar() *Bar { return &Bar{} }
+`,
+		"src/foo/bar/bar.go": `// Package bar uses foo.
+package bar
+
+import "foo"
+
+var B = foo.NewBar() // uses NewBar
+`,
+	}))
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	c.WaitIndex()
+	return c
+}
+
+func TestIndexLookupWord(t *testing.T) {
+	c := newIndexTestCorpus(t)
+	index, _ := c.CurrentIndex()
+	if index == nil {
+		t.Fatal("no index built")
+	}
+
+	type hit struct {
+		kind SpotKind
+		file string
+		line int
+	}
+	var got []hit
+	for _, run := range index.LookupWord("", "NewBar", 0) {
+		for _, s := range run.Spots {
+			got = append(got, hit{run.Kind, s.File.Path(), spotLine(s.Info, index.snippets)})
+		}
+	}
+	want := []hit{
+		{FuncDecl, "/src/foo/foo.go", 11},
+		{Use, "/src/foo/bar/bar.go", 6},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LookupWord(NewBar) = %v; want %v", got, want)
+	}
+
+	if runs := index.LookupWord("bar", "NewBar", 0); len(runs) != 1 || runs[0].Kind != Use {
+		t.Errorf("LookupWord(bar.NewBar) = %v; want one run of uses", runs)
+	}
+}
+
+func TestIndexLookupText(t *testing.T) {
+	c := newIndexTestCorpus(t)
+	index, _ := c.CurrentIndex()
+
+	found, result, complete := index.LookupText("NewBar", 10)
+	want := []FileLines{
+		{"/src/foo/foo.go", []int{10, 11}},
+		{"/src/foo/bar/bar.go", []int{6}},
+	}
+	if found != 4 || !complete || !reflect.DeepEqual(result, want) {
+		t.Errorf("LookupText(NewBar) = %d, %v, %v; want 4, %v, true", found, result, complete, want)
+	}
+
+	if found, _, complete := index.LookupText("NewBar", 2); found != 2 || complete {
+		t.Errorf("LookupText(NewBar, 2) = %d, %v; want 2, false", found, complete)
+	}
+}
+
+func TestSplitQualifiedIdent(t *testing.T) {
+	for _, tc := range []struct {
+		query     string
+		pkg, name string
+		ok        bool
+	}{
+		{"Println", "", "Println", true},
+		{"fmt.Println", "fmt", "Println", true},
+		{"net/http.Get", "net/http", "Get", true},
+		{"func", "", "func", false},
+		{"a b", "", "a b", false},
+		{".x", "", "", false},
+	} {
+		pkg, name, ok := splitQualifiedIdent(tc.query)
+		if pkg != tc.pkg || name != tc.name || ok != tc.ok {
+			t.Errorf("splitQualifiedIdent(%q) = %q, %q, %v; want %q, %q, %v", tc.query, pkg, name, ok, tc.pkg, tc.name, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+// This file contains the code to write the state of a corpus
+// (the directory tree and the search index) to a file and to
+// read it back when the corpus is initialized.
+//
+// Format of an index file:
+// - the magic string indexFileMagic
+// - the gob-encoded file format version (indexFileVersion)
+// - the gob-encoded corpusData: directory tree and directory stamps
+// - if corpusData.Index is set, the gob-encoded indexData
+// - if indexData.FullText is set, the suffix array of the full text index
+//
+// Files (and packages) are written once, in a table; spots and
+// references refer to them by their index in that table.
+//
+// The directory stamps are used to detect a stale file: when the
+// corpus is initialized from an index file, the directories whose
+// stamps changed since the file was written are rescanned (see sync.go)
+// and the search index is rebuilt.
+
+package godoc
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"index/suffixarray"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const indexFileMagic = "godoc index file\n"
+
+// indexFileVersion is the version of the index file format.
+// It must be incremented whenever the format or any of the
+// types written to an index file change.
+const indexFileVersion = 4
+
+var errIndexFileFormat = errors.New("not a godoc index file")
+
+type corpusData struct {
+	Tree   *Directory
+	Stamps map[string]dirStamp
+	Index  bool // if set, indexData follows
+}
+
+type indexData struct {
+	Files      []fileData
+	Words      map[string][]spotData
+	Snippets   []*Snippet
+	Sources    []indexedFileData
+	Refs       map[string]map[string][]refData // by import path and identifier
+	Deprecated []Deprecation
+	Stats      Statistics
+	FullText   bool // if set, the suffix array of the full text index follows
+}
+
+type fileData struct {
+	Name string
+	Pak  Pak
+}
+
+type spotData struct {
+	File int // index into indexData.Files
+	Info SpotInfo
+}
+
+type refData struct {
+	File         int // index into indexData.Files
+	Line, Offset int
+}
+
+type indexedFileData struct {
+	File       int // index into indexData.Files
+	Start, End int
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// WriteIndexTo writes the directory tree of the corpus and its
+// search index, if any, to w. It must be called after Init.
+func (c *Corpus) WriteIndexTo(w io.Writer) (n int64, err error) {
+	v, _ := c.fsTree.Get()
+	tree, _ := v.(*Directory)
+	if tree == nil {
+		return 0, errors.New("godoc: corpus has no directory tree")
+	}
+	c.syncMu.Lock()
+	stamps := c.fsStamps
+	c.syncMu.Unlock()
+	index, _ := c.CurrentIndex()
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	if _, err := bw.WriteString(indexFileMagic); err != nil {
+		return cw.n, err
+	}
+	enc := gob.NewEncoder(bw)
+	if err := enc.Encode(indexFileVersion); err != nil {
+		return cw.n, err
+	}
+	if err := enc.Encode(corpusData{tree, stamps, index != nil}); err != nil {
+		return cw.n, err
+	}
+	if index != nil {
+		if err := enc.Encode(index.data()); err != nil {
+			return cw.n, err
+		}
+		if index.fulltext != nil {
+			if err := index.fulltext.Write(bw); err != nil {
+				return cw.n, err
+			}
+		}
+	}
+	err = bw.Flush()
+	return cw.n, err
+}
+
+// ReadIndexFrom reads a directory tree and search index written by
+// WriteIndexTo from r and installs them in the corpus. It doesn't
+// check whether they are still up to date.
+func (c *Corpus) ReadIndexFrom(r io.Reader) error {
+	// gob.Decoder and suffixarray.Index.Read share the reader,
+	// so it must be an io.ByteReader: otherwise the decoder
+	// would buffer (and lose) data beyond its own messages
+	if _, ok := r.(io.ByteReader); !ok {
+		r = bufio.NewReader(r)
+	}
+
+	magic := make([]byte, len(indexFileMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != indexFileMagic {
+		return errIndexFileFormat
+	}
+	dec := gob.NewDecoder(r)
+	var version int
+	if err := dec.Decode(&version); err != nil {
+		return err
+	}
+	if version != indexFileVersion {
+		return fmt.Errorf("index file version %d; want %d", version, indexFileVersion)
+	}
+
+	var cd corpusData
+	if err := dec.Decode(&cd); err != nil {
+		return err
+	}
+	if cd.Tree == nil {
+		return errors.New("index file contains no directory tree")
+	}
+	var index *Index
+	var err error
+	if cd.Index {
+		var id indexData
+		if err := dec.Decode(&id); err != nil {
+			return err
+		}
+		if index, err = id.index(); err != nil {
+			return err
+		}
+		if id.FullText {
+			index.fulltext = new(suffixarray.Index)
+			if err := index.fulltext.Read(r); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.syncMu.Lock()
+	c.fsStamps = cd.Stamps
+	c.syncMu.Unlock()
+	c.setFSTree(cd.Tree)
+	if index != nil {
+		c.searchIndex.Set(index)
+	}
+	return nil
+}
+
+// readIndexFiles initializes the corpus from the files matching
+// c.IndexFiles, read in sorted order as one stream. It reports
+// whether the corpus has an up-to-date search index afterwards.
+// Directories that changed since the files were written are
+// rescanned; in that case the search index is discarded.
+func (c *Corpus) readIndexFiles() (indexed bool, err error) {
+	files, err := filepath.Glob(c.IndexFiles)
+	if err != nil {
+		return false, err
+	}
+	if len(files) == 0 {
+		return false, fmt.Errorf("no index files match %q", c.IndexFiles)
+	}
+	// filepath.Glob returns the files in sorted order
+	var readers []io.Reader
+	for _, filename := range files {
+		f, err := os.Open(filename)
+		if err != nil {
+			return false, err
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+	if err := c.ReadIndexFrom(io.MultiReader(readers...)); err != nil {
+		return false, err
+	}
+
+	if c.sync() {
+		if c.Verbose {
+			log.Printf("index files %s are stale; rescanned changed directories", c.IndexFiles)
+		}
+		c.searchIndex.Set(nil)
+		return false, nil
+	}
+	index, _ := c.CurrentIndex()
+	return index != nil && (index.fulltext != nil || !c.IndexFullText), nil
+}
+
+// data returns the serializable form of x.
+func (x *Index) data() *indexData {
+	d := &indexData{
+		Words:      make(map[string][]spotData, len(x.words)),
+		Refs:       make(map[string]map[string][]refData, len(x.refs)),
+		Snippets:   x.snippets,
+		Deprecated: x.deprecated,
+		Stats:      x.stats,
+		FullText:   x.fulltext != nil,
+	}
+	files := make(map[*File]int)
+	fileIndex := func(f *File) int {
+		i, ok := files[f]
+		if !ok {
+			i = len(d.Files)
+			files[f] = i
+			d.Files = append(d.Files, fileData{f.Name, *f.Pak})
+		}
+		return i
+	}
+	for word, spots := range x.words {
+		list := make([]spotData, len(spots))
+		for i, s := range spots {
+			list[i] = spotData{fileIndex(s.File), s.Info}
+		}
+		d.Words[word] = list
+	}
+	for _, f := range x.files {
+		d.Sources = append(d.Sources, indexedFileData{fileIndex(f.file), f.start, f.end})
+	}
+	for path, m := range x.refs {
+		dm := make(map[string][]refData, len(m))
+		for name, refs := range m {
+			list := make([]refData, len(refs))
+			for i, r := range refs {
+				list[i] = refData{fileIndex(r.File), r.Line, r.Offset}
+			}
+			dm[name] = list
+		}
+		d.Refs[path] = dm
+	}
+	return d
+}
+
+// index returns the Index described by d, without its full text index.
+func (d *indexData) index() (*Index, error) {
+	paks := make(map[Pak]*Pak)
+	files := make([]*File, len(d.Files))
+	for i, f := range d.Files {
+		pak := paks[f.Pak]
+		if pak == nil {
+			pak = &Pak{f.Pak.Path, f.Pak.Name}
+			paks[f.Pak] = pak
+		}
+		files[i] = &File{f.Name, pak}
+	}
+
+	x := &Index{
+		words:      make(map[string][]Spot, len(d.Words)),
+		refs:       make(map[string]map[string][]Ref, len(d.Refs)),
+		snippets:   d.Snippets,
+		deprecated: d.Deprecated,
+		stats:      d.Stats,
+	}
+	for word, list := range d.Words {
+		spots := make([]Spot, len(list))
+		for i, s := range list {
+			if s.File < 0 || s.File >= len(files) {
+				return nil, errIndexFileFormat
+			}
+			spots[i] = Spot{files[s.File], s.Info}
+		}
+		x.words[word] = spots
+	}
+	for _, f := range d.Sources {
+		if f.File < 0 || f.File >= len(files) {
+			return nil, errIndexFileFormat
+		}
+		x.files = append(x.files, indexedFile{files[f.File], f.Start, f.End})
+	}
+	for path, dm := range d.Refs {
+		m := make(map[string][]Ref, len(dm))
+		for name, list := range dm {
+			var refs []Ref // nil for identifiers without references, as written
+			for _, r := range list {
+				if r.File < 0 || r.File >= len(files) {
+					return nil, errIndexFileFormat
+				}
+				refs = append(refs, Ref{files[r.File], r.Line, r.Offset})
+			}
+			m[name] = refs
+		}
+		x.refs[path] = m
+	}
+	return x, nil
+}
@@ -0,0 +1,99 @@
+package godoc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestIndexFileRoundTrip(t *testing.T) {
+	c := newIndexTestCorpus(t)
+
+	var buf bytes.Buffer
+	n, err := c.WriteIndexTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteIndexTo returned %d; wrote %d bytes", n, buf.Len())
+	}
+
+	c2 := NewCorpus(mapfs.New(nil))
+	if err := c2.ReadIndexFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	tree1, _ := c.fsTree.Get()
+	tree2, _ := c2.fsTree.Get()
+	if !reflect.DeepEqual(tree1, tree2) {
+		t.Errorf("read tree %+v; want %+v", tree2, tree1)
+	}
+
+	index1, _ := c.CurrentIndex()
+	index2, _ := c2.CurrentIndex()
+	if index2 == nil {
+		t.Fatal("no index read")
+	}
+	if got, want := index2.LookupWord("", "NewBar", 0), index1.LookupWord("", "NewBar", 0); !reflect.DeepEqual(got, want) {
+		t.Errorf("LookupWord(NewBar) = %v; want %v", got, want)
+	}
+	_, got, _ := index2.LookupText("NewBar", 10)
+	_, want, _ := index1.LookupText("NewBar", 10)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LookupText(NewBar) = %v; want %v", got, want)
+	}
+}
+
+func TestIndexFileFormat(t *testing.T) {
+	c := NewCorpus(mapfs.New(nil))
+	if err := c.ReadIndexFrom(bytes.NewReader([]byte("not an index"))); err != errIndexFileFormat {
+		t.Errorf("ReadIndexFrom(garbage) = %v; want %v", err, errIndexFileFormat)
+	}
+}
+
+func TestInitFromStaleIndexFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "godoc-index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "index")
+
+	files := map[string]string{
+		"src/foo/foo.go": "// Package foo is great.\npackage foo\n\nfunc Foo() {}\n",
+	}
+	c := NewCorpus(mapfs.New(files))
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	c.WaitIndex()
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.WriteIndexTo(f); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	files["src/bar/bar.go"] = "// Package bar is new.\npackage bar\n\nfunc Bar() {}\n"
+	c = NewCorpus(mapfs.New(files))
+	c.IndexFiles = filename
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	c.WaitIndex()
+	tree, _ := c.fsTree.Get()
+	if d := tree.(*Directory).lookup("/src/bar"); d == nil || !d.HasPkg {
+		t.Errorf("added package missing from tree loaded from stale index file")
+	}
+	index, _ := c.CurrentIndex()
+	if index == nil || len(index.LookupWord("", "Bar", 0)) == 0 {
+		t.Errorf("index not rebuilt for stale index file")
+	}
+}
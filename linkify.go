@@ -2,7 +2,10 @@
 // links for identifiers pointing to their declarations.
 // The approach does not cover all cases because godoc
 // doesn't have complete type information, but it's
-// reasonably good for browsing.
+// reasonably good for browsing. If the package was
+// type-checked, LinkifyTypedText uses the type
+// information to link identifiers to their actual
+// declarations, including methods and fields.
 
 package godoc
 
@@ -11,6 +14,8 @@ import (
 	"go/ast"
 	"go/doc"
 	"go/token"
+	"go/types"
+	"html"
 	"io"
 	"strconv"
 )
@@ -22,7 +27,16 @@ import (
 // formatted the same way as with FormatText.
 //
 func LinkifyText(w io.Writer, text []byte, n ast.Node) {
-	links := linksFor(n)
+	LinkifyTypedText(w, text, n, nil, nil, "")
+}
+
+// LinkifyTypedText is like LinkifyText but uses the type information
+// info of package pkg, if not nil, to resolve the identifiers of n.
+// Identifiers without type information are resolved as by LinkifyText.
+// The links to other packages carry the URL query query, if not empty.
+//
+func LinkifyTypedText(w io.Writer, text []byte, n ast.Node, pkg *types.Package, info *types.Info, query string) {
+	links := linksFor(n, pkg, info)
 
 	i := 0     // links index
 	prev := "" // prev HTML tag
@@ -42,11 +56,11 @@ func LinkifyText(w io.Writer, text []byte, n ast.Node) {
 			switch info := links[i]; {
 			case info.path != "" && info.name == "":
 				// package path
-				fmt.Fprintf(w, `<a href="/pkg/%s/">`, info.path)
+				fmt.Fprintf(w, `<a href="/pkg/%s/%s">`, info.path, html.EscapeString(query))
 				prev = "a"
 			case info.path != "" && info.name != "":
 				// qualified identifier
-				fmt.Fprintf(w, `<a href="/pkg/%s/#%s">`, info.path, info.name)
+				fmt.Fprintf(w, `<a href="/pkg/%s/%s#%s">`, info.path, html.EscapeString(query), info.name)
 				prev = "a"
 			case info.path == "" && info.name != "":
 				// local identifier
@@ -76,9 +90,11 @@ type link struct {
 }
 
 // linksFor returns the list of links for the identifiers used
-// by node in the same order as they appear in the source.
+// by node in the same order as they appear in the source. If
+// info is not nil, it is the type information of package pkg
+// for node.
 //
-func linksFor(node ast.Node) (links []link) {
+func linksFor(node ast.Node, pkg *types.Package, info *types.Info) (links []link) {
 	// linkMap tracks link information for each ast.Ident node. Entries may
 	// be created out of source order (for example, when we visit a parent
 	// definition node). These links are appended to the returned slice when
@@ -120,6 +136,17 @@ func linksFor(node ast.Node) (links []link) {
 				}
 			}
 		case *ast.SelectorExpr:
+			if info != nil {
+				// Methods and fields, if the selector was type-checked.
+				if sel := info.Selections[n]; sel != nil {
+					linkMap[n.Sel] = selectionLink(pkg, sel)
+					return true
+				}
+				// Qualified identifiers are linked by the *ast.Ident case.
+				if x, _ := n.X.(*ast.Ident); x != nil && info.Uses[x] != nil {
+					return true
+				}
+			}
 			// Detect qualified identifiers of the form pkg.ident.
 			// If anything fails we return true and collect individual
 			// identifiers instead.
@@ -142,6 +169,25 @@ func linksFor(node ast.Node) (links []link) {
 			// be prefixed by the type name.
 			fieldPath := ""
 			prefix := ""
+			if info != nil {
+				if tv, ok := info.Types[n]; ok {
+					// The literal was type-checked: link the keys of
+					// struct literals to the fields of the named type;
+					// all other identifiers are linked by the *ast.Ident case.
+					if named := namedType(tv.Type); named != nil {
+						for _, e := range n.Elts {
+							if kv, ok := e.(*ast.KeyValueExpr); ok {
+								if k, ok := kv.Key.(*ast.Ident); ok {
+									if v, _ := info.Uses[k].(*types.Var); v != nil && v.IsField() {
+										linkMap[k] = link{path: pkgPath(pkg, v), name: named.Obj().Name() + "." + k.Name}
+									}
+								}
+							}
+						}
+					}
+					return true
+				}
+			}
 			switch typ := n.Type.(type) {
 			case *ast.Ident:
 				prefix = typ.Name + "."
@@ -177,6 +223,8 @@ func linksFor(node ast.Node) (links []link) {
 		case *ast.Ident:
 			if l, ok := linkMap[n]; ok {
 				links = append(links, l)
+			} else if info != nil && info.Uses[n] != nil {
+				links = append(links, objectLink(pkg, info.Uses[n]))
 			} else {
 				l := link{name: n.Name}
 				if n.Obj == nil && doc.IsPredeclared(n.Name) {
@@ -189,3 +237,81 @@ func linksFor(node ast.Node) (links []link) {
 	})
 	return
 }
+
+// objectLink returns the link for a use of obj in package pkg.
+func objectLink(pkg *types.Package, obj types.Object) link {
+	switch obj := obj.(type) {
+	case *types.PkgName:
+		return link{path: obj.Imported().Path()}
+	case *types.Builtin, *types.Nil:
+		return link{path: builtinPkgPath, name: obj.Name()}
+	}
+	if obj.Pkg() == nil {
+		// predeclared types and constants, and error.Error
+		if obj.Parent() == types.Universe {
+			return link{path: builtinPkgPath, name: obj.Name()}
+		}
+		return link{}
+	}
+	if obj.Parent() != obj.Pkg().Scope() {
+		// local object, or method or field
+		return link{}
+	}
+	return link{path: pkgPath(pkg, obj), name: obj.Name()}
+}
+
+// selectionLink returns the link for the method or field selected by sel
+// in package pkg. Methods and fields are linked as RecvType.Name, which
+// is how package documentation identifies them.
+func selectionLink(pkg *types.Package, sel *types.Selection) link {
+	obj := sel.Obj()
+	var recv *types.Named
+	switch obj := obj.(type) {
+	case *types.Func:
+		if sig, _ := obj.Type().(*types.Signature); sig != nil && sig.Recv() != nil {
+			recv = namedType(sig.Recv().Type())
+		}
+	case *types.Var:
+		// find the struct containing the field, following
+		// the embedded fields of the selection path
+		typ := sel.Recv()
+		index := sel.Index()
+		for _, i := range index[:len(index)-1] {
+			st, _ := deref(typ).Underlying().(*types.Struct)
+			if st == nil {
+				return link{}
+			}
+			typ = st.Field(i).Type()
+		}
+		recv = namedType(typ)
+	}
+	if recv == nil || recv.Obj().Pkg() == nil || recv.Obj().Parent() != recv.Obj().Pkg().Scope() {
+		return link{}
+	}
+	return link{path: pkgPath(pkg, recv.Obj()), name: recv.Obj().Name() + "." + obj.Name()}
+}
+
+// pkgPath returns the link path of the package declaring obj,
+// used from package pkg: "" if it is pkg itself.
+func pkgPath(pkg *types.Package, obj types.Object) string {
+	if obj.Pkg() == nil || obj.Pkg() == pkg {
+		return ""
+	}
+	return obj.Pkg().Path()
+}
+
+// namedType returns the named type of typ or *typ, or nil.
+func namedType(typ types.Type) *types.Named {
+	if typ == nil {
+		return nil
+	}
+	named, _ := deref(typ).(*types.Named)
+	return named
+}
+
+func deref(typ types.Type) types.Type {
+	if p, _ := typ.(*types.Pointer); p != nil {
+		return p.Elem()
+	}
+	return typ
+}
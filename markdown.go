@@ -0,0 +1,413 @@
+// This file contains the Markdown rendering of package documentation,
+// for the Markdown PageInfoMode (?m=md) of package pages.
+//
+// The pages are rendered by the PackageMarkdown template, whose
+// comment_md function converts doc comments to Markdown: paragraphs,
+// headings, indented code blocks and lists, URLs and the [Text] links
+// of link definitions. Doc links such as [io.Reader] become code spans,
+// as they have no target outside of godoc.
+
+package godoc
+
+import (
+	"bytes"
+	"go/printer"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ServeMarkdown writes the Markdown document text.
+func (p *Presentation) ServeMarkdown(w http.ResponseWriter, text []byte) {
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Write(text)
+}
+
+// tidyMarkdown removes the leading and trailing blank lines and the
+// repeated blank lines of the Markdown document text, except in code
+// blocks. This keeps the templates producing Markdown readable.
+func tidyMarkdown(text []byte) []byte {
+	var buf bytes.Buffer
+	fence := "" // of the current code block
+	blank := false
+	for _, line := range strings.Split(string(text), "\n") {
+		if fence == "" {
+			line = strings.TrimRight(line, " \t")
+			if line == "" {
+				blank = buf.Len() > 0
+				continue
+			}
+			if strings.HasPrefix(line, "```") {
+				fence = line[:len(line)-len(strings.TrimLeft(line, "`"))]
+			}
+		} else if strings.TrimSpace(line) == fence {
+			fence = ""
+		}
+		if blank {
+			buf.WriteString("\n")
+			blank = false
+		}
+		buf.WriteString(line + "\n")
+	}
+	return buf.Bytes()
+}
+
+// comment_mdFunc converts the doc comment to Markdown. The headings of
+// the comment are written at the given heading level.
+func comment_mdFunc(comment string, level int) string {
+	var buf bytes.Buffer
+	commentToMarkdown(&buf, comment, level)
+	return buf.String()
+}
+
+// text_mdFunc escapes the text for Markdown.
+func text_mdFunc(s string) string {
+	return escapeMarkdown(s)
+}
+
+// code_mdFunc returns the Markdown fenced code block of the code,
+// for the language lang (which may be empty).
+func code_mdFunc(lang, code string) string {
+	code = strings.Trim(code, "\n")
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + lang + "\n" + code + "\n" + fence
+}
+
+// anchor_mdFunc returns the fragment of the Markdown heading with the
+// given (unescaped) text, as generated by common Markdown renderers:
+// the lower-case text, without punctuation and with dashes for spaces.
+func anchor_mdFunc(heading string) string {
+	var buf strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			buf.WriteByte('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// example_mdFunc returns the Markdown of the examples of funcName.
+func (p *Presentation) example_mdFunc(info *PageInfo, funcName string) string {
+	var buf bytes.Buffer
+	for _, eg := range info.Examples {
+		if stripExampleSuffix(eg.Name) != funcName {
+			continue
+		}
+
+		cnode := &printer.CommentedNode{Node: eg.Code, Comments: eg.Comments}
+		code := p.nodeFunc(info, cnode)
+		out := eg.Output
+		if body, ok := p.exampleBody(code); ok {
+			code = body
+		} else {
+			// The output comment appears in the code.
+			out = ""
+		}
+
+		buf.WriteString("Example" + escapeMarkdown(p.example_suffixFunc(eg.Name)) + ":\n\n")
+		if eg.Doc != "" {
+			commentToMarkdown(&buf, eg.Doc, 5)
+			buf.WriteString("\n")
+		}
+		buf.WriteString(code_mdFunc("go", code) + "\n\n")
+		if out != "" {
+			buf.WriteString("Output:\n\n" + code_mdFunc("", out) + "\n\n")
+		}
+	}
+	return buf.String()
+}
+
+// ----------------------------------------------------------------------------
+// Doc comments
+
+// A mdBlock is a block of a doc comment.
+type mdBlock struct {
+	kind  int      // mdPara, mdHeading, mdCode or mdList
+	lines []string // unindented lines; list items for mdList
+}
+
+const (
+	mdPara = iota
+	mdHeading
+	mdCode
+	mdList
+)
+
+var (
+	// mdLinkDefRx matches the link definitions of doc comments.
+	mdLinkDefRx = regexp.MustCompile(`^\[([^\]]+)\]:\s+(\S+)$`)
+
+	// mdListRx matches the markers of list items.
+	mdListRx = regexp.MustCompile(`^([-*+•]|[0-9]+[.)])\s+`)
+
+	// mdInlineRx matches the URLs and bracketed text of doc comments.
+	mdInlineRx = regexp.MustCompile(`(?:https?|ftp|file|mailto)://[^\s<>"'()\[\]]*[^\s<>"'()\[\].,:;!?]|\[[^\[\]]+\]`)
+
+	// mdDocLinkRx matches the doc links of doc comments, such as
+	// [Name], [pkg.Name], [pkg.Name.Method] or [*pkg.Name].
+	mdDocLinkRx = regexp.MustCompile(`^\*?([A-Za-z_][A-Za-z0-9_./]*\.)?[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// commentToMarkdown writes the doc comment text to buf as Markdown,
+// with its headings at the given heading level.
+func commentToMarkdown(buf *bytes.Buffer, text string, level int) {
+	blocks, links := mdBlocks(text)
+	for i, b := range blocks {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		switch b.kind {
+		case mdPara:
+			for _, line := range b.lines {
+				buf.WriteString(mdInline(line, links, true) + "\n")
+			}
+		case mdHeading:
+			buf.WriteString(strings.Repeat("#", level) + " " + mdInline(b.lines[0], links, false) + "\n")
+		case mdCode:
+			buf.WriteString(code_mdFunc("", strings.Join(b.lines, "\n")) + "\n")
+		case mdList:
+			for _, item := range b.lines {
+				m := mdListRx.FindString(item)
+				marker := strings.TrimSpace(m)
+				if marker[0] < '0' || '9' < marker[0] {
+					marker = "-"
+				}
+				buf.WriteString(marker + " " + mdInline(item[len(m):], links, false) + "\n")
+			}
+		}
+	}
+}
+
+// mdBlocks splits the doc comment text into blocks, and returns them with
+// the URLs of its link definitions by link text.
+func mdBlocks(text string) ([]mdBlock, map[string]string) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	var blocks []mdBlock
+	links := make(map[string]string)
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+
+		case isIndented(line):
+			// Indented lines, possibly separated by blank lines,
+			// form a code block or a list.
+			j := i
+			for j < len(lines) && (isIndented(lines[j]) || isBlank(lines[j])) {
+				j++
+			}
+			for isBlank(lines[j-1]) {
+				j--
+			}
+			block := unindentLines(lines[i:j])
+			if mdListRx.MatchString(block[0]) {
+				blocks = append(blocks, mdBlock{mdList, listItems(block)})
+			} else {
+				blocks = append(blocks, mdBlock{mdCode, block})
+			}
+			i = j
+
+		default:
+			j := i
+			for j < len(lines) && !isBlank(lines[j]) && !isIndented(lines[j]) {
+				j++
+			}
+			para := lines[i:j]
+			switch {
+			case isLinkDefs(para):
+				for _, def := range para {
+					m := mdLinkDefRx.FindStringSubmatch(strings.TrimSpace(def))
+					links[m[1]] = m[2]
+				}
+			case len(para) == 1 && strings.HasPrefix(line, "# ") && strings.TrimSpace(line[2:]) != "":
+				blocks = append(blocks, mdBlock{mdHeading, []string{strings.TrimSpace(line[2:])}})
+			case len(para) == 1 && len(blocks) > 0 && blocks[len(blocks)-1].kind == mdPara &&
+				j+1 < len(lines) && isBlank(lines[j]) && !isBlank(lines[j+1]) && !isIndented(lines[j+1]) &&
+				isHeading(line):
+				// an old-style heading between paragraphs
+				blocks = append(blocks, mdBlock{mdHeading, []string{strings.TrimSpace(line)}})
+			default:
+				blocks = append(blocks, mdBlock{mdPara, para})
+			}
+			i = j
+		}
+	}
+	return blocks, links
+}
+
+func isBlank(line string) bool { return strings.TrimSpace(line) == "" }
+
+func isIndented(line string) bool {
+	return line != "" && (line[0] == ' ' || line[0] == '\t') && !isBlank(line)
+}
+
+// isLinkDefs reports whether the lines of a paragraph are all link definitions.
+func isLinkDefs(lines []string) bool {
+	for _, line := range lines {
+		if !mdLinkDefRx.MatchString(strings.TrimSpace(line)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isHeading reports whether the line is an old-style heading of a doc
+// comment, following the rules of go/doc: it starts with an upper-case
+// letter, ends with a letter or digit, and has no other punctuation
+// than parentheses, commas, inner periods and possessive apostrophes.
+func isHeading(line string) bool {
+	line = strings.TrimSpace(line)
+	r, _ := utf8.DecodeRuneInString(line)
+	if !unicode.IsLetter(r) || !unicode.IsUpper(r) {
+		return false
+	}
+	r, _ = utf8.DecodeLastRuneInString(line)
+	if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+		return false
+	}
+	if strings.ContainsAny(line, ";:!?+*/=[]{}_^°&§~%#@<\">\\") {
+		return false
+	}
+	for s := line; ; {
+		i := strings.IndexRune(s, '\'')
+		if i < 0 {
+			break
+		}
+		if i+1 >= len(s) || s[i+1] != 's' || (i+2 < len(s) && s[i+2] != ' ') {
+			return false
+		}
+		s = s[i+2:]
+	}
+	for s := line; ; {
+		i := strings.IndexRune(s, '.')
+		if i < 0 {
+			break
+		}
+		if i+1 >= len(s) || s[i+1] == ' ' {
+			return false
+		}
+		s = s[i+1:]
+	}
+	return true
+}
+
+// unindentLines removes the common leading white space of the lines.
+func unindentLines(lines []string) []string {
+	prefix := ""
+	for i, line := range lines {
+		if isBlank(line) {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if i == 0 {
+			prefix = indent
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = strings.TrimPrefix(strings.TrimRight(line, " \t"), prefix)
+	}
+	return out
+}
+
+// listItems returns the items of the unindented lines of a list, with
+// their continuation lines joined.
+func listItems(lines []string) []string {
+	var items []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case mdListRx.MatchString(line) || len(items) == 0:
+			items = append(items, line)
+		default:
+			items[len(items)-1] += " " + line
+		}
+	}
+	return items
+}
+
+// mdInline returns the Markdown of a line of text of a doc comment, with
+// its URLs and links converted and its other text escaped. If lineStart
+// is set, the line starts a Markdown line and its markers are escaped.
+func mdInline(line string, links map[string]string, lineStart bool) string {
+	var buf strings.Builder
+	text := func(s string) {
+		// ``quoted'' text, as in go/doc
+		s = strings.Replace(s, "``", "“", -1)
+		s = strings.Replace(s, "''", "”", -1)
+		buf.WriteString(escapeMarkdown(s))
+	}
+	last := 0
+	for _, m := range mdInlineRx.FindAllStringIndex(line, -1) {
+		text(line[last:m[0]])
+		last = m[1]
+		s := line[m[0]:m[1]]
+		if s[0] != '[' {
+			buf.WriteString("<" + s + ">")
+			continue
+		}
+		name := s[1 : len(s)-1]
+		switch url, ok := links[name]; {
+		case ok:
+			buf.WriteString("[" + escapeMarkdown(name) + "](" + url + ")")
+		case mdDocLinkRx.MatchString(name):
+			buf.WriteString("`" + name + "`")
+		default:
+			text(s)
+		}
+	}
+	text(line[last:])
+	s := buf.String()
+	if lineStart {
+		s = escapeLineStart(s)
+	}
+	return s
+}
+
+// mdEscaper escapes the characters of Markdown inline syntax.
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+)
+
+func escapeMarkdown(s string) string {
+	return mdEscaper.Replace(s)
+}
+
+// mdOrderedRx matches the markers of ordered list items.
+var mdOrderedRx = regexp.MustCompile(`^[0-9]+[.)]`)
+
+// escapeLineStart escapes the block syntax at the start of a Markdown
+// line: headings, block quotes, list items, thematic breaks and tables.
+func escapeLineStart(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '#', '>', '-', '+', '=', '|', '~':
+		return `\` + s
+	}
+	if m := mdOrderedRx.FindString(s); m != "" {
+		return m[:len(m)-1] + `\` + s[len(m)-1:]
+	}
+	return s
+}
@@ -0,0 +1,125 @@
+package godoc
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCommentToMarkdown(t *testing.T) {
+	for _, tc := range []struct {
+		name, comment, want string
+	}{
+		{
+			name:    "paragraphs",
+			comment: "Package p does *this* and_that.\n# not a heading\n\n``Quoted'' text, see https://example.com/a_b.\n",
+			want:    "Package p does \\*this\\* and\\_that.\n\\# not a heading\n\n“Quoted” text, see <https://example.com/a_b>.\n",
+		},
+		{
+			name:    "headings",
+			comment: "Intro.\n\nOld Heading\n\nText.\n\n# New heading\n\nMore text.\n\nNot a heading.\n\nEnd.\n",
+			want:    "Intro.\n\n### Old Heading\n\nText.\n\n### New heading\n\nMore text.\n\nNot a heading.\n\nEnd.\n",
+		},
+		{
+			name:    "code",
+			comment: "Example:\n\n\tx := f()\n\n\tif x {\n\t\tg()\n\t}\n\nDone.\n",
+			want:    "Example:\n\n```\nx := f()\n\nif x {\n\tg()\n}\n```\n\nDone.\n",
+		},
+		{
+			name:    "list",
+			comment: "Options:\n  - first\n  - second,\n    continued\n\nSteps:\n  1. one\n  2. two\n",
+			want:    "Options:\n\n- first\n- second, continued\n\nSteps:\n\n1. one\n2. two\n",
+		},
+		{
+			name:    "links",
+			comment: "See [Reader], [io.Writer], the [Go spec] and [not a link].\n\n[Go spec]: https://go.dev/ref/spec\n",
+			want:    "See `Reader`, `io.Writer`, the [Go spec](https://go.dev/ref/spec) and \\[not a link\\].\n",
+		},
+	} {
+		var buf bytes.Buffer
+		commentToMarkdown(&buf, tc.comment, 3)
+		if got := buf.String(); got != tc.want {
+			t.Errorf("%s:\ngot:\n%s\nwant:\n%s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestTidyMarkdown(t *testing.T) {
+	text := "\n\n# Title  \n\n\n\nText.\n\n```go\nfunc f() {\n\n\n}\n```\n\n\n- item\n\n"
+	want := "# Title\n\nText.\n\n```go\nfunc f() {\n\n\n}\n```\n\n- item\n"
+	if got := string(tidyMarkdown([]byte(text))); got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestAnchorMarkdown(t *testing.T) {
+	for heading, want := range map[string]string{
+		"func Hi":            "func-hi",
+		"func (*T) Greet":    "func-t-greet",
+		"type Reader_v2":     "type-reader_v2",
+		"Constants":          "constants",
+		"func (p Pair[K]) M": "func-p-pairk-m",
+	} {
+		if got := anchor_mdFunc(heading); got != want {
+			t.Errorf("anchor_md(%q) = %q; want %q", heading, got, want)
+		}
+	}
+}
+
+func TestServeMarkdown(t *testing.T) {
+	p := newTestPresentation(t, map[string]string{
+		"src/p/p.go": `// Package p greets.
+package p
+
+// T is a greeter.
+type T struct{}
+
+// Greet greets.
+func (*T) Greet() string { return "hi" }
+
+// BUG(x): Greet only says hi.
+`,
+		"src/p/p_test.go": `package p_test
+
+import (
+	"fmt"
+
+	"p"
+)
+
+func ExampleT_Greet() {
+	fmt.Println(new(p.T).Greet())
+	// Output: hi
+}
+`,
+	})
+	p.NotesRx = regexp.MustCompile("BUG")
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("GET", "/pkg/p/?m=md", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; want %d", rec.Code, http.StatusOK)
+	}
+	if got, want := rec.Header().Get("Content-Type"), "text/markdown; charset=utf-8"; got != want {
+		t.Errorf("Content-Type = %q; want %q", got, want)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# Package p\n",
+		"- [`type T`](#type-t)\n",
+		"  - [`func (*T) Greet() string`](#func-t-greet)\n",
+		"- [Bugs](#bugs)\n",
+		"### func (\\*T) Greet\n",
+		"```go\ntype T struct{}\n```\n",
+		"```go\nfunc (*T) Greet() string\n```\n",
+		"fmt.Println(new(p.T).Greet())",
+		"Greet only says hi.\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+}
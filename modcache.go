@@ -0,0 +1,80 @@
+// This file contains the support for browsing the module cache: the
+// /mod/ page lists the modules of Corpus.ModuleCache with their
+// versions, extracted or only downloaded, and the packages of each
+// version are served at /mod/<module>@<version>/<package>.
+
+package godoc
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/miclle/godoc/vfs/modfs"
+)
+
+// ModuleCacheResult is the data of the /mod/ page listing the modules
+// of the module cache.
+type ModuleCacheResult struct {
+	Alert   string         // error or informational message
+	Dir     string         // module cache directory
+	Modules []modfs.Module // modules of the module cache, sorted by path
+}
+
+// LookupModules returns the modules of c.ModuleCache. As walking the
+// module cache is expensive, they are read again after a sync interval
+// only.
+func (c *Corpus) LookupModules() ModuleCacheResult {
+	if c.ModuleCache == "" {
+		return ModuleCacheResult{Alert: "Module cache browsing is not enabled."}
+	}
+	mc := &c.moduleCache
+	mc.mu.Lock()
+	mc.expire(c.syncInterval())
+	cached := mc.modules
+	mc.mu.Unlock()
+	if cached != nil {
+		return *cached
+	}
+
+	result := c.readModules()
+	mc.mu.Lock()
+	mc.modules = &result
+	mc.mu.Unlock()
+	return result
+}
+
+// readModules returns the modules of c.ModuleCache read from the
+// module cache directory.
+func (c *Corpus) readModules() ModuleCacheResult {
+	result := ModuleCacheResult{Dir: c.ModuleCache}
+	mods, err := modfs.Modules(c.ModuleCache)
+	if err != nil {
+		result.Alert = "Error reading the module cache: " + err.Error()
+	}
+	result.Modules = mods
+	if err == nil && len(mods) == 0 {
+		result.Alert = "The module cache contains no modules."
+	}
+	return result
+}
+
+// HandleModules serves the index of the modules of the module cache
+// at /mod/ and the documentation of their versions at
+// /mod/<module>@<version>/<package>.
+func (p *Presentation) HandleModules(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/mod/" || r.URL.Path == "/mod" {
+		result := p.Corpus.LookupModules()
+		p.ServePage(w, Page{
+			Title:    "Module cache",
+			Tabtitle: "Modules",
+			Subtitle: result.Dir,
+			Body:     applyTemplate(p.ModulesHTML, "modulesHTML", result),
+		})
+		return
+	}
+	if p.Corpus.ModuleCache == "" || !strings.Contains(r.URL.Path, "@") {
+		http.NotFound(w, r)
+		return
+	}
+	p.modHandler.ServeHTTP(w, r)
+}
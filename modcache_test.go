@@ -0,0 +1,43 @@
+package godoc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+	"github.com/miclle/godoc/vfs/modfs"
+)
+
+func TestLookupModules(t *testing.T) {
+	cache, err := ioutil.TempDir("", "godoc-modcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cache)
+	if err := os.MkdirAll(filepath.Join(cache, "example.com/lib@v1.8.0"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	c := NewCorpus(mapfs.New(nil))
+	c.ModuleCache = cache
+	want := []modfs.Module{{Path: "example.com/lib", Versions: []string{"v1.8.0"}}}
+	if result := c.LookupModules(); result.Alert != "" || !reflect.DeepEqual(result.Modules, want) {
+		t.Fatalf("LookupModules = %+v; want modules %v", result, want)
+	}
+
+	// The module cache is read again after the sync interval only.
+	if err := os.MkdirAll(filepath.Join(cache, "example.com/lib@v1.9.0"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if result := c.LookupModules(); !reflect.DeepEqual(result.Modules, want) {
+		t.Errorf("cached modules = %v; want %v", result.Modules, want)
+	}
+	c.moduleCache.expires = time.Time{}
+	want[0].Versions = []string{"v1.8.0", "v1.9.0"}
+	if result := c.LookupModules(); !reflect.DeepEqual(result.Modules, want) {
+		t.Errorf("modules after the sync interval = %v; want %v", result.Modules, want)
+	}
+}
@@ -0,0 +1,435 @@
+// This file contains the generator of API files for the released
+// versions of a module, which provide the "since" data of the
+// package pages of the module as $GOROOT/api/go*.txt does for the
+// standard library.
+//
+// The versions of a module are those tagged in its git repository
+// or in the module cache. For each version after the first,
+// WriteModuleAPIFiles writes a file named after the version, such as
+// "v1.2.0.txt", listing the exported declarations of the module's
+// packages which first appeared in that version, in the format of the
+// Go API files:
+//
+//	pkg example.com/m/p, func NewServer(string) *Server
+//	pkg example.com/m/p, method (*Server) Close() error
+//	pkg example.com/m/p, type Server struct, Addr string
+//
+// As for Go 1, the declarations of the first version are not listed.
+// The files are loaded with the $GOROOT/api files by InitVersionInfo
+// if they match Corpus.APIFiles.
+//
+// Package pages of modules in the module cache have a version switcher
+// linking to the other versions of their module (see ModuleVersions).
+
+package godoc
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/miclle/godoc/vfs"
+	"github.com/miclle/godoc/vfs/mapfs"
+	"github.com/miclle/godoc/vfs/modfs"
+)
+
+// A ModuleVersion is a released version of a module.
+type ModuleVersion struct {
+	Version string         // semantic version, such as "v1.2.0"
+	FS      vfs.FileSystem // files of the module, rooted at "/"
+}
+
+// isRelease reports whether v is a release version, of the form
+// "vMAJOR.MINOR.PATCH", without pre-release or build suffixes.
+func isRelease(v string) bool {
+	return semver.Canonical(v) == v && semver.Prerelease(v) == ""
+}
+
+// sortVersions sorts the versions list in increasing order.
+func sortVersions(list []ModuleVersion) {
+	sort.Slice(list, func(i, j int) bool { return semver.Compare(list[i].Version, list[j].Version) < 0 })
+}
+
+// ModuleCacheVersions returns the release versions of the module
+// modpath in the module cache directory cacheDir (such as
+// $GOPATH/pkg/mod), in increasing order.
+func ModuleCacheVersions(cacheDir, modpath string) ([]ModuleVersion, error) {
+	list, err := modfs.Versions(cacheDir, modpath)
+	if err != nil {
+		return nil, err
+	}
+	var versions []ModuleVersion
+	for _, v := range list {
+		if !isRelease(v) {
+			continue
+		}
+		fs, err := modfs.Open(cacheDir, modpath, v)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, ModuleVersion{v, fs})
+	}
+	return versions, nil
+}
+
+// GitVersions returns the release versions of the module modpath in
+// the directory subdir of the git repository repo, in increasing order.
+// The versions are those of the repository's tags, which are prefixed
+// by subdir + "/" if subdir is not empty, as for the go command. As for
+// the go command too, the tags of other major versions than that of the
+// module path are ignored, except for the major versions 2 and higher of
+// a module path without major version suffix at tags without go.mod
+// file, which are "+incompatible" versions.
+// Only the Go files and go.mod files of the versions are read.
+func GitVersions(repo, subdir, modpath string) ([]ModuleVersion, error) {
+	_, pathMajor, ok := module.SplitPathVersion(modpath)
+	if !ok {
+		return nil, fmt.Errorf("invalid module path %q", modpath)
+	}
+	subdir = strings.Trim(subdir, "/")
+	prefix := ""
+	if subdir != "" {
+		prefix = subdir + "/"
+	}
+	out, err := git(repo, "tag", "--list", prefix+"v*")
+	if err != nil {
+		return nil, err
+	}
+	var versions []ModuleVersion
+	for _, tag := range strings.Fields(string(out)) {
+		v := strings.TrimPrefix(tag, prefix)
+		if !isRelease(v) {
+			continue
+		}
+		incompatible := false
+		if module.CheckPathMajor(v, pathMajor) != nil {
+			if module.CheckPathMajor(v+"+incompatible", pathMajor) != nil {
+				continue // version of another major version module
+			}
+			incompatible = true
+		}
+		args := []string{"archive", "--format=tar", tag}
+		if subdir != "" {
+			args = append(args, subdir)
+		}
+		archive, err := git(repo, args...)
+		if err != nil {
+			return nil, err
+		}
+		files, err := readGoFiles(archive, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("tag %s: %v", tag, err)
+		}
+		if incompatible {
+			if _, ok := files["go.mod"]; ok {
+				continue // the module path has no major version suffix
+			}
+			v += "+incompatible"
+		}
+		versions = append(versions, ModuleVersion{v, mapfs.New(files)})
+	}
+	sortVersions(versions)
+	return versions, nil
+}
+
+// git runs the git command with args in the repository repo and
+// returns its output.
+func git(repo string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
+
+// readGoFiles returns the Go files and go.mod files of the tar
+// archive, by their paths with prefix removed.
+func readGoFiles(archive []byte, prefix string) (map[string]string, error) {
+	files := make(map[string]string)
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(hdr.Name, prefix)
+		if hdr.Typeflag != tar.TypeReg || !strings.HasSuffix(name, ".go") && pathpkg.Base(name) != "go.mod" {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = string(data)
+	}
+}
+
+// moduleAPI returns the API features of the packages of the module
+// modpath with the files fs, as lines of an API file keyed by the
+// import paths and names of the features. The package files are
+// selected for the build configuration cfg.
+func moduleAPI(fs vfs.FileSystem, modpath string, cfg BuildConfig) (map[string]string, error) {
+	tree := NewCorpus(fs)
+	tree.BuildConfig = cfg
+	api := make(map[string]string)
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		list, err := fs.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		hasGo := false
+		for _, fi := range list {
+			name := fi.Name()
+			switch {
+			case !fi.IsDir():
+				hasGo = hasGo || strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+			case name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"):
+				// not packages of the module
+			default:
+				sub := pathpkg.Join(dir, name)
+				if _, err := fs.Stat(pathpkg.Join(sub, "go.mod")); err == nil {
+					break // nested module
+				}
+				if err := walk(sub); err != nil {
+					return err
+				}
+			}
+		}
+		if !hasGo {
+			return nil
+		}
+		importPath := pathpkg.Join(modpath, dir)
+		p, err := tree.syntaxAnalysis(dir, importPath, 0, BuildConfig{})
+		if err != nil {
+			return fmt.Errorf("%s: %v", importPath, err)
+		}
+		if p.DocPackage == nil || p.DocPackage.Name == "main" {
+			return nil
+		}
+		for name, f := range apiFeatures(p.FSet, p.DocPackage) {
+			api[importPath+" "+name] = "pkg " + importPath + ", " + apiRow(name, f)
+		}
+		return nil
+	}
+	if err := walk("/"); err != nil {
+		return nil, err
+	}
+	return api, nil
+}
+
+// apiRow returns the API file line, without the package, of the
+// feature f with the given name (see APIChange).
+func apiRow(name string, f *apiFeature) string {
+	oneLine := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	switch f.kind {
+	case "method":
+		return "method " + strings.TrimPrefix(oneLine(f.key), "func ")
+	case "field", "interface method":
+		i := strings.IndexByte(name, '.')
+		typeName, member := name[:i], name[i+1:]
+		keyword := "struct"
+		if f.kind == "interface method" {
+			keyword = "interface"
+		}
+		row := oneLine(f.key)
+		if !strings.HasPrefix(row, member+" ") && !strings.HasPrefix(row, member+"(") {
+			row = "embedded " + row
+		}
+		return "type " + typeName + " " + keyword + ", " + row
+	}
+	return oneLine(f.key)
+}
+
+// WriteModuleAPIFiles writes the API files of the versions of the
+// module modpath to the directory dir: for each version after the
+// first, the file <version>.txt lists the API features which are not
+// in the previous versions, in sorted order. The package files are
+// selected for the build configuration cfg.
+func WriteModuleAPIFiles(dir, modpath string, versions []ModuleVersion, cfg BuildConfig) error {
+	seen := make(map[string]bool)
+	for i, v := range versions {
+		api, err := moduleAPI(v.FS, modpath, cfg)
+		if err != nil {
+			return fmt.Errorf("%s: %v", v.Version, err)
+		}
+		var added []string
+		for key, row := range api {
+			if !seen[key] {
+				seen[key] = true
+				added = append(added, row)
+			}
+		}
+		if i == 0 {
+			continue
+		}
+		sort.Strings(added)
+		var buf bytes.Buffer
+		for _, row := range added {
+			buf.WriteString(row)
+			buf.WriteByte('\n')
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, v.Version+".txt"), buf.Bytes(), 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sinceLabelFunc returns the release named by the "since" version v:
+// "Go 1.7" for the Go version "1.7", and v itself for the module
+// versions of the form "vX.Y.Z".
+func sinceLabelFunc(v string) string {
+	if strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "Go " + v
+}
+
+// ----------------------------------------------------------------------------
+// Module versions of package pages
+
+// ModuleVersions describes the versions in the module cache of the
+// module of a package, for the version switcher of its package page.
+type ModuleVersions struct {
+	Path    string   // module path, such as "example.com/lib/v2"
+	Dir     string   // slash-separated package directory in the module; "" for the module root
+	Version string   // version of the page; "" for the version of the corpus
+	Default bool     // whether the corpus has a version of the module besides those of the module cache
+	List    []string // versions of the module and its other major versions, in increasing order
+}
+
+// ImportPath returns the import path of the package.
+func (m *ModuleVersions) ImportPath() string {
+	return pathpkg.Join(m.Path, m.Dir)
+}
+
+// URL returns the package page of the given version of the package;
+// the version "" is the one of the corpus.
+func (m *ModuleVersions) URL(version string) string {
+	p := m.Path
+	if version != "" {
+		p = trimMajorVersion(m.Path) + "@" + version
+	}
+	return "/pkg/" + pathpkg.Join(p, m.Dir) + "/"
+}
+
+// trimMajorVersion returns the module path modpath without its major
+// version suffix ("/v2", "/v3", ...).
+func trimMajorVersion(modpath string) string {
+	dir, elem := pathpkg.Split(modpath)
+	if n, err := strconv.Atoi(strings.TrimPrefix(elem, "v")); err == nil && n >= 2 && elem == "v"+strconv.Itoa(n) {
+		return strings.TrimSuffix(dir, "/")
+	}
+	return modpath
+}
+
+// moduleVersions returns the versions in c.ModuleCache of the module
+// of the package with the given import path, which is qualified with
+// a version for the packages of module versions ("example.com/lib@v1.8.0/sub").
+// It returns nil if c.ModuleCache is empty or the package is not in
+// a module of the module cache.
+func (c *Corpus) moduleVersions(importPath string) *ModuleVersions {
+	if c.ModuleCache == "" {
+		return nil
+	}
+	var m *ModuleVersions
+	if at := strings.IndexByte(importPath, '@'); at >= 0 {
+		version, dir := importPath[at+1:], ""
+		if i := strings.IndexByte(version, '/'); i >= 0 {
+			version, dir = version[:i], version[i+1:]
+		}
+		modpath, ok := modfs.Lookup(c.ModuleCache, importPath[:at], version)
+		if !ok {
+			return nil
+		}
+		m = &ModuleVersions{Path: modpath, Dir: dir, Version: version}
+		if _, err := c.fs.Stat(pathpkg.Join("/src", m.ImportPath())); err == nil {
+			m.Default = true
+		}
+	} else {
+		if i := strings.IndexByte(importPath, '/'); i < 0 || !strings.Contains(importPath[:i], ".") {
+			return nil // standard library
+		}
+		for p := importPath; strings.Contains(p, "/"); p = pathpkg.Dir(p) {
+			if list := c.cachedVersions(p, false); len(list) > 0 {
+				m = &ModuleVersions{Path: p, Dir: strings.TrimPrefix(importPath[len(p):], "/"), Default: true}
+				break
+			}
+		}
+		if m == nil {
+			return nil
+		}
+	}
+	m.List = c.cachedVersions(trimMajorVersion(m.Path), true)
+	return m
+}
+
+// A moduleCache caches the version lists read from Corpus.ModuleCache
+// for the package pages, and its modules listed by the /mod/ page. They
+// are read again after a sync interval, as the module cache is not
+// watched by the syncs.
+type moduleCache struct {
+	mu       sync.Mutex
+	expires  time.Time
+	versions map[string][]string // by module path, with "/..." appended for AllVersions
+	modules  *ModuleCacheResult  // of LookupModules; nil if not read
+}
+
+// expire resets mc if the sync interval d elapsed since it was
+// filled. It must be called with mc.mu held.
+func (mc *moduleCache) expire(d time.Duration) {
+	if now := time.Now(); now.After(mc.expires) {
+		mc.expires = now.Add(d)
+		mc.versions = make(map[string][]string)
+		mc.modules = nil
+	}
+}
+
+// cachedVersions returns the versions of the module modpath in
+// c.ModuleCache, as modfs.Versions does, or modfs.AllVersions if all
+// is set. It returns nil if they can't be read.
+func (c *Corpus) cachedVersions(modpath string, all bool) []string {
+	key := modpath
+	if all {
+		key += "/..."
+	}
+	mc := &c.moduleCache
+	mc.mu.Lock()
+	mc.expire(c.syncInterval())
+	list, ok := mc.versions[key]
+	mc.mu.Unlock()
+	if ok {
+		return list
+	}
+
+	// Read outside of the lock, which would serialize the requests.
+	if all {
+		list, _ = modfs.AllVersions(c.ModuleCache, modpath)
+	} else {
+		list, _ = modfs.Versions(c.ModuleCache, modpath)
+	}
+	mc.mu.Lock()
+	mc.versions[key] = list
+	mc.mu.Unlock()
+	return list
+}
@@ -0,0 +1,262 @@
+package godoc
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/miclle/godoc/vfs"
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestSortVersions(t *testing.T) {
+	list := []ModuleVersion{{Version: "v1.10.0"}, {Version: "v1.2.1"}, {Version: "v0.9.0"}, {Version: "v1.2.0"}}
+	sortVersions(list)
+	var got []string
+	for _, v := range list {
+		got = append(got, v.Version)
+	}
+	want := []string{"v0.9.0", "v1.2.0", "v1.2.1", "v1.10.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortVersions = %v; want %v", got, want)
+	}
+	for _, v := range []string{"1.2.0", "v1.2", "v1.2.0-rc.1", "v1.02.0"} {
+		if isRelease(v) {
+			t.Errorf("isRelease(%q) = true; want false", v)
+		}
+	}
+}
+
+func TestWriteModuleAPIFiles(t *testing.T) {
+	versions := []ModuleVersion{
+		{"v1.0.0", mapfs.New(map[string]string{
+			"go.mod": "module example.com/m\n",
+			"p/p.go": "package p\n\ntype S struct{ X int }\n\nfunc F() {}\n",
+		})},
+		{"v1.1.0", mapfs.New(map[string]string{
+			"go.mod":      "module example.com/m\n",
+			"p/p.go":      "package p\n\ntype S struct {\n\tX int\n\tY string\n}\n\nfunc (*S) M(n int) error { return nil }\n\nfunc F() {}\n\nfunc G() {}\n",
+			"p/p_test.go": "package p\n\nfunc Test() {}\n",
+			"cmd/c/c.go":  "package main\n\nfunc Exported() {}\n\nfunc main() {}\n",
+			"sub/go.mod":  "module example.com/m/sub\n",
+			"sub/sub.go":  "package sub\n\nfunc Nested() {}\n",
+		})},
+		{"v1.2.0", mapfs.New(map[string]string{
+			"go.mod":   "module example.com/m\n",
+			"p/p.go":   "package p\n\ntype S struct {\n\tX int\n\tY string\n}\n\nfunc (*S) M(n int) error { return nil }\n\nfunc F() {}\n\nfunc G() {}\n",
+			"q/q.go":   "package q\n\ntype I interface {\n\tM()\n}\n",
+			"q/doc.go": "// Package q is new.\npackage q\n",
+		})},
+	}
+	dir, err := ioutil.TempDir("", "godoc-modapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := WriteModuleAPIFiles(dir, "example.com/m", versions, BuildConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "v1.0.0.txt")); !os.IsNotExist(err) {
+		t.Errorf("API file of the first version: got %v; want none", err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "v1.1.0.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `pkg example.com/m/p, func G()
+pkg example.com/m/p, method (*S) M(int) error
+pkg example.com/m/p, type S struct, Y string
+`
+	if string(data) != want {
+		t.Errorf("v1.1.0.txt:\n%s\nwant:\n%s", data, want)
+	}
+
+	vp := new(versionParser)
+	for _, name := range []string{"v1.1.0.txt", "v1.2.0.txt"} {
+		if err := vp.parseFile(vfs.OS(dir), "/"+name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tc := range []struct {
+		kind, receiver, name, pkg string
+		want                      string
+	}{
+		{"func", "", "F", "example.com/m/p", ""},
+		{"func", "", "G", "example.com/m/p", "v1.1.0"},
+		{"method", "*S", "M", "example.com/m/p", "v1.1.0"},
+		{"type", "", "I", "example.com/m/q", "v1.2.0"},
+	} {
+		if got := vp.res.sinceVersionFunc(tc.kind, tc.receiver, tc.name, tc.pkg); got != tc.want {
+			t.Errorf("since %s %s = %q; want %q", tc.kind, tc.name, got, tc.want)
+		}
+	}
+	if got := vp.res["example.com/m/p"].fieldSince["S"]["Y"]; got != "v1.1.0" {
+		t.Errorf("since field S.Y = %q; want v1.1.0", got)
+	}
+}
+
+func TestGitVersions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	repo, err := ioutil.TempDir("", "godoc-gitversions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repo)
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=godoc", "-c", "user.email=godoc@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		name = filepath.Join(repo, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q")
+	write("mod/go.mod", "module example.com/mod\n")
+	write("mod/p.go", "package p\n\nfunc F() {}\n")
+	write("old/p.go", "package p\n") // a module without go.mod file
+	write("README", "not Go\n")
+	run("add", "-A")
+	run("commit", "-q", "-m", "first")
+	run("tag", "mod/v1.0.0")
+	run("tag", "v9.0.0") // another module of the repository
+	write("mod/p.go", "package p\n\nfunc F() {}\n\nfunc G() {}\n")
+	run("commit", "-q", "-a", "-m", "second")
+	run("tag", "mod/v1.1.0")
+	run("tag", "mod/v1.2.0-rc.1")
+	run("tag", "old/v1.0.0")
+	write("mod/go.mod", "module example.com/mod/v2\n")
+	run("commit", "-q", "-a", "-m", "v2")
+	run("tag", "mod/v2.0.0")
+	run("tag", "old/v2.0.0")
+
+	versions, err := GitVersions(repo, "mod", "example.com/mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range versions {
+		got = append(got, v.Version)
+	}
+	if want := []string{"v1.0.0", "v1.1.0"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("GitVersions = %v; want %v", got, want)
+	}
+	api, err := moduleAPI(versions[1].FS, "example.com/mod", BuildConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := api["example.com/mod G"], "pkg example.com/mod, func G()"; got != want {
+		t.Errorf("API of v1.1.0: G is %q; want %q", got, want)
+	}
+
+	// The other major versions are those of their module path, and
+	// those of modules without go.mod files are "+incompatible".
+	for _, tc := range []struct {
+		subdir, modpath string
+		want            []string
+	}{
+		{"mod", "example.com/mod/v2", []string{"v2.0.0"}},
+		{"old", "example.com/old", []string{"v1.0.0", "v2.0.0+incompatible"}},
+	} {
+		versions, err := GitVersions(repo, tc.subdir, tc.modpath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, v := range versions {
+			got = append(got, v.Version)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("GitVersions of %s = %v; want %v", tc.modpath, got, tc.want)
+		}
+	}
+}
+
+func TestModuleVersions(t *testing.T) {
+	cache, err := ioutil.TempDir("", "godoc-modcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cache)
+	for _, dir := range []string{"example.com/lib@v1.8.0/sub", "example.com/lib@v1.9.0", "example.com/lib/v2@v2.3.0/sub"} {
+		if err := os.MkdirAll(filepath.Join(cache, filepath.FromSlash(dir)), 0777); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The build list has v1 only.
+	c := NewCorpus(mapfs.New(map[string]string{"src/example.com/lib/lib.go": "package lib"}))
+	c.ModuleCache = cache
+	list := []string{"v1.8.0", "v1.9.0", "v2.3.0"}
+
+	for _, tc := range []struct {
+		importPath string
+		want       *ModuleVersions
+		importAs   string
+		urls       [2]string // of the versions "" and v2.3.0
+	}{
+		{"fmt", nil, "", [2]string{}},
+		{"example.com/other", nil, "", [2]string{}},
+		{"example.com/lib@v1.7.0", nil, "", [2]string{}},
+		{
+			"example.com/lib/sub",
+			&ModuleVersions{Path: "example.com/lib", Dir: "sub", Default: true, List: list},
+			"example.com/lib/sub",
+			[2]string{"/pkg/example.com/lib/sub/", "/pkg/example.com/lib@v2.3.0/sub/"},
+		},
+		{
+			"example.com/lib@v1.8.0",
+			&ModuleVersions{Path: "example.com/lib", Version: "v1.8.0", Default: true, List: list},
+			"example.com/lib",
+			[2]string{"/pkg/example.com/lib/", "/pkg/example.com/lib@v2.3.0/"},
+		},
+		{
+			"example.com/lib@v2.3.0/sub",
+			&ModuleVersions{Path: "example.com/lib/v2", Dir: "sub", Version: "v2.3.0", List: list},
+			"example.com/lib/v2/sub",
+			[2]string{"/pkg/example.com/lib/v2/sub/", "/pkg/example.com/lib@v2.3.0/sub/"},
+		},
+	} {
+		m := c.moduleVersions(tc.importPath)
+		if !reflect.DeepEqual(m, tc.want) {
+			t.Errorf("moduleVersions(%q) = %+v; want %+v", tc.importPath, m, tc.want)
+			continue
+		}
+		if m == nil {
+			continue
+		}
+		if got := m.ImportPath(); got != tc.importAs {
+			t.Errorf("%s: ImportPath = %q; want %q", tc.importPath, got, tc.importAs)
+		}
+		if got := [2]string{m.URL(""), m.URL("v2.3.0")}; got != tc.urls {
+			t.Errorf("%s: URLs = %q; want %q", tc.importPath, got, tc.urls)
+		}
+	}
+
+	// The version lists are read again after the sync interval only.
+	if err := os.MkdirAll(filepath.Join(cache, "example.com/lib@v1.10.0"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if m := c.moduleVersions("example.com/lib"); !reflect.DeepEqual(m.List, list) {
+		t.Errorf("cached versions = %q; want %q", m.List, list)
+	}
+	c.moduleCache.expires = time.Time{}
+	if m, want := c.moduleVersions("example.com/lib"), []string{"v1.8.0", "v1.9.0", "v1.10.0", "v2.3.0"}; !reflect.DeepEqual(m.List, want) {
+		t.Errorf("versions after the sync interval = %q; want %q", m.List, want)
+	}
+}
@@ -1,24 +1,28 @@
 package godoc
 
 import (
+	"fmt"
 	"go/ast"
 	"go/doc"
 	"go/token"
+	"go/types"
 )
 
-// Package type
+// A Package describes a Go package, as returned by Corpus.SyntaxAnalysis.
 type Package struct {
 	Dir           string // !important: directory containing package sources
 	ImportPath    string // !important: import path of package in dir
 	ImportComment string // path in import comment on package statement
 	Name          string // package name
 	Doc           string // package documentation string
+	Synopsis      string // package synopsis
 	Stale         bool   // would 'go install' do anything for this package?
 	StaleReason   string // why is Stale true?
 
-	Imports   []string               // import paths used by this package
-	Filenames []string               // all files
-	Notes     map[string][]*doc.Note // Contains Buts, etc...
+	Imports    []string               // import paths used by this package
+	ImportedBy []string               // import paths of the packages of the corpus importing this package
+	Filenames  []string               // package files used in the build, relative to Dir
+	Notes      map[string][]*doc.Note // Contains Bugs, etc...
 
 	// declarations
 	Consts []*doc.Value
@@ -34,11 +38,102 @@ type Package struct {
 	DocPackage *doc.Package         // nil if no package documentation
 	PAst       map[string]*ast.File // nil if no AST with package exports
 	IsMain     bool                 // true for package main
+	TypesPkg   *types.Package       // nil unless type-checked (TypeCheck mode)
+	TypesInfo  *types.Info          // nil unless type-checked (TypeCheck mode)
+	Platforms  *PlatformInfo        // nil unless documented for several platforms (AllPlatforms mode)
+
+	Deprecations *Deprecations // nil if nothing is deprecated
 
 	ParentImportPath string   // parent package ImportPath
-	Parent           *Package // parent package, important: json must ignore, prevent cycle parsing
+	Parent           *Package `json:"-"` // parent package, important: json must ignore, prevent cycle parsing
 	SubPackages      Packages // subpackages
 }
 
 // Packages with package array
 type Packages []*Package
+
+// Packages returns the package hierarchy of the corpus: the packages
+// which are not nested in the directory of another package, with the
+// packages nested in their directories as SubPackages, recursively.
+// A package is the parent of the packages nested in its directory,
+// unless they are nested in a closer package directory.
+//
+// The packages are not loaded: only Dir, ImportPath, Synopsis and
+// the hierarchy fields are set. Use Package to load a package.
+// Packages returns nil if the corpus is not initialized.
+func (c *Corpus) Packages() Packages {
+	roots, _ := c.packageTree()
+	return roots
+}
+
+// Package returns the package with the given import path, loaded by
+// SyntaxAnalysis for the current system. Its hierarchy fields are set
+// as by Packages; the parent and subpackages are not loaded.
+func (c *Corpus) Package(importPath string) (*Package, error) {
+	_, packages := c.packageTree()
+	node := packages[importPath]
+	if node == nil {
+		return nil, fmt.Errorf("package %s not found", importPath)
+	}
+
+	p, err := c.SyntaxAnalysis(node.Dir, 0, "", "")
+	if err != nil {
+		return nil, err
+	}
+	p.Synopsis = node.Synopsis
+	p.ParentImportPath = node.ParentImportPath
+	p.Parent = node.Parent
+	p.SubPackages = node.SubPackages
+	// Replace node by p in the hierarchy, which is built for this call.
+	if p.Parent != nil {
+		for i, sub := range p.Parent.SubPackages {
+			if sub == node {
+				p.Parent.SubPackages[i] = p
+			}
+		}
+	}
+	for _, sub := range p.SubPackages {
+		sub.Parent = p
+	}
+	return p, nil
+}
+
+// packageTree builds the package hierarchy from the current directory
+// tree. It returns the root packages and all packages by import path.
+func (c *Corpus) packageTree() (roots Packages, packages map[string]*Package) {
+	v, _ := c.fsTree.Get()
+	tree, _ := v.(*Directory)
+	if tree == nil {
+		return nil, nil
+	}
+	src := tree.lookup("/src")
+	if src == nil {
+		return nil, nil
+	}
+
+	packages = make(map[string]*Package)
+	var walk func(d *Directory, parent *Package)
+	walk = func(d *Directory, parent *Package) {
+		if d.HasPkg {
+			p := &Package{
+				Dir:        d.Path,
+				ImportPath: d.ImportPath,
+				Synopsis:   d.Synopsis,
+				Parent:     parent,
+			}
+			if parent != nil {
+				p.ParentImportPath = parent.ImportPath
+				parent.SubPackages = append(parent.SubPackages, p)
+			} else {
+				roots = append(roots, p)
+			}
+			packages[p.ImportPath] = p
+			parent = p
+		}
+		for _, sub := range d.SubDirectories {
+			walk(sub, parent)
+		}
+	}
+	walk(src, nil)
+	return roots, packages
+}
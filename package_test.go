@@ -0,0 +1,70 @@
+package godoc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestPackages(t *testing.T) {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/a/a.go":         "// Package a is the root.\npackage a\n",
+		"src/a/b/b.go":       "// Package b is a child of a.\npackage b\n",
+		"src/a/x/y/c/c.go":   "// Package c is a child of a too.\npackage c\n",
+		"src/a/b/d/d.go":     "// Package d is a child of b.\npackage d\n",
+		"src/e/f/f.go":       "// Package f has no parent.\npackage f\n",
+		"src/e/f/testdata/x": "ignored",
+	}))
+	c.IndexEnabled = false
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	var walk func(list Packages, parent *Package) []string
+	walk = func(list Packages, parent *Package) []string {
+		var paths []string
+		for _, p := range list {
+			if p.Parent != parent {
+				t.Errorf("%s: wrong parent %v", p.ImportPath, p.Parent)
+			}
+			if parent != nil && p.ParentImportPath != parent.ImportPath {
+				t.Errorf("%s: ParentImportPath = %q; want %q", p.ImportPath, p.ParentImportPath, parent.ImportPath)
+			}
+			paths = append(paths, p.ImportPath)
+			for _, sub := range walk(p.SubPackages, p) {
+				paths = append(paths, p.ImportPath+" > "+sub)
+			}
+		}
+		return paths
+	}
+	got := walk(c.Packages(), nil)
+	want := []string{"a", "a > a/b", "a > a/b > a/b/d", "a > a/x/y/c", "e/f"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Packages() = %q; want %q", got, want)
+	}
+
+	p, err := c.Package("a/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "b" || p.Synopsis != "Package b is a child of a." || p.ParentImportPath != "a" || p.Parent == nil || len(p.SubPackages) != 1 {
+		t.Errorf("Package(a/b) = %+v", p)
+	}
+	if sub := p.SubPackages[0]; sub.ImportPath != "a/b/d" || sub.Parent != p {
+		t.Errorf("Package(a/b).SubPackages[0] = %+v", sub)
+	}
+	found := false
+	for _, sub := range p.Parent.SubPackages {
+		if sub.ImportPath == "a/b" {
+			found = sub == p
+		}
+	}
+	if !found {
+		t.Error("Package(a/b) is not a subpackage of its parent")
+	}
+
+	if _, err := c.Package("a/x"); err == nil {
+		t.Error("Package(a/x) succeeded for a directory without package")
+	}
+}
@@ -24,6 +24,7 @@ type Page struct {
 	Body    []byte // Main content
 
 	// filled in by ServePage
+	SearchBox  bool
 	Playground bool
 	Version    string
 
@@ -39,6 +40,7 @@ type Presentation struct {
 	fileServer http.Handler
 	cmdHandler handlerServer
 	pkgHandler handlerServer
+	modHandler handlerServer
 
 	LayoutHTML,
 	SidebarHTML,
@@ -48,7 +50,17 @@ type Presentation struct {
 	ExampleHTML,
 
 	PackageHTML,
-	PackageRootHTML *template.Template // If not nil
+	PackageRootHTML,
+	RefsHTML,
+	DeprecatedHTML,
+	APIDiffHTML,
+	ModulesHTML,
+	DiagnosticsHTML,
+	SearchHTML *template.Template // If not nil
+
+	// PackageMarkdown optionally specifies the template of the
+	// package pages in the Markdown mode.
+	PackageMarkdown *template.Template
 
 	// TabWidth optionally specifies the tab width.
 	TabWidth int
@@ -122,8 +134,22 @@ func NewPresentation(c *Corpus) *Presentation {
 		fsRoot:       "/src",
 		exclude:      []string{"/src/cmd"},
 	}
+	p.modHandler = handlerServer{
+		presentation: p,
+		corpus:       c,
+		pattern:      "/mod/",
+		stripPrefix:  "mod/",
+		fsRoot:       "/src",
+	}
 	p.cmdHandler.registerWithMux(p.mux)
 	p.pkgHandler.registerWithMux(p.mux)
+	p.mux.HandleFunc("/search", p.HandleSearch)
+	p.mux.HandleFunc("/refs/", p.HandleRefs)
+	p.mux.HandleFunc("/deprecated/", p.HandleDeprecated)
+	p.mux.HandleFunc("/apidiff", p.HandleAPIDiff)
+	p.mux.HandleFunc("/graph/", p.HandleGraph)
+	p.mux.HandleFunc("/mod/", p.HandleModules)
+	p.mux.HandleFunc("/diagnostics", p.HandleDiagnostics)
 	p.mux.HandleFunc("/", p.ServeFile)
 	return p
 }
@@ -148,6 +174,7 @@ func (p *Presentation) ServePage(w http.ResponseWriter, page Page) {
 	if page.Tabtitle == "" {
 		page.Tabtitle = page.Title
 	}
+	page.SearchBox = p.Corpus.IndexEnabled
 	page.Playground = p.ShowPlayground
 	page.Version = runtime.Version()
 
@@ -175,11 +202,11 @@ func (p *Presentation) ServeError(w http.ResponseWriter, r *http.Request, relpat
 // TODO(bradfitz): move this to be a method on Corpus. Just moving code around for now,
 // but this doesn't feel right.
 func (p *Presentation) GetPkgPageInfo(abspath, relpath string, mode PageInfoMode) *PageInfo {
-	return p.pkgHandler.GetPageInfo(abspath, relpath, mode, "", "")
+	return p.pkgHandler.GetPageInfo(abspath, relpath, mode, BuildConfig{})
 }
 
 // TODO(bradfitz): move this to be a method on Corpus. Just moving code around for now,
 // but this doesn't feel right.
 func (p *Presentation) GetCmdPageInfo(abspath, relpath string, mode PageInfoMode) *PageInfo {
-	return p.cmdHandler.GetPageInfo(abspath, relpath, mode, "", "")
+	return p.cmdHandler.GetPageInfo(abspath, relpath, mode, BuildConfig{})
 }
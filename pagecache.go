@@ -0,0 +1,155 @@
+// This file contains the cache of the package information
+// computed by syntaxAnalysis for package pages.
+//
+// The entries are keyed by directory, PageInfoMode and BuildConfig,
+// and validated on each use with a stamp of the Go files of the
+// directory (names, sizes and modification times), so that changed
+// packages are analyzed again. The cache is bounded by the estimated
+// memory size of the entries and evicts the least recently used ones.
+
+package godoc
+
+import (
+	"container/list"
+	"encoding/binary"
+	"hash/fnv"
+	"os"
+	"sync"
+)
+
+// pageInfoSizeFactor estimates the memory size of the package
+// information (ASTs, documentation) per byte of source.
+const pageInfoSizeFactor = 16
+
+// PageInfoCacheStats describes the usage of the package
+// information cache of a corpus.
+type PageInfoCacheStats struct {
+	Hits      int64 // lookups of valid entries
+	Misses    int64 // lookups of missing or stale entries
+	Evictions int64 // entries evicted to stay within the size limit
+	Entries   int   // current number of entries
+	Size      int64 // current estimated size in bytes
+	MaxSize   int64 // size limit in bytes
+}
+
+type pageInfoKey struct {
+	abspath string
+	mode    PageInfoMode
+	build   BuildConfig
+}
+
+type pageInfoEntry struct {
+	key   pageInfoKey
+	stamp uint64 // stamp of the Go files of the directory
+	size  int64  // estimated memory size
+	pkg   *Package
+}
+
+// A pageInfoCache is an LRU cache of package information.
+type pageInfoCache struct {
+	mu      sync.Mutex
+	entries map[pageInfoKey]*list.Element // of *pageInfoEntry
+	lru     list.List                     // most recently used first
+	stats   PageInfoCacheStats
+}
+
+func (pc *pageInfoCache) get(key pageInfoKey, stamp uint64) *Package {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if elem := pc.entries[key]; elem != nil {
+		e := elem.Value.(*pageInfoEntry)
+		if e.stamp == stamp {
+			pc.lru.MoveToFront(elem)
+			pc.stats.Hits++
+			return e.pkg
+		}
+		pc.remove(elem)
+	}
+	pc.stats.Misses++
+	return nil
+}
+
+func (pc *pageInfoCache) put(e *pageInfoEntry, maxSize int64) {
+	if e.size > maxSize {
+		return // don't evict everything for a single huge package
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.entries == nil {
+		pc.entries = make(map[pageInfoKey]*list.Element)
+	}
+	if elem := pc.entries[e.key]; elem != nil {
+		pc.remove(elem) // added concurrently
+	}
+	pc.entries[e.key] = pc.lru.PushFront(e)
+	pc.stats.Entries++
+	pc.stats.Size += e.size
+	for pc.stats.Size > maxSize {
+		pc.remove(pc.lru.Back())
+		pc.stats.Evictions++
+	}
+}
+
+func (pc *pageInfoCache) remove(elem *list.Element) {
+	e := pc.lru.Remove(elem).(*pageInfoEntry)
+	delete(pc.entries, e.key)
+	pc.stats.Entries--
+	pc.stats.Size -= e.size
+}
+
+// goFilesStamp returns a stamp of the names, sizes and modification
+// times of the Go files in list and their total size.
+func goFilesStamp(list []os.FileInfo) (stamp uint64, size int64) {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, fi := range list {
+		if isGoFile(fi) {
+			h.Write([]byte(fi.Name()))
+			binary.LittleEndian.PutUint64(buf[:], uint64(fi.Size()))
+			h.Write(buf[:])
+			binary.LittleEndian.PutUint64(buf[:], uint64(fi.ModTime().UnixNano()))
+			h.Write(buf[:])
+			size += fi.Size()
+		}
+	}
+	return h.Sum64(), size
+}
+
+// cachedSyntaxAnalysis is like syntaxAnalysis but returns the cached
+// package information of abspath if its Go files did not change since
+// it was computed. The result must not be modified.
+//
+// Packages analyzed with the TypeCheck mode are not cached: they
+// depend on the files of the imported packages as well.
+func (c *Corpus) cachedSyntaxAnalysis(abspath, importPath string, mode PageInfoMode, cfg BuildConfig) (*Package, error) {
+	if c.PageInfoCacheSize <= 0 || mode&TypeCheck != 0 {
+		return c.syntaxAnalysis(abspath, importPath, mode, cfg)
+	}
+
+	list, err := c.fs.ReadDir(abspath)
+	if err != nil {
+		return c.syntaxAnalysis(abspath, importPath, mode, cfg)
+	}
+	stamp, size := goFilesStamp(list)
+	key := pageInfoKey{abspath, mode, cfg}
+	if pkg := c.pageInfoCache.get(key, stamp); pkg != nil {
+		return pkg, nil
+	}
+
+	pkg, err := c.syntaxAnalysis(abspath, importPath, mode, cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.pageInfoCache.put(&pageInfoEntry{key, stamp, size * pageInfoSizeFactor, pkg}, c.PageInfoCacheSize)
+	return pkg, nil
+}
+
+// PageInfoCacheStats returns the usage statistics of the package
+// information cache.
+func (c *Corpus) PageInfoCacheStats() PageInfoCacheStats {
+	c.pageInfoCache.mu.Lock()
+	defer c.pageInfoCache.mu.Unlock()
+	stats := c.pageInfoCache.stats
+	stats.MaxSize = c.PageInfoCacheSize
+	return stats
+}
@@ -0,0 +1,62 @@
+package godoc
+
+import (
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestPageInfoCache(t *testing.T) {
+	files := map[string]string{
+		"src/a/a.go": "// Package a is a package.\npackage a\n\nfunc A() {}\n",
+		"src/b/b.go": "// Package b is another package.\npackage b\n\nfunc B() {}\n",
+	}
+	c := NewCorpus(mapfs.New(files)) // mapfs reads files, so changes are visible
+	c.IndexEnabled = false
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(name string, hits, misses, evictions int64, entries int) {
+		t.Helper()
+		s := c.PageInfoCacheStats()
+		if s.Hits != hits || s.Misses != misses || s.Evictions != evictions || s.Entries != entries {
+			t.Errorf("%s: stats = %+v; want %d hits, %d misses, %d evictions, %d entries", name, s, hits, misses, evictions, entries)
+		}
+	}
+
+	p1, err := c.cachedSyntaxAnalysis("/src/a", "a", 0, BuildConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("first lookup", 0, 1, 0, 1)
+	if p2, _ := c.cachedSyntaxAnalysis("/src/a", "a", 0, BuildConfig{}); p2 != p1 {
+		t.Error("second lookup not served from the cache")
+	}
+	check("second lookup", 1, 1, 0, 1)
+
+	if p, _ := c.cachedSyntaxAnalysis("/src/a", "a", NoFiltering, BuildConfig{}); p == p1 {
+		t.Error("lookup with another mode served from the cache")
+	}
+	check("other mode", 1, 2, 0, 2)
+
+	files["src/a/a.go"] += "\nfunc A2() {}\n"
+	p3, _ := c.cachedSyntaxAnalysis("/src/a", "a", 0, BuildConfig{})
+	if p3 == p1 || len(p3.DocPackage.Funcs) != 2 {
+		t.Error("changed package served from the cache")
+	}
+	check("changed package", 1, 3, 0, 2)
+
+	// a limit for two entries evicts the least recently used one
+	c.PageInfoCacheSize = int64(len(files["src/a/a.go"])+len(files["src/b/b.go"])) * pageInfoSizeFactor
+	c.cachedSyntaxAnalysis("/src/a", "a", 0, BuildConfig{})
+	c.cachedSyntaxAnalysis("/src/b", "b", 0, BuildConfig{})
+	check("eviction", 2, 4, 1, 2)
+	if p, _ := c.cachedSyntaxAnalysis("/src/a", "a", 0, BuildConfig{}); p != p3 {
+		t.Error("recently used entry evicted")
+	}
+
+	c.PageInfoCacheSize = 0
+	c.cachedSyntaxAnalysis("/src/a", "a", 0, BuildConfig{})
+	check("disabled", 3, 4, 1, 2)
+}
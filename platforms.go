@@ -0,0 +1,217 @@
+// This file contains the support for the AllPlatforms PageInfoMode,
+// which shows the declarations of a package for several platforms
+// at once.
+//
+// The package files selected for each platform are merged and
+// documented together; the platforms a declaration is available on
+// are those selecting a file which declares it. go/doc keeps one
+// declaration per function, method and type name, so these are
+// identified by name; constants and variables are kept per
+// declaration and identified by their file.
+
+package godoc
+
+import (
+	"go/ast"
+	"go/build"
+	"go/token"
+	pathpkg "path"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// defaultPlatforms are the platforms evaluated in the AllPlatforms
+// mode if Corpus.Platforms is not set.
+var defaultPlatforms = []string{
+	"linux/amd64",
+	"linux/arm64",
+	"darwin/amd64",
+	"darwin/arm64",
+	"windows/amd64",
+	"freebsd/amd64",
+}
+
+// PlatformInfo describes the availability of the declarations of
+// a package documented for several platforms (AllPlatforms mode).
+type PlatformInfo struct {
+	Platforms []string            // the platforms evaluated, as "GOOS/GOARCH"
+	Files     map[string][]string // platforms selecting a file, by file name
+	Decls     map[string][]string // platforms by function and type name, and by "Type.Method" for methods
+}
+
+func (c *Corpus) platforms() []string {
+	if len(c.Platforms) > 0 {
+		return c.Platforms
+	}
+	return defaultPlatforms
+}
+
+// mergePlatforms returns the package in the directory abspath with the
+// package files for all platforms of c, and which platforms select them.
+// The other settings of cfg apply to all platforms. It fails if no
+// platform has a package in the directory.
+func (c *Corpus) mergePlatforms(abspath string, mode PageInfoMode, cfg BuildConfig) (*build.Package, *PlatformInfo, error) {
+	pi := &PlatformInfo{
+		Files: make(map[string][]string),
+		Decls: make(map[string][]string),
+	}
+	var merged *build.Package
+	var firstErr error
+	seen := make(map[string]bool) // merged file names and imports
+	union := func(dst *[]string, list []string, kind string) {
+		for _, name := range list {
+			if !seen[kind+name] {
+				seen[kind+name] = true
+				*dst = append(*dst, name)
+			}
+		}
+		sort.Strings(*dst)
+	}
+	for _, platform := range c.platforms() {
+		i := strings.IndexByte(platform, '/')
+		if i < 0 {
+			continue
+		}
+		cfg.GOOS, cfg.GOARCH = platform[:i], platform[i+1:]
+		ctxt := c.buildContext(mode, cfg)
+		pkginfo, err := ctxt.ImportDir(abspath, 0)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		pi.Platforms = append(pi.Platforms, platform)
+		for _, name := range append(pkginfo.GoFiles, pkginfo.CgoFiles...) {
+			pi.Files[name] = append(pi.Files[name], platform)
+		}
+		if merged == nil {
+			merged = pkginfo
+			for _, list := range [][]string{merged.GoFiles, merged.CgoFiles, merged.TestGoFiles, merged.XTestGoFiles} {
+				for _, name := range list {
+					seen["file"+name] = true
+				}
+			}
+			for _, path := range merged.Imports {
+				seen["import"+path] = true
+			}
+			continue
+		}
+		union(&merged.GoFiles, pkginfo.GoFiles, "file")
+		union(&merged.CgoFiles, pkginfo.CgoFiles, "file")
+		union(&merged.TestGoFiles, pkginfo.TestGoFiles, "file")
+		union(&merged.XTestGoFiles, pkginfo.XTestGoFiles, "file")
+		union(&merged.Imports, pkginfo.Imports, "import")
+	}
+	if merged == nil {
+		return nil, nil, firstErr
+	}
+	return merged, pi, nil
+}
+
+// addDecls records the platforms of the function, method and type
+// declarations of the file with the given name.
+func (pi *PlatformInfo) addDecls(filename string, file *ast.File) {
+	platforms := pi.Files[filename]
+	add := func(key string) {
+		for _, platform := range platforms {
+			if !containsString(pi.Decls[key], platform) {
+				pi.Decls[key] = append(pi.Decls[key], platform)
+			}
+		}
+	}
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			add(funcDeclKey(d))
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if s, ok := spec.(*ast.TypeSpec); ok {
+					add(s.Name.Name)
+				}
+			}
+		}
+	}
+}
+
+// funcDeclKey returns the key of d in PlatformInfo.Decls.
+func funcDeclKey(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return d.Name.Name
+	}
+	typ := d.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.ParenExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr: // generic type
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + d.Name.Name
+		}
+		return d.Name.Name
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// declPlatforms returns the platforms the declaration decl is available
+// on, in the order of pi.Platforms, or nil if it is available on all.
+func (info *PageInfo) declPlatforms(decl ast.Node) []string {
+	pi := info.Platforms
+	if pi == nil || decl == nil {
+		return nil
+	}
+	var list []string
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		list = pi.Decls[funcDeclKey(d)]
+	case *ast.GenDecl:
+		if d.Tok == token.TYPE && len(d.Specs) > 0 {
+			if s, ok := d.Specs[0].(*ast.TypeSpec); ok {
+				list = pi.Decls[s.Name.Name]
+				break
+			}
+		}
+		if f := info.FSet.File(d.Pos()); f != nil {
+			list = pi.Files[pathpkg.Base(f.Name())]
+		}
+	}
+	if len(list) == 0 || len(list) == len(pi.Platforms) {
+		return nil
+	}
+	var sorted []string
+	for _, platform := range pi.Platforms {
+		if containsString(list, platform) {
+			sorted = append(sorted, platform)
+		}
+	}
+	return sorted
+}
+
+// platforms_htmlFunc returns badges naming the platforms decl is
+// available on, if it is not available on all evaluated platforms.
+func platforms_htmlFunc(info *PageInfo, decl ast.Node) string {
+	var buf strings.Builder
+	for _, platform := range info.declPlatforms(decl) {
+		buf.WriteString(`<span class="badge badge-secondary" title="Available on `)
+		template.HTMLEscape(&buf, []byte(platform))
+		buf.WriteString(`">`)
+		template.HTMLEscape(&buf, []byte(platform))
+		buf.WriteString(`</span> `)
+	}
+	return buf.String()
+}
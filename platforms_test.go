@@ -0,0 +1,95 @@
+package godoc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestAllPlatforms(t *testing.T) {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/p/p.go":        "// Package p is a package.\npackage p\n\nconst C = 1\n\ntype T int\n\nfunc F() {}\n",
+		"src/p/p_linux.go":  "package p\n\nconst L = 1\n\nfunc Linux() {}\n\nfunc (T) M() {}\n",
+		"src/p/p_darwin.go": "package p\n\nfunc Darwin() {}\n\nfunc (T) M() {}\n",
+	}))
+	c.IndexEnabled = false
+	c.Platforms = []string{"linux/amd64", "darwin/amd64"}
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, err := c.syntaxAnalysis("/src/p", "p", AllPlatforms, BuildConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Platforms == nil {
+		t.Fatal("no platform information")
+	}
+	if want := c.Platforms; !reflect.DeepEqual(pkg.Platforms.Platforms, want) {
+		t.Errorf("platforms = %v; want %v", pkg.Platforms.Platforms, want)
+	}
+
+	info := &PageInfo{FSet: pkg.FSet, Platforms: pkg.Platforms}
+	want := map[string][]string{
+		"F":      nil,
+		"Linux":  {"linux/amd64"},
+		"Darwin": {"darwin/amd64"},
+	}
+	funcs := make(map[string]bool)
+	for _, f := range pkg.DocPackage.Funcs {
+		funcs[f.Name] = true
+		if got := info.declPlatforms(f.Decl); !reflect.DeepEqual(got, want[f.Name]) {
+			t.Errorf("platforms of %s = %v; want %v", f.Name, got, want[f.Name])
+		}
+	}
+	for name := range want {
+		if !funcs[name] {
+			t.Errorf("func %s missing from the merged documentation", name)
+		}
+	}
+
+	for _, typ := range pkg.DocPackage.Types {
+		if got := info.declPlatforms(typ.Decl); got != nil {
+			t.Errorf("platforms of type %s = %v; want all", typ.Name, got)
+		}
+		for _, m := range typ.Methods {
+			if got := info.declPlatforms(m.Decl); got != nil {
+				t.Errorf("platforms of method %s = %v; want all", m.Name, got)
+			}
+		}
+	}
+	for _, v := range pkg.DocPackage.Consts {
+		var want []string
+		if v.Names[0] == "L" {
+			want = []string{"linux/amd64"}
+		}
+		if got := info.declPlatforms(v.Decl); !reflect.DeepEqual(got, want) {
+			t.Errorf("platforms of const %s = %v; want %v", v.Names[0], got, want)
+		}
+	}
+}
+
+func TestAllPlatformsFallback(t *testing.T) {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/p/p_plan9.go": "// Package p is for Plan 9 only.\npackage p\n\nfunc F() {}\n",
+	}))
+	c.IndexEnabled = false
+	c.Platforms = []string{"linux/amd64", "darwin/amd64"}
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	// No platform of c has a package: the one of the build
+	// configuration is documented.
+	pkg, err := c.syntaxAnalysis("/src/p", "p", AllPlatforms, BuildConfig{GOOS: "plan9", GOARCH: "amd64"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Platforms != nil {
+		t.Errorf("platforms = %v; want none", pkg.Platforms.Platforms)
+	}
+	if pkg.DocPackage == nil || len(pkg.DocPackage.Funcs) != 1 {
+		t.Errorf("package of plan9/amd64 not documented")
+	}
+}
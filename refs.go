@@ -0,0 +1,331 @@
+// This file contains the code to record and look up references
+// to the exported package-level identifiers of a corpus.
+//
+// References are recorded by the Indexer, without type information:
+// - a selector pkg.Name, where pkg is the name of an import of the
+//   file, refers to Name in the imported package
+// - an identifier in a use position which is not resolved to a
+//   local declaration refers to the package-level identifier of
+//   the same name of its own package, if there is one; since the
+//   package-level declarations are only known after all files of
+//   a package were indexed, these references are resolved at the
+//   end of each directory
+// Dot imports, methods and fields are not covered.
+
+package godoc
+
+import (
+	"fmt"
+	"go/ast"
+	"net/http"
+	pathpkg "path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/miclle/godoc/vfs"
+)
+
+// A Ref is a reference to an exported package-level identifier.
+type Ref struct {
+	File   *File
+	Line   int
+	Offset int // byte offset of the referring identifier in the file
+}
+
+// A pendingRef is a use of a package-local identifier, which is a
+// reference if the package declares the identifier at package level.
+type pendingRef struct {
+	name string
+	ref  Ref
+}
+
+// fileImports returns the package names of the imports of file,
+// mapped to the import paths.
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := importName(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name != "_" && name != "." {
+			imports[name] = path
+		}
+	}
+	return imports
+}
+
+// importName guesses the package name of the package with the given
+// import path, following the usual conventions: the last path element,
+// ignoring major version elements (example.com/mod/v2) and suffixes
+// (gopkg.in/yaml.v2) and a "go-" prefix.
+func importName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		name = name[:i]
+	}
+	return strings.TrimPrefix(name, "go-")
+}
+
+// importPathOf returns the import path of the package referred to by
+// the expression x of a selector expression in the current file, or "".
+func (x *Indexer) importPathOf(e ast.Expr) string {
+	id, _ := e.(*ast.Ident)
+	if id == nil || id.Obj != nil {
+		// not a package name, or shadowed by a local declaration
+		return ""
+	}
+	return x.imports[id.Name]
+}
+
+func (x *Indexer) newRef(id *ast.Ident) Ref {
+	pos := x.fset.Position(id.Pos())
+	return Ref{x.current, pos.Line, pos.Offset}
+}
+
+// addRef records a reference to name in the package with the given import path.
+func (x *Indexer) addRef(importPath, name string, ref Ref) {
+	if !ast.IsExported(name) {
+		return
+	}
+	m := x.refs[importPath]
+	if m == nil {
+		m = make(map[string][]Ref)
+		x.refs[importPath] = m
+	}
+	m[name] = append(m[name], ref)
+}
+
+// visitLocalRef records the use id as a possible reference to a
+// package-level identifier of the current package.
+func (x *Indexer) visitLocalRef(id *ast.Ident) {
+	if !ast.IsExported(id.Name) || isExternalTestPackage(x.current.Pak) {
+		return
+	}
+	// unresolved identifiers may be declared in another file
+	// of the package, or they are predeclared
+	if id.Obj == nil || x.scope.Lookup(id.Name) == id.Obj {
+		x.pending = append(x.pending, pendingRef{id.Name, x.newRef(id)})
+	}
+}
+
+// declare records the package-level declarations of file, which
+// belongs to the current package.
+func (x *Indexer) declare(file *ast.File) {
+	pak := x.current.Pak
+	if isExternalTestPackage(pak) {
+		return
+	}
+	importPath := srcImportPath(pak.Path)
+	m := x.refs[importPath]
+	if m == nil {
+		m = make(map[string][]Ref)
+		x.refs[importPath] = m
+	}
+	for name := range file.Scope.Objects {
+		if ast.IsExported(name) {
+			x.declared[name] = true
+			if _, ok := m[name]; !ok {
+				m[name] = nil // list declared identifiers without references, too
+			}
+		}
+	}
+}
+
+// resolveRefs resolves the pending references of the directory dir.
+func (x *Indexer) resolveRefs(dir *Directory) {
+	importPath := srcImportPath(dir.Path)
+	for _, p := range x.pending {
+		if x.declared[p.name] {
+			x.addRef(importPath, p.name, p.ref)
+		}
+	}
+	x.pending = x.pending[:0]
+	x.declared = make(map[string]bool)
+}
+
+func isExternalTestPackage(pak *Pak) bool {
+	return strings.HasSuffix(pak.Name, "_test")
+}
+
+type refsByPosition []Ref
+
+func (s refsByPosition) Len() int      { return len(s) }
+func (s refsByPosition) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s refsByPosition) Less(i, j int) bool {
+	a, b := s[i].File, s[j].File
+	if a.Pak.Path != b.Pak.Path {
+		return a.Pak.Path < b.Pak.Path
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return s[i].Offset < s[j].Offset
+}
+
+// Refs returns the references to the exported package-level
+// identifiers of the package with the given import path, by
+// identifier, sorted by file and position. Declared identifiers
+// without references are present with an empty list.
+func (x *Index) Refs(importPath string) map[string][]Ref {
+	return x.refs[importPath]
+}
+
+// ----------------------------------------------------------------------------
+// Presentation
+
+// A RefsResult holds the references to the exported
+// identifiers of a package.
+type RefsResult struct {
+	ImportPath string
+	Alert      string // error or warning message
+	Idents     []*IdentRefs
+	Query      string // query string of the package link; see queryString
+}
+
+// IdentRefs holds the references to an identifier.
+type IdentRefs struct {
+	Name     string
+	Found    int // number of references found
+	Packages []*PackageRefs
+}
+
+// Complete reports whether all references are listed.
+func (r *IdentRefs) Complete() bool {
+	n := 0
+	for _, p := range r.Packages {
+		for _, f := range p.Files {
+			n += len(f.Lines)
+		}
+	}
+	return n == r.Found
+}
+
+// PackageRefs holds the references in the files of a package directory.
+type PackageRefs struct {
+	Path  string // directory path
+	Files []*FileRefs
+}
+
+// FileRefs holds the references in a file.
+type FileRefs struct {
+	Path  string
+	Lines []RefLine
+}
+
+// A RefLine is a source line containing a reference.
+type RefLine struct {
+	Line      int
+	Low, High int    // byte offsets of the referring identifier in the file
+	Before    string // line text before the identifier
+	Ident     string
+	After     string // line text after the identifier
+}
+
+// LookupRefs returns the references to the exported identifiers of the
+// package with the given import path. At most c.MaxResults references
+// are reported per identifier, if c.MaxResults > 0.
+func (c *Corpus) LookupRefs(importPath string) *RefsResult {
+	result := &RefsResult{ImportPath: importPath}
+
+	index, _ := c.CurrentIndex()
+	switch {
+	case !c.IndexEnabled:
+		result.Alert = "Search index disabled: no references available"
+		return result
+	case index == nil:
+		result.Alert = "Indexing in progress: references are not available yet"
+		return result
+	}
+
+	refs := index.Refs(importPath)
+	if refs == nil {
+		result.Alert = fmt.Sprintf("No package %s in the index", importPath)
+		return result
+	}
+	var names []string
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make(map[string][]string) // file path -> source lines
+	for _, name := range names {
+		list := refs[name]
+		ir := &IdentRefs{Name: name, Found: len(list)}
+		if c.MaxResults > 0 && len(list) > c.MaxResults {
+			list = list[:c.MaxResults]
+		}
+		var pr *PackageRefs
+		var fr *FileRefs
+		for _, ref := range list {
+			if pr == nil || pr.Path != ref.File.Pak.Path {
+				pr = &PackageRefs{Path: ref.File.Pak.Path}
+				ir.Packages = append(ir.Packages, pr)
+				fr = nil
+			}
+			path := ref.File.Path()
+			if fr == nil || fr.Path != path {
+				fr = &FileRefs{Path: path}
+				pr.Files = append(pr.Files, fr)
+			}
+			src, ok := lines[path]
+			if !ok {
+				if data, err := vfs.ReadFile(c.fs, path); err == nil {
+					src = strings.Split(string(data), "\n")
+				}
+				lines[path] = src
+			}
+			fr.Lines = append(fr.Lines, newRefLine(src, ref, name))
+		}
+		result.Idents = append(result.Idents, ir)
+	}
+	return result
+}
+
+// newRefLine returns the RefLine for ref, a reference to name,
+// given the source lines of the file.
+func newRefLine(src []string, ref Ref, name string) RefLine {
+	l := RefLine{Line: ref.Line, Low: ref.Offset, High: ref.Offset + len(name), Ident: name}
+	if ref.Line < 1 || ref.Line > len(src) {
+		return l
+	}
+	text := src[ref.Line-1]
+	// the column of the identifier, from the offset of the line
+	lineStart := 0
+	for _, s := range src[:ref.Line-1] {
+		lineStart += len(s) + 1
+	}
+	col := ref.Offset - lineStart
+	if col < 0 || col+len(name) > len(text) || text[col:col+len(name)] != name {
+		// file changed since indexing
+		l.Before = text
+		l.Ident = ""
+		return l
+	}
+	l.Before = text[:col]
+	l.After = text[col+len(name):]
+	return l
+}
+
+// HandleRefs serves the references to the exported identifiers of
+// the package with the import path given by the URL path /refs/<importpath>.
+func (p *Presentation) HandleRefs(w http.ResponseWriter, r *http.Request) {
+	importPath := pathpkg.Clean(strings.TrimPrefix(r.URL.Path, "/refs/"))
+	result := p.Corpus.LookupRefs(importPath)
+	result.Query = queryString(p.GetPageInfoMode(r), getBuildConfig(r))
+	p.ServePage(w, Page{
+		Title:    "References to package " + importPath,
+		Tabtitle: "References to " + importPath,
+		Body:     applyTemplate(p.RefsHTML, "refsHTML", result),
+	})
+}
@@ -0,0 +1,144 @@
+package godoc
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func newRefsTestCorpus(t *testing.T) *Corpus {
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/foo/foo.go": `package foo
+
+type Bar struct{ Name string }
+
+func NewBar() *Bar { return &Bar{Name: "bar"} }
+
+func (b *Bar) Name2() string { return b.Name }
+`,
+		"src/foo/util.go": `package foo
+
+var Default = NewBar()
+
+func Unused() {}
+
+func shadow() {
+	NewBar := 1
+	_ = NewBar
+}
+`,
+		"src/foo/foo_test.go": `package foo_test
+
+import "foo"
+
+var x = foo.NewBar()
+`,
+		"src/baz/baz.go": `package baz
+
+import (
+	f "foo"
+	"fmt"
+)
+
+func Baz() { fmt.Println(f.NewBar().Name, f.Default) }
+`,
+	}))
+	c.IndexEnabled = true
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	c.WaitIndex()
+	return c
+}
+
+func TestIndexRefs(t *testing.T) {
+	c := newRefsTestCorpus(t)
+	index, _ := c.CurrentIndex()
+	if index == nil {
+		t.Fatal("no index built")
+	}
+
+	type ref struct {
+		file string
+		line int
+	}
+	got := make(map[string][]ref)
+	for name, refs := range index.Refs("foo") {
+		got[name] = []ref{}
+		for _, r := range refs {
+			got[name] = append(got[name], ref{r.File.Path(), r.Line})
+		}
+	}
+	want := map[string][]ref{
+		"Bar": {
+			{"/src/foo/foo.go", 5},
+			{"/src/foo/foo.go", 5},
+			{"/src/foo/foo.go", 7},
+		},
+		"Default": {
+			{"/src/baz/baz.go", 8},
+		},
+		"NewBar": {
+			{"/src/baz/baz.go", 8},
+			{"/src/foo/foo_test.go", 5},
+			{"/src/foo/util.go", 3},
+		},
+		"Unused": {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Refs(foo) = %v; want %v", got, want)
+	}
+	if refs := index.Refs("fmt"); len(refs["Println"]) != 1 {
+		t.Errorf("Refs(fmt)[Println] = %v; want 1 reference", refs["Println"])
+	}
+}
+
+func TestLookupRefs(t *testing.T) {
+	c := newRefsTestCorpus(t)
+	c.MaxResults = 2
+
+	result := c.LookupRefs("foo")
+	if result.Alert != "" {
+		t.Fatalf("LookupRefs(foo) alert: %s", result.Alert)
+	}
+	var bar *IdentRefs
+	for _, ir := range result.Idents {
+		if ir.Name == "NewBar" {
+			bar = ir
+		}
+	}
+	if bar == nil {
+		t.Fatal("no references to NewBar")
+	}
+	if bar.Found != 3 || bar.Complete() {
+		t.Errorf("NewBar: found %d, complete %v; want 3, false", bar.Found, bar.Complete())
+	}
+	line := bar.Packages[0].Files[0].Lines[0]
+	want := RefLine{Line: 8, Low: line.Low, High: line.Low + 6, Before: "func Baz() { fmt.Println(f.", Ident: "NewBar", After: "().Name, f.Default) }"}
+	if line != want {
+		t.Errorf("NewBar line = %+v; want %+v", line, want)
+	}
+
+	if result := c.LookupRefs("nonexistent"); result.Alert == "" {
+		t.Error("LookupRefs(nonexistent) returned no alert")
+	}
+}
+
+func TestIndexFileRefs(t *testing.T) {
+	c := newRefsTestCorpus(t)
+	var buf bytes.Buffer
+	if _, err := c.WriteIndexTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	c2 := NewCorpus(mapfs.New(nil))
+	if err := c2.ReadIndexFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	index1, _ := c.CurrentIndex()
+	index2, _ := c2.CurrentIndex()
+	if got, want := index2.Refs("foo"), index1.Refs("foo"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Refs(foo) = %v; want %v", got, want)
+	}
+}
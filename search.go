@@ -0,0 +1,92 @@
+package godoc
+
+import (
+	"fmt"
+	"go/token"
+	"net/http"
+	"strings"
+)
+
+// A SearchResult holds the result of a search query.
+type SearchResult struct {
+	Query string
+	Alert string // error or warning message
+
+	// identifier matches
+	Hit []*SpotRun // identifier spots grouped by SpotKind; nil if no hits
+
+	// textual matches
+	Found    int         // number of textual occurrences found
+	Textual  []FileLines // textual matches of Query
+	Complete bool        // true if all textual occurrences of Query are reported
+}
+
+// Lookup looks up query in the corpus search index. The query is
+// either an identifier, a package-qualified identifier of the form
+// pkg.Name, or arbitrary text for the full text index.
+func (c *Corpus) Lookup(query string) *SearchResult {
+	result := &SearchResult{Query: query}
+
+	index, _ := c.CurrentIndex()
+	switch {
+	case !c.IndexEnabled:
+		result.Alert = "Search index disabled: no results available"
+		return result
+	case index == nil:
+		result.Alert = "Indexing in progress: result may be inaccurate"
+		return result
+	case query == "":
+		return result
+	}
+
+	// identifier search
+	if pkg, name, ok := splitQualifiedIdent(query); ok {
+		result.Hit = index.LookupWord(pkg, name, c.MaxResults)
+	}
+
+	// full text search
+	if c.IndexFullText {
+		result.Found, result.Textual, result.Complete = index.LookupText(query, c.MaxResults)
+	}
+
+	return result
+}
+
+// splitQualifiedIdent splits query into an optional package
+// name or path and an identifier. It reports false if query
+// is not of the form ident or pkg.ident.
+func splitQualifiedIdent(query string) (pkg, name string, ok bool) {
+	name = query
+	if i := strings.LastIndex(query, "."); i >= 0 {
+		pkg, name = query[:i], query[i+1:]
+		if pkg == "" {
+			return "", "", false
+		}
+	}
+	return pkg, name, token.Lookup(name) == token.IDENT && isIdentifier(name)
+}
+
+func isIdentifier(s string) bool {
+	return s != "" && len(scanIdentifier([]byte(s))) == len(s)
+}
+
+// HandleSearch serves the results of the search query given
+// by the "q" form value.
+func (p *Presentation) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.FormValue("q"))
+	result := p.Corpus.Lookup(query)
+
+	var title string
+	if result.Hit != nil || len(result.Textual) > 0 {
+		title = fmt.Sprintf(`Results for query %q`, query)
+	} else {
+		title = fmt.Sprintf(`No results found for query %q`, query)
+	}
+
+	p.ServePage(w, Page{
+		Title:    title,
+		Tabtitle: query,
+		Query:    query,
+		Body:     applyTemplate(p.SearchHTML, "searchHTML", result),
+	})
+}
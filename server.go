@@ -5,17 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
-	"go/build"
 	"go/doc"
 	"go/token"
 	"html"
 	"io"
-	"io/ioutil"
 	"log"
 	"net/http"
-	"os"
 	"path"
-	"path/filepath"
 	"sort"
 	"strings"
 	"text/template"
@@ -47,155 +43,52 @@ func (handler *handlerServer) registerWithMux(mux *http.ServeMux) {
 // is extracted from the AST. If there is no corresponding package in the
 // directory, PageInfo.PAst and PageInfo.DocPackage are nil. If there are no sub-
 // directories, PageInfo.Directory is nil. If an error occurred, PageInfo.Err is
-// set to the respective error but the error is not logged.
+// set to the respective error but the error is not logged. The package
+// information is computed as by Corpus.SyntaxAnalysis for the build
+// configuration cfg, and cached.
 //
-func (handler *handlerServer) GetPageInfo(abspath, relpath string, mode PageInfoMode, goos, goarch string) *PageInfo {
+func (handler *handlerServer) GetPageInfo(abspath, relpath string, mode PageInfoMode, cfg BuildConfig) *PageInfo {
 
 	pageInfo := &PageInfo{
 		Dirname: abspath,
 		Mode:    mode,
+		Build:   cfg,
 	}
 
-	// Restrict to the package files that would be used when building
-	// the package on this system.  This makes sure that if there are
-	// separate implementations for, say, Windows vs Unix, we don't
-	// jumble them all together.
-	// Note: If goos/goarch aren't set, the current binary's GOOS/GOARCH
-	// are used.
-	ctxt := build.Default
-	ctxt.IsAbsPath = path.IsAbs
-	ctxt.IsDir = func(path string) bool {
-		fi, err := handler.corpus.fs.Stat(filepath.ToSlash(path))
-		return err == nil && fi.IsDir()
-	}
-	ctxt.ReadDir = func(dir string) ([]os.FileInfo, error) {
-		f, err := handler.corpus.fs.ReadDir(filepath.ToSlash(dir))
-		filtered := make([]os.FileInfo, 0, len(f))
-		for _, i := range f {
-			if mode&NoFiltering != 0 || i.Name() != "internal" {
-				filtered = append(filtered, i)
-			}
-		}
-		return filtered, err
-	}
-	ctxt.OpenFile = func(name string) (r io.ReadCloser, err error) {
-		data, err := vfs.ReadFile(handler.corpus.fs, filepath.ToSlash(name))
-		if err != nil {
-			return nil, err
-		}
-		return ioutil.NopCloser(bytes.NewReader(data)), nil
-	}
-
-	// Make the syscall/js package always visible by default.
-	// It defaults to the host's GOOS/GOARCH, and golang.org's
-	// linux/amd64 means the wasm syscall/js package was blank.
-	// And you can't run godoc on js/wasm anyway, so host defaults
-	// don't make sense here.
-	if goos == "" && goarch == "" && relpath == "syscall/js" {
-		goos, goarch = "js", "wasm"
-	}
-	if goos != "" {
-		ctxt.GOOS = goos
-	}
-	if goarch != "" {
-		ctxt.GOARCH = goarch
-	}
-
-	pkginfo, err := ctxt.ImportDir(abspath, 0)
-	// continue if there are no Go source files; we still want the directory info
-	if _, nogo := err.(*build.NoGoError); err != nil && !nogo {
+	pkg, err := handler.corpus.cachedSyntaxAnalysis(abspath, relpath, mode, cfg)
+	if err != nil {
 		pageInfo.Err = err
 		return pageInfo
 	}
-
-	// collect package files
-	pkgname := pkginfo.Name
-	pkgfiles := append(pkginfo.GoFiles, pkginfo.CgoFiles...)
-	if len(pkgfiles) == 0 {
-		// Commands written in C have no .go files in the build.
-		// Instead, documentation may be found in an ignored file.
-		// The file may be ignored via an explicit +build ignore
-		// constraint (recommended), or by defining the package
-		// documentation (historic).
-		pkgname = "main" // assume package main since pkginfo.Name == ""
-		pkgfiles = pkginfo.IgnoredGoFiles
-	}
-
-	// get package information, if any
-	if len(pkgfiles) > 0 {
-		// build package AST
-		fset := token.NewFileSet()
-		files, err := handler.corpus.parseFiles(fset, relpath, abspath, pkgfiles)
-		if err != nil {
-			pageInfo.Err = err
-			return pageInfo
-		}
-
-		// ignore any errors - they are due to unresolved identifiers
-		pkg, _ := ast.NewPackage(fset, files, poorMansImporter, nil)
-
-		// extract package documentation
-		pageInfo.FSet = fset
-		if mode&ShowSource == 0 {
-			// show extracted documentation
-			var m doc.Mode
-			if mode&NoFiltering != 0 {
-				m |= doc.AllDecls
-			}
-			if mode&AllMethods != 0 {
-				m |= doc.AllMethods
-			}
-
-			importPath := path.Clean(relpath) // no trailing '/' in importpath
-			pageInfo.DocPackage = doc.New(pkg, importPath, m)
-			if mode&NoTypeAssoc != 0 {
-				for _, t := range pageInfo.DocPackage.Types {
-					pageInfo.DocPackage.Consts = append(pageInfo.DocPackage.Consts, t.Consts...)
-					pageInfo.DocPackage.Vars = append(pageInfo.DocPackage.Vars, t.Vars...)
-					pageInfo.DocPackage.Funcs = append(pageInfo.DocPackage.Funcs, t.Funcs...)
-					t.Consts = nil
-					t.Vars = nil
-					t.Funcs = nil
-				}
-				// for now we cannot easily sort consts and vars since
-				// go/doc.Value doesn't export the order information
-				sort.Sort(funcsByName(pageInfo.DocPackage.Funcs))
-			}
-
-			// collect examples
-			testfiles := append(pkginfo.TestGoFiles, pkginfo.XTestGoFiles...)
-			files, err = handler.corpus.parseFiles(fset, relpath, abspath, testfiles)
-			if err != nil {
-				log.Println("parsing examples:", err)
-			}
-			pageInfo.Examples = collectExamples(handler.corpus, pkg, files)
-
-			// collect any notes that we want to show
-			if pageInfo.DocPackage.Notes != nil {
-				// could regexp.Compile only once per godoc, but probably not worth it
-				if rx := handler.presentation.NotesRx; rx != nil {
-					for m, n := range pageInfo.DocPackage.Notes {
-						if rx.MatchString(m) {
-							if pageInfo.Notes == nil {
-								pageInfo.Notes = make(map[string][]*doc.Note)
-							}
-							pageInfo.Notes[m] = n
-						}
-					}
+	pageInfo.FSet = pkg.FSet
+	pageInfo.DocPackage = pkg.DocPackage
+	pageInfo.Examples = pkg.Examples
+	pageInfo.PAst = pkg.PAst
+	pageInfo.IsMain = pkg.IsMain
+	pageInfo.TypesPkg = pkg.TypesPkg
+	pageInfo.TypesInfo = pkg.TypesInfo
+	pageInfo.Platforms = pkg.Platforms
+	pageInfo.Deprecations = pkg.Deprecations
+	if pkg.DocPackage != nil {
+		pageInfo.Implements = handler.corpus.Implementations(pkg.ImportPath)
+	}
+	pageInfo.Imports = pkg.Imports
+	pageInfo.ImportedBy = handler.corpus.Importers(srcImportPath(abspath)) // pkg may be cached
+	if mode&ShowImporters != 0 {
+		pageInfo.Importers = handler.corpus.TransitiveImporters(srcImportPath(abspath))
+	}
+
+	// collect any notes that we want to show
+	// could regexp.Compile only once per godoc, but probably not worth it
+	if rx := handler.presentation.NotesRx; rx != nil {
+		for m, n := range pkg.Notes {
+			if rx.MatchString(m) {
+				if pageInfo.Notes == nil {
+					pageInfo.Notes = make(map[string][]*doc.Note)
 				}
+				pageInfo.Notes[m] = n
 			}
-
-		} else {
-			// show source code
-			// TODO(gri) Consider eliminating export filtering in this mode,
-			//           or perhaps eliminating the mode altogether.
-			if mode&NoFiltering == 0 {
-				packageExports(fset, pkg)
-			}
-			pageInfo.PAst = files
 		}
-
-		pageInfo.IsMain = pkgname == "main"
 	}
 
 	directory, timestamp := handler.corpus.Directory(abspath)
@@ -233,13 +126,26 @@ func (handler *handlerServer) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		// since it's not helpful for this fake package (see issue 6645).
 		mode |= NoFiltering | NoTypeAssoc
 	}
-	pageInfo := handler.GetPageInfo(abspath, relpath, mode, r.FormValue("GOOS"), r.FormValue("GOARCH"))
+	importPath := relpath
+	modVersions := handler.corpus.moduleVersions(relpath)
+	if modVersions != nil && modVersions.Version != "" {
+		importPath = modVersions.ImportPath() // not qualified with the version
+	}
+	// The Markdown mode doesn't change the package information.
+	pageInfo := handler.GetPageInfo(abspath, importPath, mode&^Markdown, getBuildConfig(r))
+	pageInfo.ModuleVersions = modVersions
+	pageInfo.Vendored = handler.corpus.vendoredModule(importPath)
 	if pageInfo.Err != nil {
 		log.Print(pageInfo.Err)
 		handler.presentation.ServeError(w, r, relpath, pageInfo.Err)
 		return
 	}
 
+	if mode&Markdown != 0 && handler.presentation.PackageMarkdown != nil {
+		handler.presentation.ServeMarkdown(w, tidyMarkdown(applyTemplate(handler.presentation.PackageMarkdown, "packageMarkdown", pageInfo)))
+		return
+	}
+
 	var tabtitle, title, subtitle string
 	switch {
 	case pageInfo.PAst != nil:
@@ -291,6 +197,7 @@ func (handler *handlerServer) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	// ------------------------------------------------------------------
 
 	if pageInfo.Dirname == "/src" {
+		pageInfo.Workspace, pageInfo.Directory = handler.corpus.workspaceModules(pageInfo.Directory)
 		page.Body = applyTemplate(handler.presentation.PackageRootHTML, "packageRootHTML", pageInfo)
 	} else {
 		page.Body = applyTemplate(handler.presentation.PackageHTML, "packageHTML", pageInfo)
@@ -310,19 +217,27 @@ type PageInfoMode uint
 const (
 	PageInfoModeQueryString = "m" // query string where PageInfoMode is stored
 
-	NoFiltering PageInfoMode = 1 << iota // do not filter exports
-	AllMethods                           // show all embedded methods
-	ShowSource                           // show source code, do not extract documentation
-	FlatDir                              // show directory in a flat (non-indented) manner
-	NoTypeAssoc                          // don't associate consts, vars, and factory functions with types (not exposed via ?m= query parameter, used for package builtin, see issue 6645)
+	NoFiltering   PageInfoMode = 1 << iota // do not filter exports
+	AllMethods                             // show all embedded methods
+	ShowSource                             // show source code, do not extract documentation
+	FlatDir                                // show directory in a flat (non-indented) manner
+	NoTypeAssoc                            // don't associate consts, vars, and factory functions with types (not exposed via ?m= query parameter, used for package builtin, see issue 6645)
+	TypeCheck                              // type-check the package to link identifiers to their declarations
+	ShowImporters                          // list the packages importing the package, directly or indirectly
+	AllPlatforms                           // show the declarations for all platforms of Corpus.Platforms
+	Markdown                               // render the documentation as Markdown
 )
 
 // modeNames defines names for each PageInfoMode flag.
 var modeNames = map[string]PageInfoMode{
-	"all":     NoFiltering,
-	"methods": AllMethods,
-	"src":     ShowSource,
-	"flat":    FlatDir,
+	"all":       NoFiltering,
+	"methods":   AllMethods,
+	"src":       ShowSource,
+	"flat":      FlatDir,
+	"types":     TypeCheck,
+	"importers": ShowImporters,
+	"platforms": AllPlatforms,
+	"md":        Markdown,
 }
 
 // generate a query string for persisting PageInfoMode between pages.
@@ -746,6 +661,24 @@ func (p *Presentation) serveFile(w http.ResponseWriter, r *http.Request) {
 	p.fileServer.ServeHTTP(w, r)
 }
 
+// ServesRawFile reports whether ServeFile serves the file of the URL path
+// raw, rather than as an HTML page: the files which are neither Go, HTML,
+// nor text files, such as images, style sheets and scripts.
+func (p *Presentation) ServesRawFile(urlPath string) bool {
+	if m := p.Corpus.MetadataFor(urlPath); m != nil {
+		return false
+	}
+	switch path.Ext(urlPath) {
+	case ".html", ".go":
+		return false
+	}
+	fi, err := p.Corpus.fs.Lstat(urlPath)
+	if err != nil || fi.IsDir() {
+		return false
+	}
+	return !util.IsTextFile(p.Corpus.fs, urlPath)
+}
+
 func (p *Presentation) ServeText(w http.ResponseWriter, text []byte) {
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.Write(text)
@@ -1,6 +1,7 @@
 package godoc
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/miclle/godoc/vfs/mapfs"
@@ -23,7 +24,7 @@ package main`}))
 		},
 		corpus: c,
 	}
-	pInfo := srv.GetPageInfo("/src/"+packagePath, packagePath, NoFiltering, "linux", "amd64")
+	pInfo := srv.GetPageInfo("/src/"+packagePath, packagePath, NoFiltering, BuildConfig{GOOS: "linux", GOARCH: "amd64"})
 
 	if pInfo.DocPackage == nil {
 		t.Error("pInfo.DocPackage = nil; want non-nil.")
@@ -58,8 +59,79 @@ func F()
 		presentation: &Presentation{Corpus: c},
 		corpus:       c,
 	}
-	pInfo := srv.GetPageInfo("/src/"+packagePath, packagePath, 0, "linux", "amd64")
+	pInfo := srv.GetPageInfo("/src/"+packagePath, packagePath, 0, BuildConfig{GOOS: "linux", GOARCH: "amd64"})
 	if got, want := pInfo.DocPackage.Funcs[0].Doc, "F doc //line 1 should appear\nline 2 should appear\n"; got != want {
 		t.Errorf("pInfo.DocPackage.Funcs[0].Doc = %q; want %q", got, want)
 	}
 }
+
+func TestSyntaxAnalysis(t *testing.T) {
+	const packagePath = "example.com/p"
+	c := NewCorpus(mapfs.New(map[string]string{
+		"src/" + packagePath + "/p.go": `// Package p is a package.
+package p // import "example.com/p"
+
+import "fmt"
+
+// C is a constant.
+const C = 1
+
+// T is a type.
+type T struct{}
+
+// NewT returns a T.
+func NewT() *T { return nil }
+
+// F prints.
+func F() { fmt.Println() }
+
+// BUG(x): F is not useful.
+`,
+		"src/" + packagePath + "/p_windows.go": `package p
+
+import "os"
+
+// W is only on Windows.
+func W() { os.Exit(0) }
+`,
+		"src/" + packagePath + "/p_test.go": `package p
+
+func ExampleF() {
+	F()
+}
+`}))
+
+	pkg, err := c.SyntaxAnalysis("/src/"+packagePath, 0, "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.ImportPath != packagePath || pkg.ImportComment != packagePath || pkg.Name != "p" || pkg.IsMain {
+		t.Errorf("got import path %q, import comment %q, name %q, main %v", pkg.ImportPath, pkg.ImportComment, pkg.Name, pkg.IsMain)
+	}
+	if pkg.Doc != "Package p is a package.\n" {
+		t.Errorf("Doc = %q", pkg.Doc)
+	}
+	if got := fmt.Sprint(pkg.Filenames, pkg.Imports); got != "[p.go] [fmt]" {
+		t.Errorf("Filenames, Imports = %s; want [p.go] [fmt]", got)
+	}
+	if len(pkg.Consts) != 1 || len(pkg.Types) != 1 || len(pkg.Types[0].Funcs) != 1 || len(pkg.Funcs) != 1 || pkg.Funcs[0].Name != "F" {
+		t.Errorf("unexpected declarations: %d consts, %d types, %d funcs", len(pkg.Consts), len(pkg.Types), len(pkg.Funcs))
+	}
+	if len(pkg.Examples) != 1 || len(pkg.Notes["BUG"]) != 1 {
+		t.Errorf("got %d examples, %d bugs; want 1, 1", len(pkg.Examples), len(pkg.Notes["BUG"]))
+	}
+	if pkg.DocPackage == nil || pkg.FSet == nil || pkg.PAst != nil {
+		t.Errorf("got DocPackage %v, FSet %v, PAst %v", pkg.DocPackage, pkg.FSet, pkg.PAst)
+	}
+
+	pkg, err = c.SyntaxAnalysis("/src/"+packagePath, ShowSource, "windows", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(pkg.Filenames, pkg.Imports); got != "[p.go p_windows.go] [fmt os]" {
+		t.Errorf("Filenames, Imports = %s; want [p.go p_windows.go] [fmt os]", got)
+	}
+	if len(pkg.PAst) != 2 || pkg.DocPackage != nil || pkg.Doc != "Package p is a package.\n" {
+		t.Errorf("got %d ASTs, DocPackage %v, Doc %q", len(pkg.PAst), pkg.DocPackage, pkg.Doc)
+	}
+}
@@ -45,6 +45,13 @@ var files = []string{
 	"sidebar.html",
 	"packageroot.html",
 	"package.html",
+	"package.md",
+	"search.html",
+	"refs.html",
+	"deprecated.html",
+	"apidiff.html",
+	"modules.html",
+	"diagnostics.html",
 	"example.html",
 	"dirlist.html",
 	"error.html",
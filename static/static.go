@@ -1,4 +1,4 @@
-// Copyright 2020 The Go Authors. All rights reserved.
+// Copyright 2026 The Go Authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
@@ -49,13 +49,27 @@ var Files = map[string]string{
 
 	"style.css": "body\x20{\x0a\x20\x20margin:\x200;\x0a\x20\x20padding-top:\x2056px;\x0a\x20\x20color:\x20#222;\x20}\x0a\x0atextarea\x20{\x0a\x20\x20/*\x20Inherit\x20text\x20color\x20from\x20body\x20avoiding\x20illegible\x20text\x20in\x20the\x20case\x20where\x20the\x0a\x20\x09*\x20user\x20has\x20inverted\x20the\x20browsers\x20custom\x20text\x20and\x20background\x20colors.\x20*/\x0a\x20\x20color:\x20inherit;\x20}\x0a\x0apre,\x0acode\x20{\x0a\x20\x20font-family:\x20Menlo,\x20monospace;\x0a\x20\x20font-size:\x200.875rem;\x20}\x0a\x0apre\x20{\x0a\x20\x20line-height:\x201.4;\x0a\x20\x20overflow-x:\x20auto;\x20}\x0a\x0apre\x20.comment\x20{\x0a\x20\x20color:\x20#006600;\x20}\x0a\x0apre\x20.highlight,\x0apre\x20.highlight-comment,\x0apre\x20.selection-highlight,\x0apre\x20.selection-highlight-comment\x20{\x0a\x20\x20background:\x20#FFFF00;\x20}\x0a\x0apre\x20.selection,\x0apre\x20.selection-comment\x20{\x0a\x20\x20background:\x20#FF9632;\x20}\x0a\x0apre\x20.ln\x20{\x0a\x20\x20color:\x20#999;\x0a\x20\x20background:\x20#efefef;\x20}\x0a\x0a.ln\x20{\x0a\x20\x20-webkit-user-select:\x20none;\x0a\x20\x20-moz-user-select:\x20none;\x0a\x20\x20-ms-user-select:\x20none;\x0a\x20\x20user-select:\x20none;\x0a\x20\x20/*\x20Ensure\x208\x20characters\x20in\x20the\x20document\x20-\x20which\x20due\x20to\x20floating\x0a\x20\x20\x20*\x20point\x20rendering\x20issues,\x20might\x20have\x20a\x20width\x20of\x20less\x20than\x201\x20each\x20-\x20are\x208\x0a\x20\x20\x20*\x20characters\x20wide,\x20so\x20a\x20tab\x20in\x20the\x209th\x20position\x20indents\x20properly.\x20See\x0a\x20\x20\x20*\x20https://github.com/webcompat/web-bugs/issues/17530#issuecomment-402675091\x0a\x20\x20\x20*\x20for\x20more\x20information.\x20*/\x0a\x20\x20display:\x20inline-block;\x0a\x20\x20width:\x208ch;\x20}\x0a\x0aa,\x0a.exampleHeading\x20.text,\x0a.expandAll\x20{\x0a\x20\x20color:\x20#375EAB;\x0a\x20\x20text-decoration:\x20none;\x20}\x0a\x0aa:hover,\x0a.exampleHeading\x20.text:hover,\x0a.expandAll:hover\x20{\x0a\x20\x20text-decoration:\x20underline;\x20}\x0a\x0a.article\x20a\x20{\x0a\x20\x20text-decoration:\x20underline;\x20}\x0a\x0a.article\x20.title\x20a\x20{\x0a\x20\x20text-decoration:\x20none;\x20}\x0a\x0a.permalink\x20{\x0a\x20\x20display:\x20none;\x20}\x0a\x0a:hover\x20>\x20.permalink\x20{\x0a\x20\x20display:\x20inline;\x20}\x0a\x0ap,\x20li\x20{\x0a\x20\x20max-width:\x2050rem;\x0a\x20\x20word-wrap:\x20break-word;\x20}\x0a\x0ap,\x0apre,\x0aul,\x0aol\x20{\x0a\x20\x20margin:\x201.25rem;\x20}\x0a\x0apre\x20{\x0a\x20\x20background:\x20#EFEFEF;\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20border-radius:\x200.3125rem;\x20}\x0a\x0ah1,\x0ah2,\x0ah3,\x0ah4,\x0a.rootHeading\x20{\x0a\x20\x20margin:\x201.25rem\x200\x201.25rem;\x0a\x20\x20padding:\x200;\x0a\x20\x20color:\x20#375EAB;\x0a\x20\x20font-weight:\x20bold;\x20}\x0a\x0ah1\x20{\x0a\x20\x20font-size:\x201.75rem;\x0a\x20\x20line-height:\x201;\x20}\x0a\x0ah1\x20.text-muted\x20{\x0a\x20\x20color:\x20#777;\x20}\x0a\x0ah2\x20{\x0a\x20\x20font-size:\x201.25rem;\x0a\x20\x20background:\x20#E0EBF5;\x0a\x20\x20padding:\x200.5rem;\x0a\x20\x20line-height:\x201.25;\x0a\x20\x20font-weight:\x20normal;\x0a\x20\x20overflow:\x20auto;\x0a\x20\x20overflow-wrap:\x20break-word;\x20}\x0a\x0ah2\x20a\x20{\x0a\x20\x20font-weight:\x20bold;\x20}\x0a\x0ah3\x20{\x0a\x20\x20font-size:\x201.25rem;\x0a\x20\x20line-height:\x201.25;\x0a\x20\x20overflow:\x20auto;\x0a\x20\x20overflow-wrap:\x20break-word;\x20}\x0a\x0ah3,\x0ah4\x20{\x0a\x20\x20margin:\x201.25rem\x200.3125rem;\x20}\x0a\x0ah4\x20{\x0a\x20\x20font-size:\x201rem;\x20}\x0a\x0a.rootHeading\x20{\x0a\x20\x20font-size:\x201.25rem;\x0a\x20\x20margin:\x200;\x20}\x0a\x0ah2\x20>\x20span,\x0ah3\x20>\x20span\x20{\x0a\x20\x20float:\x20right;\x0a\x20\x20margin:\x200\x2025px\x200\x200;\x0a\x20\x20font-weight:\x20normal;\x0a\x20\x20color:\x20#5279C7;\x20}\x0a\x0adl\x20{\x0a\x20\x20margin:\x201.25rem;\x20}\x0a\x0add\x20{\x0a\x20\x20margin:\x200\x200\x200\x201.25rem;\x20}\x0a\x0adl,\x0add\x20{\x0a\x20\x20font-size:\x200.875rem;\x20}\x0a\x0adiv#nav\x20table\x20td\x20{\x0a\x20\x20vertical-align:\x20top;\x20}\x0a\x0a#pkg-index\x20h3\x20{\x0a\x20\x20font-size:\x201rem;\x20}\x0a\x0a.pkg-dir\x20{\x0a\x20\x20padding:\x200\x200.625rem;\x20}\x0a\x0a.pkg-dir\x20table\x20{\x0a\x20\x20border-collapse:\x20collapse;\x0a\x20\x20border-spacing:\x200;\x20}\x0a\x0a.pkg-name\x20{\x0a\x20\x20padding-right:\x200.625rem;\x20}\x0a\x0a.alert\x20{\x0a\x20\x20color:\x20#AA0000;\x20}\x0a\x0a.top-heading\x20{\x0a\x20\x20float:\x20left;\x0a\x20\x20padding:\x201.313rem\x200;\x0a\x20\x20font-size:\x201.25rem;\x0a\x20\x20font-weight:\x20normal;\x20}\x0a\x0a.top-heading\x20a\x20{\x0a\x20\x20color:\x20#222;\x0a\x20\x20text-decoration:\x20none;\x20}\x0a\x0a#pkg-examples\x20h3\x20{\x0a\x20\x20float:\x20left;\x20}\x0a\x0a#pkg-examples\x20dl\x20{\x0a\x20\x20clear:\x20both;\x20}\x0a\x0a.expandAll\x20{\x0a\x20\x20cursor:\x20pointer;\x0a\x20\x20float:\x20left;\x0a\x20\x20margin:\x201.25rem\x200;\x20}\x0a\x0adiv#plusone\x20{\x0a\x20\x20float:\x20right;\x0a\x20\x20clear:\x20right;\x0a\x20\x20margin-top:\x200.3125rem;\x20}\x0a\x0adiv#footer\x20{\x0a\x20\x20text-align:\x20center;\x0a\x20\x20color:\x20#666;\x0a\x20\x20font-size:\x200.875rem;\x0a\x20\x20margin:\x202.5rem\x200;\x20}\x0a\x0adiv#menu\x20>\x20a,\x0adiv#learn\x20.buttons\x20a,\x0adiv.play\x20.buttons\x20a,\x0adiv#blog\x20.read\x20a,\x0a#menu-button\x20{\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20text-decoration:\x20none;\x0a\x20\x20font-size:\x201rem;\x0a\x20\x20border-radius:\x200.3125rem;\x20}\x0a\x0adiv#playground\x20.buttons\x20a,\x0adiv#menu\x20>\x20a,\x0a#menu-button\x20{\x0a\x20\x20border:\x200.0625rem\x20solid\x20#375EAB;\x20}\x0a\x0adiv#playground\x20.buttons\x20a,\x0adiv#menu\x20>\x20a,\x0a#menu-button\x20{\x0a\x20\x20color:\x20white;\x0a\x20\x20background:\x20#375EAB;\x20}\x0a\x0a#playgroundButton.active\x20{\x0a\x20\x20background:\x20white;\x0a\x20\x20color:\x20#375EAB;\x20}\x0a\x0aa#start,\x0adiv#learn\x20.buttons\x20a,\x0adiv.play\x20.buttons\x20a,\x0adiv#blog\x20.read\x20a\x20{\x0a\x20\x20color:\x20#222;\x0a\x20\x20border:\x200.0625rem\x20solid\x20#375EAB;\x0a\x20\x20background:\x20#E0EBF5;\x20}\x0a\x0a.download\x20{\x0a\x20\x20width:\x209.375rem;\x20}\x0a\x0adiv#menu\x20{\x0a\x20\x20text-align:\x20right;\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20white-space:\x20nowrap;\x0a\x20\x20max-height:\x200;\x0a\x20\x20-moz-transition:\x20max-height\x20.25s\x20linear;\x0a\x20\x20transition:\x20max-height\x20.25s\x20linear;\x0a\x20\x20width:\x20100%;\x20}\x0a\x0adiv#menu.menu-visible\x20{\x0a\x20\x20max-height:\x2031.25rem;\x20}\x0a\x0adiv#menu\x20>\x20a,\x0a#menu-button\x20{\x0a\x20\x20margin:\x200.625rem\x200.125rem;\x0a\x20\x20padding:\x200.625rem;\x20}\x0a\x0a::-webkit-input-placeholder\x20{\x0a\x20\x20color:\x20#7f7f7f;\x0a\x20\x20opacity:\x201;\x20}\x0a\x0a::placeholder\x20{\x0a\x20\x20color:\x20#7f7f7f;\x0a\x20\x20opacity:\x201;\x20}\x0a\x0a#menu\x20.search-box\x20{\x0a\x20\x20display:\x20inline-flex;\x0a\x20\x20width:\x208.75rem;\x20}\x0a\x0a#menu-button\x20{\x0a\x20\x20display:\x20none;\x0a\x20\x20position:\x20absolute;\x0a\x20\x20right:\x200.3125rem;\x0a\x20\x20top:\x200;\x0a\x20\x20margin-right:\x200.3125rem;\x20}\x0a\x0a#menu-button-arrow\x20{\x0a\x20\x20display:\x20inline-block;\x20}\x0a\x0a.vertical-flip\x20{\x0a\x20\x20transform:\x20rotate(-180deg);\x20}\x0a\x0adiv.left\x20{\x0a\x20\x20float:\x20left;\x0a\x20\x20clear:\x20left;\x0a\x20\x20margin-right:\x202.5%;\x20}\x0a\x0adiv.right\x20{\x0a\x20\x20float:\x20right;\x0a\x20\x20clear:\x20right;\x0a\x20\x20margin-left:\x202.5%;\x20}\x0a\x0adiv.left,\x0adiv.right\x20{\x0a\x20\x20width:\x2045%;\x20}\x0a\x0adiv#learn,\x0adiv#about\x20{\x0a\x20\x20padding-top:\x201.25rem;\x20}\x0a\x0adiv#learn\x20h2,\x0adiv#about\x20{\x0a\x20\x20margin:\x200;\x20}\x0a\x0adiv#about\x20{\x0a\x20\x20font-size:\x201.25rem;\x0a\x20\x20margin:\x200\x20auto\x201.875rem;\x20}\x0a\x0adiv#gopher\x20{\x0a\x20\x20background:\x20url(/doc/gopher/frontpage.png)\x20no-repeat;\x0a\x20\x20background-position:\x20center\x20top;\x0a\x20\x20height:\x209.688rem;\x0a\x20\x20max-height:\x20200px;\x0a\x20\x20/*\x20Setting\x20in\x20px\x20to\x20prevent\x20the\x20gopher\x20from\x20blowing\x20up\x20in\x20very\x20high\x20default\x20font-sizes\x20*/\x20}\x0a\x0aa#start\x20{\x0a\x20\x20display:\x20block;\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20text-align:\x20center;\x0a\x20\x20text-decoration:\x20none;\x0a\x20\x20border-radius:\x200.3125rem;\x20}\x0a\x0aa#start\x20.big\x20{\x0a\x20\x20display:\x20block;\x0a\x20\x20font-weight:\x20bold;\x0a\x20\x20font-size:\x201.25rem;\x20}\x0a\x0aa#start\x20.desc\x20{\x0a\x20\x20display:\x20block;\x0a\x20\x20font-size:\x200.875rem;\x0a\x20\x20font-weight:\x20normal;\x0a\x20\x20margin-top:\x200.3125rem;\x20}\x0a\x0adiv#learn\x20.popout\x20{\x0a\x20\x20float:\x20right;\x0a\x20\x20display:\x20block;\x0a\x20\x20cursor:\x20pointer;\x0a\x20\x20font-size:\x200.75rem;\x0a\x20\x20background:\x20url(/doc/share.png)\x20no-repeat;\x0a\x20\x20background-position:\x20right\x20center;\x0a\x20\x20padding:\x200.375rem\x201.688rem;\x20}\x0a\x0adiv#learn\x20pre,\x0adiv#learn\x20textarea\x20{\x0a\x20\x20padding:\x200;\x0a\x20\x20margin:\x200;\x0a\x20\x20font-family:\x20Menlo,\x20monospace;\x0a\x20\x20font-size:\x200.875rem;\x20}\x0a\x0adiv#learn\x20.input\x20{\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20margin-top:\x200.625rem;\x0a\x20\x20height:\x209.375rem;\x0a\x20\x20border-top-left-radius:\x200.3125rem;\x0a\x20\x20border-top-right-radius:\x200.3125rem;\x20}\x0a\x0adiv#learn\x20.input\x20textarea\x20{\x0a\x20\x20width:\x20100%;\x0a\x20\x20height:\x20100%;\x0a\x20\x20border:\x20none;\x0a\x20\x20outline:\x20none;\x0a\x20\x20resize:\x20none;\x20}\x0a\x0adiv#learn\x20.output\x20{\x0a\x20\x20border-top:\x20none\x20!important;\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20height:\x203.688rem;\x0a\x20\x20overflow:\x20auto;\x0a\x20\x20border-bottom-right-radius:\x200.3125rem;\x0a\x20\x20border-bottom-left-radius:\x200.3125rem;\x20}\x0a\x0adiv#learn\x20.output\x20pre\x20{\x0a\x20\x20padding:\x200;\x0a\x20\x20border-radius:\x200;\x20}\x0a\x0adiv#learn\x20.input,\x0adiv#learn\x20.input\x20textarea,\x0adiv#learn\x20.output,\x0adiv#learn\x20.output\x20pre\x20{\x0a\x20\x20background:\x20#FFFFD8;\x20}\x0a\x0adiv#learn\x20.input,\x0adiv#learn\x20.output\x20{\x0a\x20\x20border:\x200.0625rem\x20solid\x20#375EAB;\x20}\x0a\x0adiv#learn\x20.buttons\x20{\x0a\x20\x20float:\x20right;\x0a\x20\x20padding:\x201.25rem\x200\x200.625rem\x200;\x0a\x20\x20text-align:\x20right;\x20}\x0a\x0adiv#learn\x20.buttons\x20a\x20{\x0a\x20\x20height:\x201rem;\x0a\x20\x20margin-left:\x200.3125rem;\x0a\x20\x20padding:\x200.625rem;\x20}\x0a\x0adiv#learn\x20.toys\x20{\x0a\x20\x20margin-top:\x200.5rem;\x20}\x0a\x0adiv#learn\x20.toys\x20select\x20{\x0a\x20\x20font-size:\x200.875rem;\x0a\x20\x20border:\x200.0625rem\x20solid\x20#375EAB;\x0a\x20\x20margin:\x200;\x20}\x0a\x0adiv#learn\x20.output\x20.exit\x20{\x0a\x20\x20display:\x20none;\x20}\x0a\x0adiv#video\x20{\x0a\x20\x20max-width:\x20100%;\x20}\x0a\x0adiv#blog,\x0adiv#video\x20{\x0a\x20\x20margin-top:\x202.5rem;\x20}\x0a\x0adiv#blog\x20>\x20a,\x0adiv#blog\x20>\x20div,\x0adiv#blog\x20>\x20h2,\x0adiv#video\x20>\x20a,\x0adiv#video\x20>\x20div,\x0adiv#video\x20>\x20h2\x20{\x0a\x20\x20margin-bottom:\x200.625rem;\x20}\x0a\x0adiv#blog\x20.title,\x0adiv#video\x20.title\x20{\x0a\x20\x20display:\x20block;\x0a\x20\x20font-size:\x201.25rem;\x20}\x0a\x0adiv#blog\x20.when\x20{\x0a\x20\x20color:\x20#666;\x0a\x20\x20font-size:\x200.875rem;\x20}\x0a\x0adiv#blog\x20.read\x20{\x0a\x20\x20text-align:\x20right;\x20}\x0a\x0a@supports\x20(--c:\x200)\x20{\x0a\x20\x20[style*=\"--aspect-ratio-padding:\"]\x20{\x0a\x20\x20\x20\x20position:\x20relative;\x0a\x20\x20\x20\x20overflow:\x20hidden;\x0a\x20\x20\x20\x20padding-top:\x20var(--aspect-ratio-padding);\x20}\x0a\x20\x20[style*=\"--aspect-ratio-padding:\"]\x20>\x20*\x20{\x0a\x20\x20\x20\x20position:\x20absolute;\x0a\x20\x20\x20\x20top:\x200;\x0a\x20\x20\x20\x20left:\x200;\x0a\x20\x20\x20\x20width:\x20100%;\x0a\x20\x20\x20\x20height:\x20100%;\x20}\x20}\x0a\x0a.toggleButton\x20{\x0a\x20\x20cursor:\x20pointer;\x20}\x0a\x0a.toggle\x20>\x20.collapsed\x20{\x0a\x20\x20display:\x20block;\x20}\x0a\x0a.toggle\x20>\x20.expanded\x20{\x0a\x20\x20display:\x20none;\x20}\x0a\x0a.toggleVisible\x20>\x20.collapsed\x20{\x0a\x20\x20display:\x20none;\x20}\x0a\x0a.toggleVisible\x20>\x20.expanded\x20{\x0a\x20\x20display:\x20block;\x20}\x0a\x0atable.codetable\x20{\x0a\x20\x20margin-left:\x20auto;\x0a\x20\x20margin-right:\x20auto;\x0a\x20\x20border-style:\x20none;\x20}\x0a\x0atable.codetable\x20td\x20{\x0a\x20\x20padding-right:\x200.625rem;\x20}\x0a\x0ahr\x20{\x0a\x20\x20border-style:\x20none;\x0a\x20\x20border-top:\x200.0625rem\x20solid\x20black;\x20}\x0a\x0aimg.gopher\x20{\x0a\x20\x20float:\x20right;\x0a\x20\x20margin-left:\x200.625rem;\x0a\x20\x20margin-bottom:\x200.625rem;\x0a\x20\x20z-index:\x20-1;\x20}\x0a\x0ah2\x20{\x0a\x20\x20clear:\x20right;\x20}\x0a\x0a/*\x20example\x20and\x20drop-down\x20playground\x20*/\x0adiv.play\x20{\x0a\x20\x20padding:\x200\x201.25rem\x202.5rem\x201.25rem;\x20}\x0a\x0adiv.play\x20pre,\x0adiv.play\x20textarea,\x0adiv.play\x20.lines\x20{\x0a\x20\x20padding:\x200;\x0a\x20\x20margin:\x200;\x0a\x20\x20font-family:\x20Menlo,\x20monospace;\x0a\x20\x20font-size:\x200.875rem;\x20}\x0a\x0adiv.play\x20.input\x20{\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20margin-top:\x200.625rem;\x0a\x20\x20border-top-left-radius:\x200.3125rem;\x0a\x20\x20border-top-right-radius:\x200.3125rem;\x0a\x20\x20overflow:\x20hidden;\x20}\x0a\x0adiv.play\x20.input\x20textarea\x20{\x0a\x20\x20width:\x20100%;\x0a\x20\x20height:\x20100%;\x0a\x20\x20border:\x20none;\x0a\x20\x20outline:\x20none;\x0a\x20\x20resize:\x20none;\x0a\x20\x20overflow:\x20hidden;\x20}\x0a\x0adiv#playground\x20.input\x20textarea\x20{\x0a\x20\x20overflow:\x20auto;\x0a\x20\x20resize:\x20auto;\x20}\x0a\x0adiv.play\x20.output\x20{\x0a\x20\x20border-top:\x20none\x20!important;\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20max-height:\x2012.5rem;\x0a\x20\x20overflow:\x20auto;\x0a\x20\x20border-bottom-right-radius:\x200.3125rem;\x0a\x20\x20border-bottom-left-radius:\x200.3125rem;\x20}\x0a\x0adiv.play\x20.output\x20pre\x20{\x0a\x20\x20padding:\x200;\x0a\x20\x20border-radius:\x200;\x20}\x0a\x0adiv.play\x20.input,\x0adiv.play\x20.input\x20textarea,\x0adiv.play\x20.output,\x0adiv.play\x20.output\x20pre\x20{\x0a\x20\x20background:\x20#FFFFD8;\x20}\x0a\x0adiv.play\x20.input,\x0adiv.play\x20.output\x20{\x0a\x20\x20border:\x200.0625rem\x20solid\x20#375EAB;\x20}\x0a\x0adiv.play\x20.buttons\x20{\x0a\x20\x20float:\x20right;\x0a\x20\x20padding:\x201.25rem\x200\x200.625rem\x200;\x0a\x20\x20text-align:\x20right;\x20}\x0a\x0adiv.play\x20.buttons\x20a\x20{\x0a\x20\x20height:\x201rem;\x0a\x20\x20margin-left:\x200.3125rem;\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20cursor:\x20pointer;\x20}\x0a\x0a.output\x20.stderr\x20{\x0a\x20\x20color:\x20#933;\x20}\x0a\x0a.output\x20.system\x20{\x0a\x20\x20color:\x20#999;\x20}\x0a\x0a/*\x20drop-down\x20playground\x20*/\x0adiv#playground\x20{\x0a\x20\x20/*\x20start\x20hidden;\x20revealed\x20by\x20javascript\x20*/\x0a\x20\x20display:\x20none;\x20}\x0a\x0adiv#playground\x20{\x0a\x20\x20position:\x20absolute;\x0a\x20\x20top:\x203.938rem;\x0a\x20\x20right:\x201.25rem;\x0a\x20\x20padding:\x200\x200.625rem\x200.625rem\x200.625rem;\x0a\x20\x20z-index:\x201;\x0a\x20\x20text-align:\x20left;\x0a\x20\x20background:\x20#E0EBF5;\x0a\x20\x20border:\x200.0625rem\x20solid\x20#B0BBC5;\x0a\x20\x20border-top:\x20none;\x0a\x20\x20border-bottom-left-radius:\x200.3125rem;\x0a\x20\x20border-bottom-right-radius:\x200.3125rem;\x20}\x0a\x0adiv#playground\x20.code\x20{\x0a\x20\x20width:\x2032.5rem;\x0a\x20\x20height:\x2012.5rem;\x20}\x0a\x0adiv#playground\x20.output\x20{\x0a\x20\x20height:\x206.25rem;\x20}\x0a\x0a/*\x20Inline\x20runnable\x20snippets\x20(play.js/initPlayground)\x20*/\x0a#content\x20.code\x20pre,\x20#content\x20.playground\x20pre,\x20#content\x20.output\x20pre\x20{\x0a\x20\x20margin:\x200;\x0a\x20\x20padding:\x200;\x0a\x20\x20background:\x20none;\x0a\x20\x20border:\x20none;\x0a\x20\x20outline:\x200\x20solid\x20transparent;\x0a\x20\x20overflow:\x20auto;\x20}\x0a\x0a#content\x20.playground\x20.number,\x20#content\x20.code\x20.number\x20{\x0a\x20\x20color:\x20#999;\x20}\x0a\x0a#content\x20.code,\x20#content\x20.playground,\x20#content\x20.output\x20{\x0a\x20\x20width:\x20auto;\x0a\x20\x20margin:\x201.25rem;\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20border-radius:\x200.3125rem;\x20}\x0a\x0a#content\x20.code,\x20#content\x20.playground\x20{\x0a\x20\x20background:\x20#e9e9e9;\x20}\x0a\x0a#content\x20.output\x20{\x0a\x20\x20background:\x20#202020;\x20}\x0a\x0a#content\x20.output\x20.stdout,\x20#content\x20.output\x20pre\x20{\x0a\x20\x20color:\x20#e6e6e6;\x20}\x0a\x0a#content\x20.output\x20.stderr,\x20#content\x20.output\x20.error\x20{\x0a\x20\x20color:\x20#f44a3f;\x20}\x0a\x0a#content\x20.output\x20.system,\x20#content\x20.output\x20.exit\x20{\x0a\x20\x20color:\x20#ffd14d;\x20}\x0a\x0a#content\x20.buttons\x20{\x0a\x20\x20position:\x20relative;\x0a\x20\x20float:\x20right;\x0a\x20\x20top:\x20-3.125rem;\x0a\x20\x20right:\x201.875rem;\x20}\x0a\x0a#content\x20.output\x20.buttons\x20{\x0a\x20\x20top:\x20-3.75rem;\x0a\x20\x20right:\x200;\x0a\x20\x20height:\x200;\x20}\x0a\x0a#content\x20.buttons\x20.kill\x20{\x0a\x20\x20display:\x20none;\x0a\x20\x20visibility:\x20hidden;\x20}\x0a\x0aa.error\x20{\x0a\x20\x20font-weight:\x20bold;\x0a\x20\x20color:\x20white;\x0a\x20\x20background-color:\x20darkred;\x0a\x20\x20border-bottom-left-radius:\x200.25rem;\x0a\x20\x20border-bottom-right-radius:\x200.25rem;\x0a\x20\x20border-top-left-radius:\x200.25rem;\x0a\x20\x20border-top-right-radius:\x200.25rem;\x0a\x20\x20padding:\x200.125rem\x200.25rem\x200.125rem\x200.25rem;\x0a\x20\x20/*\x20TRBL\x20*/\x20}\x0a\x0a#heading-narrow\x20{\x0a\x20\x20display:\x20none;\x20}\x0a\x0a.downloading\x20{\x0a\x20\x20background:\x20#F9F9BE;\x0a\x20\x20padding:\x200.625rem;\x0a\x20\x20text-align:\x20center;\x0a\x20\x20border-radius:\x200.3125rem;\x20}\x0a\x0a@media\x20(max-width:\x2058.125em)\x20{\x0a\x20\x20#heading-wide\x20{\x0a\x20\x20\x20\x20display:\x20none;\x20}\x0a\x20\x20#heading-narrow\x20{\x0a\x20\x20\x20\x20display:\x20block;\x20}\x20}\x0a\x0a@media\x20(max-width:\x2047.5em)\x20{\x0a\x20\x20.container\x20.left,\x0a\x20\x20.container\x20.right\x20{\x0a\x20\x20\x20\x20width:\x20auto;\x0a\x20\x20\x20\x20float:\x20none;\x20}\x0a\x20\x20div#about\x20{\x0a\x20\x20\x20\x20max-width:\x2031.25rem;\x0a\x20\x20\x20\x20text-align:\x20center;\x20}\x20}\x0a\x0a@media\x20(max-width:\x2043.75em)\x20{\x0a\x20\x20body\x20{\x0a\x20\x20\x20\x20font-size:\x200.9375rem;\x20}\x0a\x20\x20div#playground\x20{\x0a\x20\x20\x20\x20left:\x200;\x0a\x20\x20\x20\x20right:\x200;\x20}\x0a\x20\x20pre,\x0a\x20\x20code\x20{\x0a\x20\x20\x20\x20font-size:\x200.866rem;\x20}\x0a\x20\x20#heading-wide\x20{\x0a\x20\x20\x20\x20display:\x20block;\x20}\x0a\x20\x20#heading-narrow\x20{\x0a\x20\x20\x20\x20display:\x20none;\x20}\x0a\x20\x20.top-heading\x20{\x0a\x20\x20\x20\x20float:\x20none;\x0a\x20\x20\x20\x20display:\x20inline-block;\x0a\x20\x20\x20\x20padding:\x200.75rem;\x20}\x0a\x20\x20div#menu\x20{\x0a\x20\x20\x20\x20padding:\x200;\x0a\x20\x20\x20\x20min-width:\x200;\x0a\x20\x20\x20\x20text-align:\x20left;\x0a\x20\x20\x20\x20float:\x20left;\x20}\x0a\x20\x20div#menu\x20>\x20a\x20{\x0a\x20\x20\x20\x20display:\x20block;\x0a\x20\x20\x20\x20margin-left:\x200;\x0a\x20\x20\x20\x20margin-right:\x200;\x20}\x0a\x20\x20#menu\x20.search-box\x20{\x0a\x20\x20\x20\x20display:\x20flex;\x0a\x20\x20\x20\x20width:\x20100%;\x20}\x0a\x20\x20#menu-button\x20{\x0a\x20\x20\x20\x20display:\x20inline-block;\x20}\x0a\x20\x20p,\x0a\x20\x20pre,\x0a\x20\x20ul,\x0a\x20\x20ol\x20{\x0a\x20\x20\x20\x20margin:\x200.625rem;\x20}\x0a\x20\x20.pkg-synopsis\x20{\x0a\x20\x20\x20\x20display:\x20none;\x20}\x0a\x20\x20img.gopher\x20{\x0a\x20\x20\x20\x20display:\x20none;\x20}\x20}\x0a\x0a@media\x20(max-width:\x2030em)\x20{\x0a\x20\x20#heading-wide\x20{\x0a\x20\x20\x20\x20display:\x20none;\x20}\x0a\x20\x20#heading-narrow\x20{\x0a\x20\x20\x20\x20display:\x20block;\x20}\x20}\x0a\x0a@media\x20print\x20{\x0a\x20\x20pre\x20{\x0a\x20\x20\x20\x20background:\x20#FFF;\x0a\x20\x20\x20\x20border:\x200.0625rem\x20solid\x20#BBB;\x0a\x20\x20\x20\x20white-space:\x20pre-wrap;\x20}\x20}\x0a\x0a.collapsing\x20{\x0a\x20\x20position:\x20relative;\x0a\x20\x20height:\x200;\x0a\x20\x20overflow:\x20hidden;\x0a\x20\x20-webkit-transition:\x20height\x20.05s\x20ease;\x0a\x20\x20-o-transition:\x20height\x20.05s\x20ease;\x0a\x20\x20transition:\x20height\x20.05s\x20ease;\x20}\x0a\x0a.navbar\x20{\x0a\x20\x20background:\x20#FFF;\x0a\x20\x20box-shadow:\x200\x202px\x204px\x200\x20rgba(0,\x200,\x200,\x200.1);\x20}\x0a\x0a#sidebar\x20{\x0a\x20\x20width:\x20330px;\x0a\x20\x20padding:\x200;\x20}\x0a\x20\x20#sidebar\x20.sphinxsidebar\x20{\x0a\x20\x20\x20\x20width:\x20330px;\x0a\x20\x20\x20\x20font-size:\x2016px;\x0a\x20\x20\x20\x20line-height:\x2020px;\x0a\x20\x20\x20\x20border-right:\x201px\x20solid\x20#e0e7e8;\x0a\x20\x20\x20\x20top:\x2056px;\x0a\x20\x20\x20\x20bottom:\x200;\x0a\x20\x20\x20\x20position:\x20fixed;\x0a\x20\x20\x20\x20overflow-y:\x20auto;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20ul,\x20#sidebar\x20.sphinxsidebar\x20li\x20{\x0a\x20\x20\x20\x20\x20\x20list-style:\x20none;\x0a\x20\x20\x20\x20\x20\x20margin:\x200;\x0a\x20\x20\x20\x20\x20\x20padding:\x200;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20>\x20ul\x20>\x20li\x20{\x0a\x20\x20\x20\x20\x20\x20border-bottom:\x201px\x20solid\x20#e0e7e8;\x0a\x20\x20\x20\x20\x20\x20overflow-x:\x20auto;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20li.opend\x20{\x0a\x20\x20\x20\x20\x20\x20background-color:\x20#f0f7ff;\x20}\x0a\x20\x20\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20li.opend\x20>\x20.reference\x20.package\x20{\x0a\x20\x20\x20\x20\x20\x20\x20\x20font-weight:\x20700;\x0a\x20\x20\x20\x20\x20\x20\x20\x20color:\x20#375EAB;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-1\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2010px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-2\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2020px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-3\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2030px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-4\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2040px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-5\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2050px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-6\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2060px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-7\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2070px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-8\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2080px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-9\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x2090px;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.sphinxsidebar\x20.depth-10\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20padding-left:\x20100px;\x20}\x0a\x20\x20#sidebar\x20.reference\x20{\x0a\x20\x20\x20\x20width:\x20100%;\x0a\x20\x20\x20\x20line-height:\x2024px;\x0a\x20\x20\x20\x20position:\x20relative;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.reference\x20.expand-icon\x20{\x0a\x20\x20\x20\x20\x20\x20display:\x20inline-block;\x0a\x20\x20\x20\x20\x20\x20width:\x202.5rem;\x0a\x20\x20\x20\x20\x20\x20top:\x200;\x0a\x20\x20\x20\x20\x20\x20bottom:\x200;\x0a\x20\x20\x20\x20\x20\x20right:\x200;\x0a\x20\x20\x20\x20\x20\x20position:\x20absolute;\x0a\x20\x20\x20\x20\x20\x20background-image:\x20url(/lib/godoc/images/icon-chevron-right.svg);\x0a\x20\x20\x20\x20\x20\x20background-repeat:\x20no-repeat;\x0a\x20\x20\x20\x20\x20\x20background-position:\x20center;\x0a\x20\x20\x20\x20\x20\x20border-radius:\x203px;\x20}\x0a\x20\x20\x20\x20\x20\x20#sidebar\x20.reference\x20.expand-icon:hover\x20{\x0a\x20\x20\x20\x20\x20\x20\x20\x20background-color:\x20#e0ebf5;\x20}\x0a\x20\x20\x20\x20\x20\x20#sidebar\x20.reference\x20.expand-icon:focus\x20{\x0a\x20\x20\x20\x20\x20\x20\x20\x20outline:\x200;\x0a\x20\x20\x20\x20\x20\x20\x20\x20box-shadow:\x20none;\x0a\x20\x20\x20\x20\x20\x20\x20\x20border:\x202px\x20solid;\x20}\x0a\x20\x20\x20\x20#sidebar\x20.reference\x20a.package\x20{\x0a\x20\x20\x20\x20\x20\x20display:\x20inline-block;\x0a\x20\x20\x20\x20\x20\x20width:\x20100%;\x0a\x20\x20\x20\x20\x20\x20padding:\x20.75rem\x201.25rem;\x0a\x20\x20\x20\x20\x20\x20color:\x20#222;\x20}\x0a\x20\x20\x20\x20\x20\x20#sidebar\x20.reference\x20a.package:hover\x20{\x0a\x20\x20\x20\x20\x20\x20\x20\x20background-color:\x20#e0ebf5;\x20}\x0a\x20\x20\x20\x20\x20\x20#sidebar\x20.reference\x20a.package.current\x20{\x0a\x20\x20\x20\x20\x20\x20\x20\x20font-weight:\x20700;\x0a\x20\x20\x20\x20\x20\x20\x20\x20color:\x20#375EAB;\x20}\x0a\x20\x20\x20\x20\x20\x20\x20\x20#sidebar\x20.reference\x20a.package.current::before,\x20#sidebar\x20.reference\x20a.package.current::after\x20{\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20content:\x20\"\";\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20left:\x20calc(0.5rem);\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20top:\x201.3em;\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20height:\x208px;\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20position:\x20absolute;\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20transition:\x20all\x20250ms\x20cubic-bezier(0.4,\x200,\x200.2,\x201)\x200s;\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20width:\x208px;\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20background-color:\x20#375EAB;\x20}\x0a\x20\x20\x20\x20\x20\x20\x20\x20#sidebar\x20.reference\x20a.package.current::before\x20{\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20border-radius:\x20100%;\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20transform:\x20scale(1);\x20}\x0a\x20\x20\x20\x20\x20\x20\x20\x20#sidebar\x20.reference\x20a.package.current::after\x20{\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20border-radius:\x204px;\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20opacity:\x201;\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20transform:\x20translateX(-92px);\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20width:\x20100px;\x20}\x0a\x0a#main-column\x20{\x0a\x20\x20overflow-x:\x20auto;\x20}\x0a",
 
-	"layout.html": "<!DOCTYPE\x20html>\x0a<html>\x0a<head>\x0a\x20\x20<meta\x20http-equiv=\"Content-Type\"\x20content=\"text/html;\x20charset=utf-8\">\x0a\x20\x20<meta\x20name=\"viewport\"\x20content=\"width=device-width,\x20initial-scale=1\">\x0a\x20\x20<meta\x20name=\"theme-color\"\x20content=\"#375EAB\">\x0a\x0a\x20\x20{{with\x20.Tabtitle}}\x0a\x20\x20<title>{{html\x20.}}\x20-\x20Go\x20Documentation\x20Server</title>\x0a\x20\x20{{else}}\x0a\x20\x20<title>Go\x20Documentation\x20Server</title>\x0a\x20\x20{{end}}\x0a\x0a\x20\x20<link\x20type=\"text/css\"\x20rel=\"stylesheet\"\x20href=\"/lib/godoc/bootstrap-grid.min.css\">\x0a\x09<link\x20type=\"text/css\"\x20rel=\"stylesheet\"\x20href=\"/lib/godoc/bootstrap-reboot.min.css\">\x0a\x20\x20<link\x20type=\"text/css\"\x20rel=\"stylesheet\"\x20href=\"/lib/godoc/bootstrap.min.css\">\x0a\x20\x20<link\x20type=\"text/css\"\x20rel=\"stylesheet\"\x20href=\"/lib/godoc/style.css\">\x0a\x0a\x20\x20<script\x20src=\"/lib/godoc/jquery.js\"></script>\x0a\x20\x20<script\x20src=\"/lib/godoc/popper.min.js\"></script>\x0a\x09<script\x20src=\"/lib/godoc/bootstrap.bundle.min.js\"></script>\x0a\x20\x20<script\x20src=\"/lib/godoc/bootstrap.min.js\"></script>\x0a\x0a\x20\x20{{if\x20.Playground}}\x0a\x20\x20<script\x20src=\"/lib/godoc/playground.js\"></script>\x0a\x20\x20{{end}}\x0a\x20\x20<script\x20src=\"/lib/godoc/godocs.js\"\x20defer></script>\x0a</head>\x0a<body>\x0a\x0a\x20\x20<nav\x20class=\"navbar\x20fixed-top\">\x0a\x20\x20\x20\x20<a\x20class=\"navbar-brand\"\x20href=\"/pkg/\">Go\x20Documentation\x20Server</a>\x0a\x20\x20</nav>\x0a\x0a\x20\x20<div\x20id=\"page\">\x0a\x20\x20\x20\x20<div\x20class=\"container-fluid\">\x0a\x20\x20\x20\x20\x20\x20<div\x20class=\"row\">\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20<aside\x20id=\"sidebar\"\x20class=\"col-auto\">\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{printf\x20\"%s\"\x20.Sidebar}}\x20{{/*\x20Sidebar\x20is\x20HTML-escaped\x20elsewhere\x20*/}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20</aside>\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20<main\x20id=\"main-column\"\x20class=\"col\">\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{if\x20or\x20.Title\x20.SrcPath}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<h1>\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{html\x20.Title}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{html\x20.SrcPath\x20|\x20srcBreadcrumb}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20</h1>\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{end}}\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{with\x20.Subtitle}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<h2>{{html\x20.}}</h2>\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{end}}\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{with\x20.SrcPath}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<h2>Documentation:\x20{{html\x20.\x20|\x20srcToPkgLink}}</h2>\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{end}}\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{/*\x20The\x20Table\x20of\x20Contents\x20is\x20automatically\x20inserted\x20in\x20this\x20<div>.\x20Do\x20not\x20delete\x20this\x20<div>.\x20*/}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<div\x20id=\"nav\"></div>\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{printf\x20\"%s\"\x20.Body}}{{/*\x20Body\x20is\x20HTML-escaped\x20elsewhere\x20*/}}\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<div\x20id=\"footer\">Made\x20by\x20godoc</div>\x0a\x20\x20\x20\x20\x20\x20\x20\x20</main>\x0a\x20\x20\x20\x20\x20\x20</div>\x0a\x20\x20\x20\x20</div><!--\x20.container-fluid\x20-->\x0a\x0a\x20\x20</div><!--\x20#page\x20-->\x0a</body>\x0a</html>\x0a",
+	"layout.html": "<!DOCTYPE\x20html>\x0a<html>\x0a<head>\x0a\x20\x20<meta\x20http-equiv=\"Content-Type\"\x20content=\"text/html;\x20charset=utf-8\">\x0a\x20\x20<meta\x20name=\"viewport\"\x20content=\"width=device-width,\x20initial-scale=1\">\x0a\x20\x20<meta\x20name=\"theme-color\"\x20content=\"#375EAB\">\x0a\x0a\x20\x20{{with\x20.Tabtitle}}\x0a\x20\x20<title>{{html\x20.}}\x20-\x20Go\x20Documentation\x20Server</title>\x0a\x20\x20{{else}}\x0a\x20\x20<title>Go\x20Documentation\x20Server</title>\x0a\x20\x20{{end}}\x0a\x0a\x20\x20<link\x20type=\"text/css\"\x20rel=\"stylesheet\"\x20href=\"/lib/godoc/bootstrap-grid.min.css\">\x0a\x09<link\x20type=\"text/css\"\x20rel=\"stylesheet\"\x20href=\"/lib/godoc/bootstrap-reboot.min.css\">\x0a\x20\x20<link\x20type=\"text/css\"\x20rel=\"stylesheet\"\x20href=\"/lib/godoc/bootstrap.min.css\">\x0a\x20\x20<link\x20type=\"text/css\"\x20rel=\"stylesheet\"\x20href=\"/lib/godoc/style.css\">\x0a\x0a\x20\x20<script\x20src=\"/lib/godoc/jquery.js\"></script>\x0a\x20\x20<script\x20src=\"/lib/godoc/popper.min.js\"></script>\x0a\x09<script\x20src=\"/lib/godoc/bootstrap.bundle.min.js\"></script>\x0a\x20\x20<script\x20src=\"/lib/godoc/bootstrap.min.js\"></script>\x0a\x0a\x20\x20{{if\x20.Playground}}\x0a\x20\x20<script\x20src=\"/lib/godoc/playground.js\"></script>\x0a\x20\x20{{end}}\x0a\x20\x20<script\x20src=\"/lib/godoc/godocs.js\"\x20defer></script>\x0a</head>\x0a<body>\x0a\x0a\x20\x20<nav\x20class=\"navbar\x20fixed-top\">\x0a\x20\x20\x20\x20<a\x20class=\"navbar-brand\"\x20href=\"/pkg/\">Go\x20Documentation\x20Server</a>\x0a\x20\x20\x20\x20{{if\x20.SearchBox}}\x0a\x20\x20\x20\x20<form\x20class=\"form-inline\"\x20method=\"GET\"\x20action=\"/search\">\x0a\x20\x20\x20\x20\x20\x20<input\x20class=\"form-control\x20form-control-sm\"\x20type=\"search\"\x20name=\"q\"\x20placeholder=\"Search\"\x20value=\"{{html\x20.Query}}\">\x0a\x20\x20\x20\x20</form>\x0a\x20\x20\x20\x20{{end}}\x0a\x20\x20</nav>\x0a\x0a\x20\x20<div\x20id=\"page\">\x0a\x20\x20\x20\x20<div\x20class=\"container-fluid\">\x0a\x20\x20\x20\x20\x20\x20<div\x20class=\"row\">\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20<aside\x20id=\"sidebar\"\x20class=\"col-auto\">\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{printf\x20\"%s\"\x20.Sidebar}}\x20{{/*\x20Sidebar\x20is\x20HTML-escaped\x20elsewhere\x20*/}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20</aside>\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20<main\x20id=\"main-column\"\x20class=\"col\">\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{if\x20or\x20.Title\x20.SrcPath}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<h1>\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{html\x20.Title}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{html\x20.SrcPath\x20|\x20srcBreadcrumb}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20</h1>\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{end}}\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{with\x20.Subtitle}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<h2>{{html\x20.}}</h2>\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{end}}\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{with\x20.SrcPath}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<h2>Documentation:\x20{{html\x20.\x20|\x20srcToPkgLink}}</h2>\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{end}}\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{/*\x20The\x20Table\x20of\x20Contents\x20is\x20automatically\x20inserted\x20in\x20this\x20<div>.\x20Do\x20not\x20delete\x20this\x20<div>.\x20*/}}\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<div\x20id=\"nav\"></div>\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20{{printf\x20\"%s\"\x20.Body}}{{/*\x20Body\x20is\x20HTML-escaped\x20elsewhere\x20*/}}\x0a\x0a\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20<div\x20id=\"footer\">Made\x20by\x20godoc</div>\x0a\x20\x20\x20\x20\x20\x20\x20\x20</main>\x0a\x20\x20\x20\x20\x20\x20</div>\x0a\x20\x20\x20\x20</div><!--\x20.container-fluid\x20-->\x0a\x0a\x20\x20</div><!--\x20#page\x20-->\x0a</body>\x0a</html>\x0a",
 
-	"sidebar.html": "<!--\x20sidebar.html\x20-->\x0a<div\x20class=\"sphinxsidebar\">\x0a\x09{{/*\x20Nested\x20render\x20directory\x20*/}}\x0a\x09{{-\x20define\x20\"item\"\x20-}}\x0a\x09{{-\x20range\x20.\x20}}\x0a\x09\x09<li\x20class=\"leaf\x20depth-{{.Depth}}\">\x0a\x0a\x09\x09\x09<div\x20class=\"reference\">\x0a\x09\x09\x09\x09<a\x20class=\"package\"\x20href=\"/pkg/{{.ImportPath}}\">{{.Name}}</a>\x0a\x0a\x09\x09\x09\x09{{-\x20if\x20gt\x20(len\x20.SubDirectories)\x200\x20}}\x0a\x09\x09\x09\x09<button\x0a\x09\x09\x09\x09\x09class=\"btn\x20btn-link\x20expand-icon\x20docs-expand-arrow\"\x0a\x09\x09\x09\x09\x09data-toggle=\"collapse\"\x0a\x09\x09\x09\x09\x09data-target=\"#path-{{srcID\x20.Path}}\"></button>\x0a\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09</div>\x0a\x0a\x09\x09\x09{{-\x20if\x20gt\x20(len\x20.SubDirectories)\x200\x20}}\x0a\x09\x09\x09<ul\x20class=\"collapse\x20multi-collapse\"\x20id=\"path-{{srcID\x20.Path}}\">\x0a\x09\x09\x09\x09{{template\x20\"item\"\x20.SubDirectories}}\x0a\x09\x09\x09</ul>\x0a\x09\x09\x09{{-\x20end}}\x0a\x09\x09</li>\x0a\x09{{-\x20end\x20-}}\x0a\x09{{-\x20end\x20-}}\x0a\x0a\x09{{with\x20.Directory}}\x0a\x09\x09<ul>\x0a\x09\x09\x09{{template\x20\"item\"\x20.SubDirectories}}\x0a\x09\x09</ul>\x0a\x09{{end}}\x0a</div>\x0a<!--\x20end\x20sidebar.html\x20-->\x0a",
+	"sidebar.html": "<!--\x20sidebar.html\x20-->\x0a<div\x20class=\"sphinxsidebar\">\x0a\x09{{/*\x20Nested\x20render\x20directory\x20*/}}\x0a\x09{{-\x20define\x20\"item\"\x20-}}\x0a\x09{{-\x20range\x20.Dirs\x20}}\x0a\x09\x09{{-\x20if\x20.Deprecated\x20}}\x0a\x09\x09{{/*\x20deprecated\x20packages\x20are\x20omitted,\x20but\x20not\x20their\x20subdirectories\x20*/}}\x0a\x09\x09{{template\x20\"item\"\x20(dirListing\x20$.Query\x20.SubDirectories)}}\x0a\x09\x09{{-\x20else}}\x0a\x09\x09<li\x20class=\"leaf\x20depth-{{.Depth}}\">\x0a\x0a\x09\x09\x09<div\x20class=\"reference\">\x0a\x09\x09\x09\x09<a\x20class=\"package\"\x20href=\"/pkg/{{.ImportPath}}{{html\x20$.Query}}\">{{.Name}}</a>\x0a\x0a\x09\x09\x09\x09{{-\x20if\x20gt\x20(len\x20.SubDirectories)\x200\x20}}\x0a\x09\x09\x09\x09<button\x0a\x09\x09\x09\x09\x09class=\"btn\x20btn-link\x20expand-icon\x20docs-expand-arrow\"\x0a\x09\x09\x09\x09\x09data-toggle=\"collapse\"\x0a\x09\x09\x09\x09\x09data-target=\"#path-{{srcID\x20.Path}}\"></button>\x0a\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09</div>\x0a\x0a\x09\x09\x09{{-\x20if\x20gt\x20(len\x20.SubDirectories)\x200\x20}}\x0a\x09\x09\x09<ul\x20class=\"collapse\x20multi-collapse\"\x20id=\"path-{{srcID\x20.Path}}\">\x0a\x09\x09\x09\x09{{template\x20\"item\"\x20(dirListing\x20$.Query\x20.SubDirectories)}}\x0a\x09\x09\x09</ul>\x0a\x09\x09\x09{{-\x20end}}\x0a\x09\x09</li>\x0a\x09\x09{{-\x20end}}\x0a\x09{{-\x20end\x20-}}\x0a\x09{{-\x20end\x20-}}\x0a\x0a\x09{{$query\x20:=\x20\"\"}}\x0a\x09{{with\x20.PageInfo}}{{$query\x20=\x20queryString\x20.Mode\x20.Build}}{{end}}\x0a\x09{{with\x20.Directory}}\x0a\x09\x09<ul>\x0a\x09\x09\x09{{template\x20\"item\"\x20(dirListing\x20$query\x20.SubDirectories)}}\x0a\x09\x09</ul>\x0a\x09{{end}}\x0a</div>\x0a<!--\x20end\x20sidebar.html\x20-->\x0a",
 
-	"packageroot.html": "<!--\x20packageroot.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{with\x20.PAst}}\x0a\x09{{range\x20$filename,\x20$ast\x20:=\x20.}}\x0a\x09\x09<a\x20href=\"{{$filename|srcLink|html}}\">{{$filename|filename|html}}</a>:<pre>{{node_html\x20$\x20$ast\x20false}}</pre>\x0a\x09{{end}}\x0a{{end}}\x0a\x0a\x0a{{/*\x20Nested\x20render\x20directory\x20*/}}\x0a{{-\x20define\x20\"item\"\x20-}}\x0a{{-\x20range\x20.\x20}}\x0a\x09<tr>\x0a\x09\x09<td>\x0a\x09\x09\x09<a\x20href=\"/pkg/{{.ImportPath}}\">{{html\x20.ImportPath}}</a>\x0a\x09\x09</td>\x0a\x09\x09<td>{{html\x20.Synopsis}}</td>\x0a\x0a\x09\x09{{-\x20if\x20gt\x20(len\x20.SubDirectories)\x200\x20}}\x0a\x09\x09{{template\x20\"item\"\x20.SubDirectories}}\x0a\x09\x09{{-\x20end}}\x0a\x09</tr>\x0a{{-\x20end\x20-}}\x0a{{-\x20end\x20-}}\x0a\x0a{{with\x20.Directory}}\x0a\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09<tr>\x0a\x09\x09\x09<th\x20class=\"pkg-name\">Package</th>\x0a\x09\x09\x09<th\x20class=\"pkg-synopsis\">Synopsis</th>\x0a\x09\x09</tr>\x0a\x09\x09{{template\x20\"item\"\x20.SubDirectories}}\x0a\x09</table>\x0a{{end}}\x0a<!--\x20end\x20packageroot.html\x20-->\x0a",
+	"packageroot.html": "<!--\x20packageroot.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{with\x20.PAst}}\x0a\x09{{range\x20$filename,\x20$ast\x20:=\x20.}}\x0a\x09\x09<a\x20href=\"{{$filename|srcLink|html}}\">{{$filename|filename|html}}</a>:<pre>{{node_html\x20$\x20$ast\x20false}}</pre>\x0a\x09{{end}}\x0a{{end}}\x0a\x0a\x0a{{/*\x20Nested\x20render\x20directory\x20*/}}\x0a{{-\x20define\x20\"item\"\x20-}}\x0a{{-\x20range\x20.\x20}}\x0a\x09<tr>\x0a\x09\x09<td>\x0a\x09\x09\x09<a\x20href=\"/pkg/{{.ImportPath}}\">{{html\x20.ImportPath}}</a>\x0a\x09\x09</td>\x0a\x09\x09<td>{{html\x20.Synopsis}}</td>\x0a\x0a\x09\x09{{-\x20if\x20gt\x20(len\x20.SubDirectories)\x200\x20}}\x0a\x09\x09{{template\x20\"item\"\x20.SubDirectories}}\x0a\x09\x09{{-\x20end}}\x0a\x09</tr>\x0a{{-\x20end\x20-}}\x0a{{-\x20end\x20-}}\x0a\x0a{{with\x20.Workspace}}\x0a\x09{{range\x20.}}\x0a\x09\x09<h2\x20id=\"{{html\x20.Path}}\">{{html\x20.Path}}</h2>\x0a\x09\x09<p\x20class=\"text-muted\">{{html\x20.Dir}}</p>\x0a\x09\x09{{with\x20.Packages}}\x0a\x09\x09\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09\x09\x09<tr>\x0a\x09\x09\x09\x09\x09<th\x20class=\"pkg-name\">Package</th>\x0a\x09\x09\x09\x09\x09<th\x20class=\"pkg-synopsis\">Synopsis</th>\x0a\x09\x09\x09\x09</tr>\x0a\x09\x09\x09\x09{{template\x20\"item\"\x20.}}\x0a\x09\x09\x09</table>\x0a\x09\x09{{else}}\x0a\x09\x09\x09<p>No\x20packages.</p>\x0a\x09\x09{{end}}\x0a\x09{{end}}\x0a\x09<h2\x20id=\"pkg-other\">Other\x20packages</h2>\x0a{{end}}\x0a\x0a{{with\x20.Directory}}\x0a\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09<tr>\x0a\x09\x09\x09<th\x20class=\"pkg-name\">Package</th>\x0a\x09\x09\x09<th\x20class=\"pkg-synopsis\">Synopsis</th>\x0a\x09\x09</tr>\x0a\x09\x09{{template\x20\"item\"\x20.SubDirectories}}\x0a\x09</table>\x0a{{end}}\x0a<!--\x20end\x20packageroot.html\x20-->\x0a",
 
-	"package.html": "<!--\x20package.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{with\x20.DocPackage}}\x0a\x09{{if\x20$.IsMain}}\x0a\x09\x09{{/*\x20command\x20documentation\x20*/}}\x0a\x09\x09{{comment_html\x20.Doc}}\x0a\x09{{else}}\x0a\x09\x09{{/*\x20package\x20documentation\x20*/}}\x0a\x09\x09<div\x20id=\"short-nav\">\x0a\x09\x09\x09<dl>\x0a\x09\x09\x09\x09<dd><code>import\x20\"{{html\x20.ImportPath}}\"</code></dd>\x0a\x09\x09\x09</dl>\x0a\x09\x09\x09<dl>\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-overview\"\x20class=\"overviewLink\">Overview</a></dd>\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-index\"\x20class=\"indexLink\">Index</a></dd>\x0a\x0a\x09\x09\x09{{if\x20$.Examples}}\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-examples\"\x20class=\"examplesLink\">Examples</a></dd>\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{if\x20$.Directory}}\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-subdirectories\">Subdirectories</a></dd>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09</dl>\x0a\x09\x09</div>\x0a\x09\x09<!--\x20The\x20package's\x20Name\x20is\x20printed\x20as\x20title\x20by\x20the\x20top-level\x20template\x20-->\x0a\x09\x09<div\x20id=\"pkg-overview\"\x20class=\"toggleVisible\">\x0a\x09\x09\x09<div\x20class=\"collapsed\">\x0a\x09\x09\x09\x09<h2\x20class=\"toggleButton\"\x20title=\"Click\x20to\x20show\x20Overview\x20section\">Overview\x20\xe2\x96\xb9</h2>\x0a\x09\x09\x09</div>\x0a\x09\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09\x09<h2\x20class=\"toggleButton\"\x20title=\"Click\x20to\x20hide\x20Overview\x20section\">Overview\x20\xe2\x96\xbe</h2>\x0a\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09{{example_html\x20$\x20\"\"}}\x0a\x09\x09\x09</div>\x0a\x09\x09</div>\x0a\x0a\x09\x09<div\x20id=\"pkg-index\"\x20class=\"toggleVisible\">\x0a\x09\x09\x09<div\x20class=\"collapsed\">\x0a\x09\x09\x09\x09<h2\x20class=\"toggleButton\"\x20title=\"Click\x20to\x20show\x20Index\x20section\">Index\x20\xe2\x96\xb9</h2>\x0a\x09\x09\x09</div>\x0a\x0a\x09\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09\x09<h2\x20class=\"toggleButton\"\x20title=\"Click\x20to\x20hide\x20Index\x20section\">Index\x20\xe2\x96\xbe</h2>\x0a\x0a\x09\x09\x09\x09<!--\x20Table\x20of\x20contents\x20for\x20API;\x20must\x20be\x20named\x20manual-nav\x20to\x20turn\x20off\x20auto\x20nav.\x20-->\x0a\x09\x09\x09\x09<div\x20id=\"manual-nav\">\x0a\x09\x09\x09\x09\x09<dl>\x0a\x09\x09\x09\x09\x09{{if\x20.Consts}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#pkg-constants\">Constants</a></dd>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{if\x20.Vars}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#pkg-variables\">Variables</a></dd>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{range\x20.Funcs}}\x0a\x09\x09\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#{{$name_html}}\">{{node_html\x20$\x20.Decl\x20false\x20|\x20sanitize}}</a></dd>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{range\x20.Types}}\x0a\x09\x09\x09\x09\x09\x09{{$tname_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#{{$tname_html}}\">type\x20{{$tname_html}}</a></dd>\x0a\x09\x09\x09\x09\x09\x09{{range\x20.Funcs}}\x0a\x09\x09\x09\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09\x09<dd>&nbsp;\x20&nbsp;\x20<a\x20href=\"#{{$name_html}}\">{{node_html\x20$\x20.Decl\x20false\x20|\x20sanitize}}</a></dd>\x0a\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09\x09{{range\x20.Methods}}\x0a\x09\x09\x09\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09\x09<dd>&nbsp;\x20&nbsp;\x20<a\x20href=\"#{{$tname_html}}.{{$name_html}}\">{{node_html\x20$\x20.Decl\x20false\x20|\x20sanitize}}</a></dd>\x0a\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{if\x20$.Notes}}\x0a\x09\x09\x09\x09\x09\x09{{range\x20$marker,\x20$item\x20:=\x20$.Notes}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#pkg-note-{{$marker}}\">{{noteTitle\x20$marker\x20|\x20html}}s</a></dd>\x0a\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09</dl>\x0a\x09\x09\x09\x09</div><!--\x20#manual-nav\x20-->\x0a\x0a\x09\x09\x09{{if\x20$.Examples}}\x0a\x09\x09\x09\x09<div\x20id=\"pkg-examples\">\x0a\x09\x09\x09\x09\x09<h3>Examples</h3>\x0a\x09\x09\x09\x09\x09<div\x20class=\"js-expandAll\x20expandAll\x20collapsed\">(Expand\x20All)</div>\x0a\x09\x09\x09\x09\x09<dl>\x0a\x09\x09\x09\x09\x09{{range\x20$.Examples}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20class=\"exampleLink\"\x20href=\"#example_{{.Name}}\">{{example_name\x20.Name}}</a></dd>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09</dl>\x0a\x09\x09\x09\x09</div>\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{with\x20.Filenames}}\x0a\x09\x09\x09\x09<h3>Package\x20files</h3>\x0a\x09\x09\x09\x09<p>\x0a\x09\x09\x09\x09\x09<span\x20style=\"font-size:90%\">\x0a\x09\x09\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09\x09\x09\x09<a\x20href=\"{{.|srcLink|html}}\">{{.|filename|html}}</a>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09</span>\x0a\x09\x09\x09\x09</p>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09</div><!--\x20.expanded\x20-->\x0a\x09\x09</div><!--\x20#pkg-index\x20-->\x0a\x0a\x0a\x09\x09{{with\x20.Consts}}\x0a\x09\x09\x09<h2\x20id=\"pkg-constants\">Constants</h2>\x0a\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09{{end}}\x0a\x09\x09{{end}}\x0a\x0a\x0a\x09\x09{{with\x20.Vars}}\x0a\x09\x09\x09<h2\x20id=\"pkg-variables\">Variables</h2>\x0a\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09{{end}}\x0a\x09\x09{{end}}\x0a\x0a\x0a\x09\x09{{range\x20.Funcs}}\x0a\x09\x09\x09{{/*\x20Name\x20is\x20a\x20string\x20-\x20no\x20need\x20for\x20FSet\x20*/}}\x0a\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09<h2\x20id=\"{{$name_html}}\">func\x20<a\x20href=\"{{posLink_url\x20$\x20.Decl}}\">{{$name_html}}</a>\x0a\x09\x09\x09\x09<a\x20class=\"permalink\"\x20href=\"#{{$name_html}}\">&#xb6;</a>\x0a\x09\x09\x09\x09{{$since\x20:=\x20since\x20\"func\"\x20\"\"\x20.Name\x20$.DocPackage.ImportPath}}\x0a\x09\x09\x09\x09{{if\x20$since}}<span\x20title=\"Added\x20in\x20Go\x20{{$since}}\">{{$since}}</span>{{end}}\x0a\x09\x09\x09</h2>\x0a\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09{{example_html\x20$\x20.Name}}\x0a\x09\x09{{end}}\x0a\x0a\x0a\x09\x09{{range\x20.Types}}\x0a\x09\x09\x09{{$tname\x20:=\x20.Name}}\x0a\x09\x09\x09{{$tname_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09<h2\x20id=\"{{$tname_html}}\">type\x20<a\x20href=\"{{posLink_url\x20$\x20.Decl}}\">{{$tname_html}}</a>\x0a\x09\x09\x09\x09<a\x20class=\"permalink\"\x20href=\"#{{$tname_html}}\">&#xb6;</a>\x0a\x09\x09\x09\x09{{$since\x20:=\x20since\x20\"type\"\x20\"\"\x20.Name\x20$.DocPackage.ImportPath}}\x0a\x09\x09\x09\x09{{if\x20$since}}<span\x20title=\"Added\x20in\x20Go\x20{{$since}}\">{{$since}}</span>{{end}}\x0a\x09\x09\x09</h2>\x0a\x0a\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x0a\x09\x09\x09{{range\x20.Consts}}\x0a\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{range\x20.Vars}}\x0a\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{example_html\x20$\x20$tname}}\x0a\x0a\x09\x09\x09{{range\x20.Funcs}}\x0a\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09<h3\x20id=\"{{$name_html}}\">func\x20<a\x20href=\"{{posLink_url\x20$\x20.Decl}}\">{{$name_html}}</a>\x0a\x09\x09\x09\x09\x09<a\x20class=\"permalink\"\x20href=\"#{{$name_html}}\">&#xb6;</a>\x0a\x09\x09\x09\x09\x09{{$since\x20:=\x20since\x20\"func\"\x20\"\"\x20.Name\x20$.DocPackage.ImportPath}}\x0a\x09\x09\x09\x09\x09{{if\x20$since}}<span\x20title=\"Added\x20in\x20Go\x20{{$since}}\">{{$since}}</span>{{end}}\x0a\x09\x09\x09\x09</h3>\x0a\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09{{example_html\x20$\x20.Name}}\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{range\x20.Methods}}\x0a\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09<h3\x20id=\"{{$tname_html}}.{{$name_html}}\">func\x20({{html\x20.Recv}})\x20<a\x20href=\"{{posLink_url\x20$\x20.Decl}}\">{{$name_html}}</a>\x0a\x09\x09\x09\x09\x09<a\x20class=\"permalink\"\x20href=\"#{{$tname_html}}.{{$name_html}}\">&#xb6;</a>\x0a\x09\x09\x09\x09\x09{{$since\x20:=\x20since\x20\"method\"\x20.Recv\x20.Name\x20$.DocPackage.ImportPath}}\x0a\x09\x09\x09\x09\x09{{if\x20$since}}<span\x20title=\"Added\x20in\x20Go\x20{{$since}}\">{{$since}}</span>{{end}}\x0a\x09\x09\x09\x09</h3>\x0a\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09{{$name\x20:=\x20printf\x20\"%s_%s\"\x20$tname\x20.Name}}\x0a\x09\x09\x09\x09{{example_html\x20$\x20$name}}\x0a\x09\x09\x09{{end}}\x0a\x09\x09{{end}}\x0a\x09{{end}}\x0a\x0a\x0a\x09{{with\x20$.Notes}}\x0a\x09\x09{{range\x20$marker,\x20$content\x20:=\x20.}}\x0a\x09\x09\x09<h2\x20id=\"pkg-note-{{$marker}}\">{{noteTitle\x20$marker\x20|\x20html}}s</h2>\x0a\x09\x09\x09<ul\x20style=\"list-style:\x20none;\x20padding:\x200;\">\x0a\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09<li><a\x20href=\"{{posLink_url\x20$\x20.}}\"\x20style=\"float:\x20left;\">&#x261e;</a>\x20{{comment_html\x20.Body}}</li>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09</ul>\x0a\x09\x09{{end}}\x0a\x09{{end}}\x0a{{end}}\x0a\x0a\x0a{{with\x20.PAst}}\x0a\x09{{range\x20$filename,\x20$ast\x20:=\x20.}}\x0a\x09\x09<a\x20href=\"{{$filename|srcLink|html}}\">{{$filename|filename|html}}</a>:<pre>{{node_html\x20$\x20$ast\x20false}}</pre>\x0a\x09{{end}}\x0a{{end}}\x0a\x0a\x0a{{/*\x20Nested\x20render\x20directory\x20*/}}\x0a{{-\x20define\x20\"item\"\x20-}}\x0a{{-\x20range\x20.\x20}}\x0a\x09<tr>\x0a\x09\x09<td>\x0a\x09\x09\x09<a\x20href=\"/pkg/{{.ImportPath}}\">{{html\x20.Path}}</a>\x0a\x09\x09</td>\x0a\x09\x09<td>{{html\x20.Synopsis}}</td>\x0a\x0a\x09\x09{{-\x20if\x20gt\x20(len\x20.SubDirectories)\x200\x20}}\x0a\x09\x09{{template\x20\"item\"\x20.SubDirectories}}\x0a\x09\x09{{-\x20end}}\x0a\x09</tr>\x0a{{-\x20end\x20-}}\x0a{{-\x20end\x20-}}\x0a\x0a{{with\x20.Directory}}\x0a\x09{{if\x20$.DocPackage}}\x0a\x09\x09<h2\x20id=\"pkg-subdirectories\">Subdirectories</h2>\x0a\x09{{end}}\x0a\x0a\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09<tr>\x0a\x09\x09\x09<th\x20class=\"pkg-name\">Package</th>\x0a\x09\x09\x09<th\x20class=\"pkg-synopsis\">Synopsis</th>\x0a\x09\x09</tr>\x0a\x09\x09<tr>\x0a\x09\x09\x09<td><a\x20href=\"..\">..</a></td>\x0a\x09\x09\x09<td></td>\x0a\x09\x09</tr>\x0a\x09\x09{{template\x20\"item\"\x20.SubDirectories}}\x0a\x09</table>\x0a{{end}}\x0a<!--\x20end\x20package.html\x20-->\x0a",
+	"package.html": "<!--\x20package.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{with\x20.DocPackage}}\x0a\x09{{if\x20$.IsMain}}\x0a\x09\x09{{/*\x20command\x20documentation\x20*/}}\x0a\x09\x09{{comment_html\x20.Doc}}\x0a\x09{{else}}\x0a\x09\x09{{/*\x20package\x20documentation\x20*/}}\x0a\x09\x09<div\x20id=\"short-nav\">\x0a\x09\x09\x09<dl>\x0a\x09\x09\x09\x09<dd><code>import\x20\"{{html\x20.ImportPath}}\"</code></dd>\x0a\x09\x09\x09</dl>\x0a\x09\x09\x09{{with\x20$.Vendored}}\x0a\x09\x09\x09<dl>\x0a\x09\x09\x09\x09<dd>Vendored:\x20{{html\x20.Path}}\x20{{html\x20.Version}}{{with\x20.Replace}}\x20=&gt;\x20{{html\x20.}}{{end}}</dd>\x0a\x09\x09\x09</dl>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09{{with\x20$.ModuleVersions}}{{$mv\x20:=\x20.}}\x0a\x09\x09\x09<dl>\x0a\x09\x09\x09\x09<dd>Versions:{{if\x20.Default}}\x20{{if\x20.Version}}<a\x20href=\"{{.URL\x20\"\"\x20|\x20html}}{{queryString\x20$.Mode\x20$.Build\x20|\x20html}}\">default</a>{{else}}<strong>default</strong>{{end}}{{end}}{{range\x20.List}}\x20{{if\x20eq\x20.\x20$mv.Version}}<strong>{{html\x20.}}</strong>{{else}}<a\x20href=\"{{$mv.URL\x20.\x20|\x20html}}{{queryString\x20$.Mode\x20$.Build\x20|\x20html}}\">{{html\x20.}}</a>{{end}}{{end}}</dd>\x0a\x09\x09\x09</dl>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09{{with\x20$.Deprecations}}{{with\x20.Package}}\x0a\x09\x09\x09<dl>\x0a\x09\x09\x09\x09<dd>{{template\x20\"deprecated\"\x20.}}</dd>\x0a\x09\x09\x09</dl>\x0a\x09\x09\x09{{end}}{{end}}\x0a\x09\x09\x09{{with\x20$.Platforms}}\x0a\x09\x09\x09<dl>\x0a\x09\x09\x09\x09<dd>Documented\x20for\x20{{range\x20$i,\x20$p\x20:=\x20.Platforms}}{{if\x20$i}},\x20{{end}}{{html\x20$p}}{{end}};\x20declarations\x20not\x20available\x20on\x20all\x20of\x20them\x20are\x20marked\x20with\x20their\x20platforms.</dd>\x0a\x09\x09\x09</dl>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09<dl>\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-overview\"\x20class=\"overviewLink\">Overview</a></dd>\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-index\"\x20class=\"indexLink\">Index</a></dd>\x0a\x0a\x09\x09\x09{{if\x20$.Examples}}\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-examples\"\x20class=\"examplesLink\">Examples</a></dd>\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{if\x20$.Imports}}\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-imports\">Imports</a></dd>\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{if\x20or\x20$.ImportedBy\x20$.Importers}}\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-importers\">Imported\x20by</a></dd>\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{if\x20$.Directory}}\x0a\x09\x09\x09\x09<dd><a\x20href=\"#pkg-subdirectories\">Subdirectories</a></dd>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09</dl>\x0a\x09\x09</div>\x0a\x09\x09<!--\x20The\x20package's\x20Name\x20is\x20printed\x20as\x20title\x20by\x20the\x20top-level\x20template\x20-->\x0a\x09\x09<div\x20id=\"pkg-overview\"\x20class=\"toggleVisible\">\x0a\x09\x09\x09<div\x20class=\"collapsed\">\x0a\x09\x09\x09\x09<h2\x20class=\"toggleButton\"\x20title=\"Click\x20to\x20show\x20Overview\x20section\">Overview\x20\xe2\x96\xb9</h2>\x0a\x09\x09\x09</div>\x0a\x09\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09\x09<h2\x20class=\"toggleButton\"\x20title=\"Click\x20to\x20hide\x20Overview\x20section\">Overview\x20\xe2\x96\xbe</h2>\x0a\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09{{example_html\x20$\x20\"\"}}\x0a\x09\x09\x09</div>\x0a\x09\x09</div>\x0a\x0a\x09\x09<div\x20id=\"pkg-index\"\x20class=\"toggleVisible\">\x0a\x09\x09\x09<div\x20class=\"collapsed\">\x0a\x09\x09\x09\x09<h2\x20class=\"toggleButton\"\x20title=\"Click\x20to\x20show\x20Index\x20section\">Index\x20\xe2\x96\xb9</h2>\x0a\x09\x09\x09</div>\x0a\x0a\x09\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09\x09<h2\x20class=\"toggleButton\"\x20title=\"Click\x20to\x20hide\x20Index\x20section\">Index\x20\xe2\x96\xbe</h2>\x0a\x0a\x09\x09\x09\x09<!--\x20Table\x20of\x20contents\x20for\x20API;\x20must\x20be\x20named\x20manual-nav\x20to\x20turn\x20off\x20auto\x20nav.\x20-->\x0a\x09\x09\x09\x09<div\x20id=\"manual-nav\">\x0a\x09\x09\x09\x09\x09<dl>\x0a\x09\x09\x09\x09\x09{{if\x20.Consts}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#pkg-constants\">Constants</a></dd>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{if\x20.Vars}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#pkg-variables\">Variables</a></dd>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{range\x20.Funcs}}\x0a\x09\x09\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#{{$name_html}}\">{{node_html\x20$\x20.Decl\x20false\x20|\x20sanitize}}</a></dd>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{range\x20.Types}}\x0a\x09\x09\x09\x09\x09\x09{{$tname_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#{{$tname_html}}\">type\x20{{$tname_html}}</a></dd>\x0a\x09\x09\x09\x09\x09\x09{{range\x20.Funcs}}\x0a\x09\x09\x09\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09\x09<dd>&nbsp;\x20&nbsp;\x20<a\x20href=\"#{{$name_html}}\">{{node_html\x20$\x20.Decl\x20false\x20|\x20sanitize}}</a></dd>\x0a\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09\x09{{range\x20.Methods}}\x0a\x09\x09\x09\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09\x09<dd>&nbsp;\x20&nbsp;\x20<a\x20href=\"#{{$tname_html}}.{{$name_html}}\">{{node_html\x20$\x20.Decl\x20false\x20|\x20sanitize}}</a></dd>\x0a\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{if\x20$.Notes}}\x0a\x09\x09\x09\x09\x09\x09{{range\x20$marker,\x20$item\x20:=\x20$.Notes}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20href=\"#pkg-note-{{$marker}}\">{{noteTitle\x20$marker\x20|\x20html}}s</a></dd>\x0a\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09</dl>\x0a\x09\x09\x09\x09</div><!--\x20#manual-nav\x20-->\x0a\x0a\x09\x09\x09{{if\x20$.Examples}}\x0a\x09\x09\x09\x09<div\x20id=\"pkg-examples\">\x0a\x09\x09\x09\x09\x09<h3>Examples</h3>\x0a\x09\x09\x09\x09\x09<div\x20class=\"js-expandAll\x20expandAll\x20collapsed\">(Expand\x20All)</div>\x0a\x09\x09\x09\x09\x09<dl>\x0a\x09\x09\x09\x09\x09{{range\x20$.Examples}}\x0a\x09\x09\x09\x09\x09\x09<dd><a\x20class=\"exampleLink\"\x20href=\"#example_{{.Name}}\">{{example_name\x20.Name}}</a></dd>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09</dl>\x0a\x09\x09\x09\x09</div>\x0a\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09{{with\x20.Filenames}}\x0a\x09\x09\x09\x09<h3>Package\x20files</h3>\x0a\x09\x09\x09\x09<p>\x0a\x09\x09\x09\x09\x09<span\x20style=\"font-size:90%\">\x0a\x09\x09\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09\x09\x09\x09<a\x20href=\"{{.|srcLink|html}}\">{{.|filename|html}}</a>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09</span>\x0a\x09\x09\x09\x09</p>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09</div><!--\x20.expanded\x20-->\x0a\x09\x09</div><!--\x20#pkg-index\x20-->\x0a\x0a\x0a\x09\x09{{with\x20.Consts}}\x0a\x09\x09\x09<h2\x20id=\"pkg-constants\">Constants</h2>\x0a\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09\x09{{template\x20\"values\"\x20(valueListing\x20$\x20.)}}\x0a\x09\x09\x09{{end}}\x0a\x09\x09{{end}}\x0a\x0a\x0a\x09\x09{{with\x20.Vars}}\x0a\x09\x09\x09<h2\x20id=\"pkg-variables\">Variables</h2>\x0a\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09\x09{{template\x20\"values\"\x20(valueListing\x20$\x20.)}}\x0a\x09\x09\x09{{end}}\x0a\x09\x09{{end}}\x0a\x0a\x0a\x09\x09{{range\x20.Funcs}}\x0a\x09\x09\x09{{/*\x20Name\x20is\x20a\x20string\x20-\x20no\x20need\x20for\x20FSet\x20*/}}\x0a\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09{{$deprecated\x20:=\x20deprecated\x20$\x20.Name}}\x0a\x09\x09\x09<h2\x20id=\"{{$name_html}}\">func\x20<a\x20href=\"{{posLink_url\x20$\x20.Decl}}\">{{$name_html}}</a>\x0a\x09\x09\x09\x09<a\x20class=\"permalink\"\x20href=\"#{{$name_html}}\">&#xb6;</a>\x0a\x09\x09\x09\x09{{$since\x20:=\x20since\x20\"func\"\x20\"\"\x20.Name\x20$.DocPackage.ImportPath}}\x0a\x09\x09\x09\x09{{if\x20$since}}<span\x20title=\"Added\x20in\x20{{sinceLabel\x20$since}}\">{{$since}}</span>{{end}}\x0a\x09\x09\x09\x09{{platforms_html\x20$\x20.Decl}}\x0a\x09\x09\x09\x09{{if\x20$deprecated}}{{template\x20\"deprecated\"\x20$deprecated}}{{end}}\x0a\x09\x09\x09</h2>\x0a\x09\x09\x09<div{{if\x20$deprecated}}\x20class=\"toggle\"{{end}}>\x0a\x09\x09\x09\x09{{if\x20$deprecated}}<div\x20class=\"collapsed\"><p\x20class=\"toggleButton\">\xe2\x96\xb9\x20Show\x20deprecated\x20function</p></div>{{end}}\x0a\x09\x09\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09\x09\x09{{if\x20$deprecated}}<p\x20class=\"toggleButton\">\xe2\x96\xbe\x20Hide\x20deprecated\x20function</p>{{end}}\x0a\x09\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09\x09{{example_html\x20$\x20.Name}}\x0a\x09\x09\x09\x09</div>\x0a\x09\x09\x09</div>\x0a\x09\x09{{end}}\x0a\x0a\x0a\x09\x09{{range\x20.Types}}\x0a\x09\x09\x09{{$tname\x20:=\x20.Name}}\x0a\x09\x09\x09{{$tname_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09{{$deprecated\x20:=\x20deprecated\x20$\x20.Name}}\x0a\x09\x09\x09<h2\x20id=\"{{$tname_html}}\">type\x20<a\x20href=\"{{posLink_url\x20$\x20.Decl}}\">{{$tname_html}}</a>\x0a\x09\x09\x09\x09<a\x20class=\"permalink\"\x20href=\"#{{$tname_html}}\">&#xb6;</a>\x0a\x09\x09\x09\x09{{$since\x20:=\x20since\x20\"type\"\x20\"\"\x20.Name\x20$.DocPackage.ImportPath}}\x0a\x09\x09\x09\x09{{if\x20$since}}<span\x20title=\"Added\x20in\x20{{sinceLabel\x20$since}}\">{{$since}}</span>{{end}}\x0a\x09\x09\x09\x09{{platforms_html\x20$\x20.Decl}}\x0a\x09\x09\x09\x09{{if\x20$deprecated}}{{template\x20\"deprecated\"\x20$deprecated}}{{end}}\x0a\x09\x09\x09</h2>\x0a\x09\x09\x09<div{{if\x20$deprecated}}\x20class=\"toggle\"{{end}}>\x0a\x09\x09\x09\x09{{if\x20$deprecated}}<div\x20class=\"collapsed\"><p\x20class=\"toggleButton\">\xe2\x96\xb9\x20Show\x20deprecated\x20type</p></div>{{end}}\x0a\x09\x09\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09\x09\x09{{if\x20$deprecated}}<p\x20class=\"toggleButton\">\xe2\x96\xbe\x20Hide\x20deprecated\x20type</p>{{end}}\x0a\x09\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09\x09\x09{{with\x20deprecatedFields\x20$\x20$tname}}\x0a\x09\x09\x09\x09\x09\x09<p>{{template\x20\"deprecated\"\x20(deprecated\x20$\x20(printf\x20\"%s.%s\"\x20$tname\x20(index\x20.\x200)))}}\x20{{range\x20$i,\x20$name\x20:=\x20.}}{{if\x20$i}},\x20{{end}}<code>{{html\x20$name}}</code>{{end}}</p>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{with\x20index\x20$.Implements\x20$tname}}\x0a\x09\x09\x09\x09\x09\x09{{if\x20.Implements}}\x0a\x09\x09\x09\x09\x09\x09\x09<p>Implements:</p>\x0a\x09\x09\x09\x09\x09\x09\x09<ul>\x0a\x09\x09\x09\x09\x09\x09\x09{{range\x20.Implements}}\x0a\x09\x09\x09\x09\x09\x09\x09\x09<li><a\x20href=\"{{if\x20ne\x20.ImportPath\x20$.DocPackage.ImportPath}}/pkg/{{.ImportPath}}/{{queryString\x20$.Mode\x20$.Build\x20|\x20html}}{{end}}#{{.Name}}\">{{if\x20ne\x20.ImportPath\x20$.DocPackage.ImportPath}}{{.Package}}.{{end}}{{.Name}}</a>{{if\x20.Pointer}}\x20(by\x20*{{$tname}}){{end}}</li>\x0a\x09\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09\x09\x09</ul>\x0a\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09\x09{{if\x20.ImplementedBy}}\x0a\x09\x09\x09\x09\x09\x09\x09<p>Implemented\x20by:</p>\x0a\x09\x09\x09\x09\x09\x09\x09<ul>\x0a\x09\x09\x09\x09\x09\x09\x09{{range\x20.ImplementedBy}}\x0a\x09\x09\x09\x09\x09\x09\x09\x09<li><a\x20href=\"{{if\x20ne\x20.ImportPath\x20$.DocPackage.ImportPath}}/pkg/{{.ImportPath}}/{{queryString\x20$.Mode\x20$.Build\x20|\x20html}}{{end}}#{{.Name}}\">{{if\x20.Pointer}}*{{end}}{{if\x20ne\x20.ImportPath\x20$.DocPackage.ImportPath}}{{.Package}}.{{end}}{{.Name}}</a></li>\x0a\x09\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09\x09\x09</ul>\x0a\x09\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{range\x20.Consts}}\x0a\x09\x09\x09\x09\x09\x09{{template\x20\"values\"\x20(valueListing\x20$\x20.)}}\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{range\x20.Vars}}\x0a\x09\x09\x09\x09\x09\x09{{template\x20\"values\"\x20(valueListing\x20$\x20.)}}\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{example_html\x20$\x20$tname}}\x0a\x0a\x09\x09\x09\x09\x09{{range\x20.Funcs}}\x0a\x09\x09\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09{{$deprecated\x20:=\x20deprecated\x20$\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09<h3\x20id=\"{{$name_html}}\">func\x20<a\x20href=\"{{posLink_url\x20$\x20.Decl}}\">{{$name_html}}</a>\x0a\x09\x09\x09\x09\x09\x09\x09<a\x20class=\"permalink\"\x20href=\"#{{$name_html}}\">&#xb6;</a>\x0a\x09\x09\x09\x09\x09\x09\x09{{$since\x20:=\x20since\x20\"func\"\x20\"\"\x20.Name\x20$.DocPackage.ImportPath}}\x0a\x09\x09\x09\x09\x09\x09\x09{{if\x20$since}}<span\x20title=\"Added\x20in\x20{{sinceLabel\x20$since}}\">{{$since}}</span>{{end}}\x0a\x09\x09\x09\x09\x09\x09\x09{{platforms_html\x20$\x20.Decl}}\x0a\x09\x09\x09\x09\x09\x09\x09{{if\x20$deprecated}}{{template\x20\"deprecated\"\x20$deprecated}}{{end}}\x0a\x09\x09\x09\x09\x09\x09</h3>\x0a\x09\x09\x09\x09\x09\x09<div{{if\x20$deprecated}}\x20class=\"toggle\"{{end}}>\x0a\x09\x09\x09\x09\x09\x09\x09{{if\x20$deprecated}}<div\x20class=\"collapsed\"><p\x20class=\"toggleButton\">\xe2\x96\xb9\x20Show\x20deprecated\x20function</p></div>{{end}}\x0a\x09\x09\x09\x09\x09\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09\x09\x09\x09\x09\x09{{if\x20$deprecated}}<p\x20class=\"toggleButton\">\xe2\x96\xbe\x20Hide\x20deprecated\x20function</p>{{end}}\x0a\x09\x09\x09\x09\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09\x09\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09\x09\x09\x09\x09{{example_html\x20$\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09\x09</div>\x0a\x09\x09\x09\x09\x09\x09</div>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x0a\x09\x09\x09\x09\x09{{range\x20.Methods}}\x0a\x09\x09\x09\x09\x09\x09{{$name_html\x20:=\x20html\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09{{$deprecated\x20:=\x20deprecated\x20$\x20(printf\x20\"%s.%s\"\x20$tname\x20.Name)}}\x0a\x09\x09\x09\x09\x09\x09<h3\x20id=\"{{$tname_html}}.{{$name_html}}\">func\x20({{html\x20.Recv}})\x20<a\x20href=\"{{posLink_url\x20$\x20.Decl}}\">{{$name_html}}</a>\x0a\x09\x09\x09\x09\x09\x09\x09<a\x20class=\"permalink\"\x20href=\"#{{$tname_html}}.{{$name_html}}\">&#xb6;</a>\x0a\x09\x09\x09\x09\x09\x09\x09{{$since\x20:=\x20since\x20\"method\"\x20.Recv\x20.Name\x20$.DocPackage.ImportPath}}\x0a\x09\x09\x09\x09\x09\x09\x09{{if\x20$since}}<span\x20title=\"Added\x20in\x20{{sinceLabel\x20$since}}\">{{$since}}</span>{{end}}\x0a\x09\x09\x09\x09\x09\x09\x09{{platforms_html\x20$\x20.Decl}}\x0a\x09\x09\x09\x09\x09\x09\x09{{if\x20$deprecated}}{{template\x20\"deprecated\"\x20$deprecated}}{{end}}\x0a\x09\x09\x09\x09\x09\x09</h3>\x0a\x09\x09\x09\x09\x09\x09<div{{if\x20$deprecated}}\x20class=\"toggle\"{{end}}>\x0a\x09\x09\x09\x09\x09\x09\x09{{if\x20$deprecated}}<div\x20class=\"collapsed\"><p\x20class=\"toggleButton\">\xe2\x96\xb9\x20Show\x20deprecated\x20method</p></div>{{end}}\x0a\x09\x09\x09\x09\x09\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09\x09\x09\x09\x09\x09{{if\x20$deprecated}}<p\x20class=\"toggleButton\">\xe2\x96\xbe\x20Hide\x20deprecated\x20method</p>{{end}}\x0a\x09\x09\x09\x09\x09\x09\x09\x09<pre>{{node_html\x20$\x20.Decl\x20true}}</pre>\x0a\x09\x09\x09\x09\x09\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09\x09\x09\x09\x09\x09{{$name\x20:=\x20printf\x20\"%s_%s\"\x20$tname\x20.Name}}\x0a\x09\x09\x09\x09\x09\x09\x09\x09{{example_html\x20$\x20$name}}\x0a\x09\x09\x09\x09\x09\x09\x09</div>\x0a\x09\x09\x09\x09\x09\x09</div>\x0a\x09\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09</div>\x0a\x09\x09\x09</div>\x0a\x09\x09{{end}}\x0a\x09{{end}}\x0a\x0a\x0a\x09{{with\x20$.Notes}}\x0a\x09\x09{{range\x20$marker,\x20$content\x20:=\x20.}}\x0a\x09\x09\x09<h2\x20id=\"pkg-note-{{$marker}}\">{{noteTitle\x20$marker\x20|\x20html}}s</h2>\x0a\x09\x09\x09<ul\x20style=\"list-style:\x20none;\x20padding:\x200;\">\x0a\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09<li><a\x20href=\"{{posLink_url\x20$\x20.}}\"\x20style=\"float:\x20left;\">&#x261e;</a>\x20{{comment_html\x20.Body}}</li>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09</ul>\x0a\x09\x09{{end}}\x0a\x09{{end}}\x0a\x0a\x09{{with\x20$.Imports}}\x0a\x09\x09<h2\x20id=\"pkg-imports\">Imports</h2>\x0a\x09\x09<p>Import\x20graph:\x20{{$query\x20:=\x20queryString\x20$.Mode\x20$.Build}}<a\x20href=\"/graph/{{html\x20$.DocPackage.ImportPath}}{{html\x20$query}}\">SVG</a>,\x20<a\x20href=\"/graph/{{html\x20$.DocPackage.ImportPath}}{{if\x20$query}}{{html\x20$query}}&amp;{{else}}?{{end}}format=dot\">DOT</a></p>\x0a\x09\x09<ul>\x0a\x09\x09{{range\x20.}}\x0a\x09\x09\x09<li><a\x20href=\"/{{pkgLink\x20.\x20|\x20html}}{{queryString\x20$.Mode\x20$.Build\x20|\x20html}}\">{{html\x20.}}</a></li>\x0a\x09\x09{{end}}\x0a\x09\x09</ul>\x0a\x09{{end}}\x0a\x0a\x09{{if\x20$.Importers}}\x0a\x09\x09<h2\x20id=\"pkg-importers\">Imported\x20by\x20(directly\x20or\x20indirectly)</h2>\x0a\x09\x09<ul>\x0a\x09\x09{{range\x20$.Importers}}\x0a\x09\x09\x09<li><a\x20href=\"/{{pkgLink\x20.\x20|\x20html}}{{queryString\x20$.Mode\x20$.Build\x20|\x20html}}\">{{html\x20.}}</a></li>\x0a\x09\x09{{end}}\x0a\x09\x09</ul>\x0a\x09{{else}}{{with\x20$.ImportedBy}}\x0a\x09\x09<h2\x20id=\"pkg-importers\">Imported\x20by</h2>\x0a\x09\x09<ul>\x0a\x09\x09{{range\x20.}}\x0a\x09\x09\x09<li><a\x20href=\"/{{pkgLink\x20.\x20|\x20html}}{{queryString\x20$.Mode\x20$.Build\x20|\x20html}}\">{{html\x20.}}</a></li>\x0a\x09\x09{{end}}\x0a\x09\x09</ul>\x0a\x09\x09<p><a\x20href=\"{{queryString\x20(withMode\x20$.Mode\x20\"importers\")\x20$.Build\x20|\x20html}}#pkg-importers\">All\x20packages\x20importing\x20this\x20package,\x20directly\x20or\x20indirectly</a></p>\x0a\x09{{end}}{{end}}\x0a{{end}}\x0a\x0a\x0a{{with\x20.PAst}}\x0a\x09{{range\x20$filename,\x20$ast\x20:=\x20.}}\x0a\x09\x09<a\x20href=\"{{$filename|srcLink|html}}\">{{$filename|filename|html}}</a>:<pre>{{node_html\x20$\x20$ast\x20false}}</pre>\x0a\x09{{end}}\x0a{{end}}\x0a\x0a\x0a{{/*\x20Badge\x20of\x20a\x20deprecated\x20declaration;\x20the\x20argument\x20is\x20the\x20deprecation\x20notice\x20*/}}\x0a{{-\x20define\x20\"deprecated\"\x20-}}\x0a<span\x20class=\"badge\x20badge-warning\"\x20title=\"{{html\x20.}}\">Deprecated</span>\x0a{{-\x20end\x20-}}\x0a\x0a{{/*\x20Constant\x20and\x20variable\x20declarations,\x20collapsed\x20if\x20deprecated\x20*/}}\x0a{{-\x20define\x20\"values\"\x20-}}\x0a{{$info\x20:=\x20.Info}}\x0a{{with\x20.Value}}\x0a\x09{{$names\x20:=\x20deprecatedNames\x20$info\x20.Names}}\x0a\x09{{$deprecated\x20:=\x20eq\x20(len\x20$names)\x20(len\x20.Names)}}\x0a\x09{{with\x20$names}}\x0a\x09\x09<p>{{template\x20\"deprecated\"\x20(deprecated\x20$info\x20(index\x20.\x200))}}{{if\x20not\x20$deprecated}}\x20{{range\x20$i,\x20$name\x20:=\x20.}}{{if\x20$i}},\x20{{end}}<code>{{html\x20$name}}</code>{{end}}{{end}}</p>\x0a\x09{{end}}\x0a\x09<div{{if\x20$deprecated}}\x20class=\"toggle\"{{end}}>\x0a\x09\x09{{if\x20$deprecated}}<div\x20class=\"collapsed\"><p\x20class=\"toggleButton\">\xe2\x96\xb9\x20Show\x20deprecated\x20declaration</p></div>{{end}}\x0a\x09\x09<div\x20class=\"expanded\">\x0a\x09\x09\x09{{if\x20$deprecated}}<p\x20class=\"toggleButton\">\xe2\x96\xbe\x20Hide\x20deprecated\x20declaration</p>{{end}}\x0a\x09\x09\x09{{comment_html\x20.Doc}}\x0a\x09\x09\x09{{platforms_html\x20$info\x20.Decl}}\x0a\x09\x09\x09<pre>{{node_html\x20$info\x20.Decl\x20true}}</pre>\x0a\x09\x09</div>\x0a\x09</div>\x0a{{end}}\x0a{{-\x20end\x20-}}\x0a\x0a{{/*\x20Nested\x20render\x20directory\x20*/}}\x0a{{-\x20define\x20\"item\"\x20-}}\x0a{{-\x20range\x20.Dirs\x20}}\x0a\x09<tr>\x0a\x09\x09<td>\x0a\x09\x09\x09<a\x20href=\"/pkg/{{.ImportPath}}{{html\x20$.Query}}\">{{html\x20.Path}}</a>\x0a\x09\x09\x09{{if\x20.Deprecated}}<span\x20class=\"badge\x20badge-warning\">Deprecated</span>{{end}}\x0a\x09\x09</td>\x0a\x09\x09<td>{{html\x20.Synopsis}}</td>\x0a\x0a\x09\x09{{-\x20if\x20gt\x20(len\x20.SubDirectories)\x200\x20}}\x0a\x09\x09{{template\x20\"item\"\x20(dirListing\x20$.Query\x20.SubDirectories)}}\x0a\x09\x09{{-\x20end}}\x0a\x09</tr>\x0a{{-\x20end\x20-}}\x0a{{-\x20end\x20-}}\x0a\x0a{{with\x20.Directory}}\x0a\x09{{if\x20$.DocPackage}}\x0a\x09\x09<h2\x20id=\"pkg-subdirectories\">Subdirectories</h2>\x0a\x09{{end}}\x0a\x0a\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09<tr>\x0a\x09\x09\x09<th\x20class=\"pkg-name\">Package</th>\x0a\x09\x09\x09<th\x20class=\"pkg-synopsis\">Synopsis</th>\x0a\x09\x09</tr>\x0a\x09\x09<tr>\x0a\x09\x09\x09<td><a\x20href=\"../{{queryString\x20$.Mode\x20$.Build\x20|\x20html}}\">..</a></td>\x0a\x09\x09\x09<td></td>\x0a\x09\x09</tr>\x0a\x09\x09{{template\x20\"item\"\x20(dirListing\x20(queryString\x20$.Mode\x20$.Build)\x20.SubDirectories)}}\x0a\x09</table>\x0a{{end}}\x0a<!--\x20end\x20package.html\x20-->\x0a",
+
+	"package.md": "{{/*\x0a\x09package.md\x20renders\x20package\x20pages\x20as\x20Markdown,\x20in\x20the\x20Markdown\x20mode\x0a\x09(?m=md).\x20The\x20blank\x20lines\x20are\x20tidied\x20after\x20execution:\x20actions\x20may\x20be\x0a\x09written\x20on\x20lines\x20of\x20their\x20own,\x20but\x20text\x20must\x20not\x20be\x20indented.\x0a*/}}\x0a{{with\x20.DocPackage}}\x0a{{if\x20$.IsMain}}\x0a#\x20Command\x20{{filename\x20$.Dirname\x20|\x20text_md}}\x0a\x0a{{comment_md\x20.Doc\x202}}\x0a{{else}}\x0a#\x20Package\x20{{text_md\x20.Name}}\x0a\x0a{{code_md\x20\"go\"\x20(printf\x20\"import\x20%q\"\x20.ImportPath)}}\x0a\x0a{{with\x20$.Vendored}}\x0aVendored:\x20{{text_md\x20.Path}}\x20{{text_md\x20.Version}}{{with\x20.Replace}}\x20=>\x20{{text_md\x20.}}{{end}}\x0a{{end}}\x0a\x0a##\x20Overview\x0a\x0a{{comment_md\x20.Doc\x203}}\x0a\x0a{{example_md\x20$\x20\"\"}}\x0a\x0a##\x20Index\x0a{{if\x20.Consts}}\x0a-\x20[Constants](#constants)\x0a{{-\x20end}}\x0a{{-\x20if\x20.Vars}}\x0a-\x20[Variables](#variables)\x0a{{-\x20end}}\x0a{{-\x20range\x20.Funcs}}\x0a-\x20[`{{node\x20$\x20.Decl\x20|\x20sanitize}}`](#{{anchor_md\x20(printf\x20\"func\x20%s\"\x20.Name)}})\x0a{{-\x20end}}\x0a{{-\x20range\x20.Types}}\x0a-\x20[`type\x20{{.Name}}`](#{{anchor_md\x20(printf\x20\"type\x20%s\"\x20.Name)}})\x0a{{-\x20range\x20.Funcs}}\x0a\x20\x20-\x20[`{{node\x20$\x20.Decl\x20|\x20sanitize}}`](#{{anchor_md\x20(printf\x20\"func\x20%s\"\x20.Name)}})\x0a{{-\x20end}}\x0a{{-\x20range\x20.Methods}}\x0a\x20\x20-\x20[`{{node\x20$\x20.Decl\x20|\x20sanitize}}`](#{{anchor_md\x20(printf\x20\"func\x20(%s)\x20%s\"\x20.Recv\x20.Name)}})\x0a{{-\x20end}}\x0a{{-\x20end}}\x0a{{-\x20range\x20$marker,\x20$item\x20:=\x20$.Notes}}\x0a-\x20[{{noteTitle\x20$marker\x20|\x20text_md}}s](#{{noteTitle\x20$marker\x20|\x20printf\x20\"%ss\"\x20|\x20anchor_md}})\x0a{{-\x20end}}\x0a\x0a{{if\x20$.Examples}}\x0a###\x20Examples\x0a\x0a{{range\x20$.Examples}}\x0a-\x20{{example_name\x20.Name\x20|\x20text_md}}\x0a{{-\x20end}}\x0a{{end}}\x0a\x0a{{with\x20.Consts}}\x0a##\x20Constants\x0a\x0a{{range\x20.}}\x0a{{code_md\x20\"go\"\x20(node\x20$\x20.Decl)}}\x0a\x0a{{comment_md\x20.Doc\x204}}\x0a{{end}}\x0a{{end}}\x0a\x0a{{with\x20.Vars}}\x0a##\x20Variables\x0a\x0a{{range\x20.}}\x0a{{code_md\x20\"go\"\x20(node\x20$\x20.Decl)}}\x0a\x0a{{comment_md\x20.Doc\x204}}\x0a{{end}}\x0a{{end}}\x0a\x0a{{range\x20.Funcs}}\x0a##\x20func\x20{{text_md\x20.Name}}\x0a\x0a{{code_md\x20\"go\"\x20(node\x20$\x20.Decl)}}\x0a\x0a{{comment_md\x20.Doc\x204}}\x0a\x0a{{example_md\x20$\x20.Name}}\x0a{{end}}\x0a\x0a{{range\x20.Types}}{{$tname\x20:=\x20.Name}}\x0a##\x20type\x20{{text_md\x20.Name}}\x0a\x0a{{code_md\x20\"go\"\x20(node\x20$\x20.Decl)}}\x0a\x0a{{comment_md\x20.Doc\x204}}\x0a\x0a{{range\x20.Consts}}\x0a{{code_md\x20\"go\"\x20(node\x20$\x20.Decl)}}\x0a\x0a{{comment_md\x20.Doc\x204}}\x0a{{end}}\x0a\x0a{{range\x20.Vars}}\x0a{{code_md\x20\"go\"\x20(node\x20$\x20.Decl)}}\x0a\x0a{{comment_md\x20.Doc\x204}}\x0a{{end}}\x0a\x0a{{example_md\x20$\x20$tname}}\x0a\x0a{{range\x20.Funcs}}\x0a###\x20func\x20{{text_md\x20.Name}}\x0a\x0a{{code_md\x20\"go\"\x20(node\x20$\x20.Decl)}}\x0a\x0a{{comment_md\x20.Doc\x204}}\x0a\x0a{{example_md\x20$\x20.Name}}\x0a{{end}}\x0a\x0a{{range\x20.Methods}}\x0a###\x20func\x20({{text_md\x20.Recv}})\x20{{text_md\x20.Name}}\x0a\x0a{{code_md\x20\"go\"\x20(node\x20$\x20.Decl)}}\x0a\x0a{{comment_md\x20.Doc\x204}}\x0a\x0a{{example_md\x20$\x20(printf\x20\"%s_%s\"\x20$tname\x20.Name)}}\x0a{{end}}\x0a{{end}}\x0a{{end}}\x0a\x0a{{range\x20$marker,\x20$content\x20:=\x20$.Notes}}\x0a##\x20{{noteTitle\x20$marker\x20|\x20text_md}}s\x0a\x0a{{range\x20.}}\x0a{{comment_md\x20.Body\x203}}\x0a{{end}}\x0a{{end}}\x0a{{end}}\x0a\x0a{{with\x20.PAst}}\x0a{{range\x20$filename,\x20$ast\x20:=\x20.}}\x0a{{filename\x20$filename\x20|\x20text_md}}:\x0a\x0a{{code_md\x20\"go\"\x20(node\x20$\x20$ast)}}\x0a{{end}}\x0a{{end}}\x0a\x0a{{if\x20not\x20.DocPackage}}\x0a{{if\x20eq\x20.Dirname\x20\"/src\"}}\x0a#\x20Packages\x0a{{else}}\x0a#\x20Directory\x20{{text_md\x20.Dirname}}\x0a{{end}}\x0a{{end}}\x0a\x0a{{with\x20.Directory}}\x0a{{with\x20.SubDirectories}}\x0a##\x20Subdirectories\x0a\x0a{{template\x20\"directories\"\x20.}}\x0a{{end}}\x0a{{end}}\x0a\x0a{{/*\x20Flat\x20list\x20of\x20a\x20directory\x20tree,\x20with\x20the\x20synopses\x20of\x20its\x20packages\x20*/}}\x0a{{-\x20define\x20\"directories\"\x20-}}\x0a{{range\x20.}}\x0a-\x20`{{.ImportPath}}`{{with\x20.Synopsis}}:\x20{{text_md\x20.}}{{end}}\x0a{{-\x20with\x20.SubDirectories}}{{template\x20\"directories\"\x20.}}{{end}}\x0a{{-\x20end}}\x0a{{-\x20end\x20-}}\x0a",
+
+	"search.html": "<!--\x20search.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{$query_url\x20:=\x20urlquery\x20.Query}}\x0a{{with\x20.Alert}}\x0a\x09<p>\x0a\x09\x09<span\x20class=\"alert\"\x20style=\"font-size:120%\">{{html\x20.}}</span>\x0a\x09</p>\x0a{{end}}\x0a\x0a{{with\x20.Hit}}\x0a\x09<div\x20id=\"manual-nav\">\x0a\x09\x09<dl>\x0a\x09\x09{{range\x20.}}\x0a\x09\x09\x09<dd><a\x20href=\"#pkg-{{.Kind.Name}}\">{{.Kind.Name}}</a></dd>\x0a\x09\x09{{end}}\x0a\x09\x09{{if\x20$.Textual}}\x0a\x09\x09\x09<dd><a\x20href=\"#pkg-textual\">Textual\x20occurrences</a></dd>\x0a\x09\x09{{end}}\x0a\x09\x09</dl>\x0a\x09</div>\x0a\x0a\x09{{range\x20.}}\x0a\x09\x09<h2\x20id=\"pkg-{{.Kind.Name}}\">{{.Kind.Name}}</h2>\x0a\x09\x09{{range\x20.Spots}}\x0a\x09\x09\x09{{$line\x20:=\x20infoLine\x20.Info}}\x0a\x09\x09\x09{{$src_html\x20:=\x20.File.Path\x20|\x20html}}\x0a\x09\x09\x09<p>\x0a\x09\x09\x09\x09<a\x20href=\"{{queryLink\x20.File.Path\x20$query_url\x20$line\x20|\x20html}}\">{{$src_html}}:{{$line}}</a>\x0a\x09\x09\x09\x09<span\x20class=\"text-muted\">{{infoKind_html\x20.Info}}\x20in\x20package\x20{{html\x20.File.Pak.Name}}</span>\x0a\x09\x09\x09</p>\x0a\x09\x09\x09{{if\x20.Info.IsIndex}}\x0a\x09\x09\x09\x09{{infoSnippet_html\x20.Info}}\x0a\x09\x09\x09{{end}}\x0a\x09\x09{{end}}\x0a\x09{{end}}\x0a{{end}}\x0a\x0a{{with\x20.Textual}}\x0a\x09<h2\x20id=\"pkg-textual\">\x0a\x09\x09{{if\x20$.Complete}}\x0a\x09\x09\x09{{html\x20$.Found}}\x20textual\x20occurrence{{if\x20gt\x20$.Found\x201}}s{{end}}\x0a\x09\x09{{else}}\x0a\x09\x09\x09More\x20than\x20{{html\x20$.Found}}\x20textual\x20occurrences\x0a\x09\x09{{end}}\x0a\x09</h2>\x0a\x09{{if\x20not\x20$.Complete}}\x0a\x09\x09<p>\x0a\x09\x09\x09<span\x20class=\"alert\"\x20style=\"font-size:120%\">Not\x20all\x20files\x20or\x20lines\x20containing\x20\"{{html\x20$.Query}}\"\x20are\x20shown.</span>\x0a\x09\x09</p>\x0a\x09{{end}}\x0a\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09<tr>\x0a\x09\x09\x09<th\x20class=\"pkg-name\">File</th>\x0a\x09\x09\x09<th>Lines</th>\x0a\x09\x09</tr>\x0a\x09\x09{{range\x20.}}\x0a\x09\x09\x09{{$file\x20:=\x20.Filename}}\x0a\x09\x09\x09<tr>\x0a\x09\x09\x09\x09<td><a\x20href=\"{{queryLink\x20$file\x20$query_url\x200\x20|\x20html}}\">{{$file\x20|\x20html}}</a></td>\x0a\x09\x09\x09\x09<td>\x0a\x09\x09\x09\x09{{range\x20.Lines}}\x0a\x09\x09\x09\x09\x09<a\x20href=\"{{queryLink\x20$file\x20$query_url\x20.\x20|\x20html}}\">{{html\x20.}}</a>\x0a\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09\x09</td>\x0a\x09\x09\x09</tr>\x0a\x09\x09{{end}}\x0a\x09</table>\x0a{{end}}\x0a<!--\x20end\x20search.html\x20-->\x0a",
+
+	"refs.html": "<!--\x20refs.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{with\x20.Alert}}\x0a\x09<p>\x0a\x09\x09<span\x20class=\"alert\"\x20style=\"font-size:120%\">{{html\x20.}}</span>\x0a\x09</p>\x0a{{end}}\x0a\x0a{{with\x20.Idents}}\x0a\x09<p>\x0a\x09\x09Package\x20<a\x20href=\"/{{pkgLink\x20$.ImportPath\x20|\x20html}}{{html\x20$.Query}}\">{{html\x20$.ImportPath}}</a>\x0a\x09</p>\x0a\x0a\x09<div\x20id=\"manual-nav\">\x0a\x09\x09<dl>\x0a\x09\x09{{range\x20.}}\x0a\x09\x09\x09<dd><a\x20href=\"#{{.Name}}\">{{html\x20.Name}}</a></dd>\x0a\x09\x09{{end}}\x0a\x09\x09</dl>\x0a\x09</div>\x0a\x0a\x09{{range\x20.}}\x0a\x09\x09{{$name\x20:=\x20.Name}}\x0a\x09\x09<h2\x20id=\"{{$name}}\">\x0a\x09\x09\x09{{html\x20$name}}\x0a\x09\x09\x09<span\x20class=\"text-muted\">{{html\x20.Found}}\x20reference{{if\x20ne\x20.Found\x201}}s{{end}}</span>\x0a\x09\x09</h2>\x0a\x09\x09{{if\x20not\x20.Complete}}\x0a\x09\x09\x09<p>\x0a\x09\x09\x09\x09<span\x20class=\"alert\"\x20style=\"font-size:120%\">Not\x20all\x20references\x20to\x20{{html\x20$name}}\x20are\x20shown.</span>\x0a\x09\x09\x09</p>\x0a\x09\x09{{end}}\x0a\x09\x09{{range\x20.Packages}}\x0a\x09\x09\x09<h3><a\x20href=\"{{srcLink\x20.Path\x20|\x20html}}\">{{html\x20.Path}}</a></h3>\x0a\x09\x09\x09{{range\x20.Files}}\x0a\x09\x09\x09\x09{{$file\x20:=\x20.Path}}\x0a\x09\x09\x09\x09<p>{{html\x20$file}}</p>\x0a\x09\x09\x09\x09<pre>{{range\x20.Lines}}<a\x20href=\"{{srcPosLink_url\x20$file\x20.Line\x20.Low\x20.High}}\">{{html\x20.Line}}</a>\x09{{html\x20.Before}}<b>{{html\x20.Ident}}</b>{{html\x20.After}}\x0a{{end}}</pre>\x0a\x09\x09\x09{{end}}\x0a\x09\x09{{end}}\x0a\x09{{end}}\x0a{{end}}\x0a<!--\x20end\x20refs.html\x20-->\x0a",
+
+	"deprecated.html": "<!--\x20deprecated.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{with\x20.Alert}}\x0a\x09<p>\x0a\x09\x09<span\x20class=\"alert\"\x20style=\"font-size:120%\">{{html\x20.}}</span>\x0a\x09</p>\x0a{{end}}\x0a\x0a{{with\x20.Packages}}\x0a\x09<div\x20id=\"manual-nav\">\x0a\x09\x09<dl>\x0a\x09\x09{{range\x20.}}\x0a\x09\x09\x09<dd><a\x20href=\"#{{html\x20.ImportPath}}\">{{html\x20.ImportPath}}</a></dd>\x0a\x09\x09{{end}}\x0a\x09\x09</dl>\x0a\x09</div>\x0a\x0a\x09{{range\x20.}}\x0a\x09\x09{{$pkglink\x20:=\x20pkgLink\x20.ImportPath}}\x0a\x09\x09<h2\x20id=\"{{html\x20.ImportPath}}\">\x0a\x09\x09\x09<a\x20href=\"/{{html\x20$pkglink}}/\">{{html\x20.ImportPath}}</a>\x0a\x09\x09\x09{{if\x20.Notice}}<span\x20class=\"badge\x20badge-warning\">Deprecated</span>{{end}}\x0a\x09\x09</h2>\x0a\x09\x09{{with\x20.Notice}}<p>{{html\x20.}}</p>{{end}}\x0a\x09\x09{{with\x20.Decls}}\x0a\x09\x09\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09\x09\x09<tr>\x0a\x09\x09\x09\x09\x09<th>Declaration</th>\x0a\x09\x09\x09\x09\x09<th>Notice</th>\x0a\x09\x09\x09\x09</tr>\x0a\x09\x09\x09{{range\x20.}}\x0a\x09\x09\x09\x09<tr>\x0a\x09\x09\x09\x09\x09<td>{{html\x20.Kind}}\x20<a\x20href=\"/{{html\x20$pkglink}}/#{{html\x20.Fragment}}\">{{html\x20.Name}}</a></td>\x0a\x09\x09\x09\x09\x09<td>{{html\x20.Notice}}\x20<a\x20href=\"{{srcPosLink_url\x20.Path\x20.Line\x200\x200}}\"\x20class=\"text-muted\">{{filename\x20.Path\x20|\x20html}}:{{html\x20.Line}}</a></td>\x0a\x09\x09\x09\x09</tr>\x0a\x09\x09\x09{{end}}\x0a\x09\x09\x09</table>\x0a\x09\x09{{end}}\x0a\x09{{end}}\x0a{{end}}\x0a<!--\x20end\x20deprecated.html\x20-->\x0a",
+
+	"apidiff.html": "<!--\x20apidiff.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{define\x20\"changes\"}}\x0a\x09<table\x20class=\"table\x20table-bordered\">\x0a\x09{{range\x20.}}\x0a\x09\x09<tr>\x0a\x09\x09\x09<td>\x0a\x09\x09\x09\x09<code>{{html\x20.Name}}</code>\x0a\x09\x09\x09\x09{{if\x20.Compatible}}\x0a\x09\x09\x09\x09\x09<span\x20class=\"badge\x20badge-success\">compatible</span>\x0a\x09\x09\x09\x09{{else}}\x0a\x09\x09\x09\x09\x09<span\x20class=\"badge\x20badge-danger\">incompatible</span>\x0a\x09\x09\x09\x09{{end}}\x0a\x09\x09\x09</td>\x0a\x09\x09\x09<td>\x0a\x09\x09\x09\x09{{with\x20.Old}}<pre>-\x20{{html\x20.}}</pre>{{end}}\x0a\x09\x09\x09\x09{{with\x20.New}}<pre>+\x20{{html\x20.}}</pre>{{end}}\x0a\x09\x09\x09</td>\x0a\x09\x09</tr>\x0a\x09{{end}}\x0a\x09</table>\x0a{{end}}\x0a\x0a<form\x20method=\"GET\"\x20action=\"/apidiff\">\x0a\x09<div\x20class=\"form-group\">\x0a\x09\x09<label\x20for=\"pkg-old\">Old\x20package\x20tree</label>\x0a\x09\x09<input\x20type=\"text\"\x20class=\"form-control\"\x20id=\"pkg-old\"\x20name=\"old\"\x20value=\"{{html\x20.Old}}\"\x20placeholder=\"/home/gopher/go/pkg/mod/example.com/mod@v1.0.0\">\x0a\x09</div>\x0a\x09<div\x20class=\"form-group\">\x0a\x09\x09<label\x20for=\"pkg-new\">New\x20package\x20tree</label>\x0a\x09\x09<input\x20type=\"text\"\x20class=\"form-control\"\x20id=\"pkg-new\"\x20name=\"new\"\x20value=\"{{html\x20.New}}\"\x20placeholder=\"/home/gopher/go/pkg/mod/example.com/mod@v1.1.0\">\x0a\x09</div>\x0a\x09<div\x20class=\"form-group\">\x0a\x09\x09<label\x20for=\"pkg-pkg\">Package\x20directory</label>\x0a\x09\x09<input\x20type=\"text\"\x20class=\"form-control\"\x20id=\"pkg-pkg\"\x20name=\"pkg\"\x20value=\"{{html\x20.Pkg}}\"\x20placeholder=\"sub/pkg\">\x0a\x09</div>\x0a\x09<button\x20type=\"submit\"\x20class=\"btn\x20btn-primary\">Compare</button>\x0a</form>\x0a\x0a{{with\x20.Diff}}\x0a\x09<p>\x0a\x09\x09Changes\x20of\x20the\x20exported\x20API\x20of\x20<code>{{html\x20.Pkg}}</code>\x0a\x09\x09from\x20<code>{{html\x20.Old}}</code>\x20to\x20<code>{{html\x20.New}}</code>:\x0a\x09\x09{{if\x20.Compatible}}\x0a\x09\x09\x09<span\x20class=\"badge\x20badge-success\">compatible</span>\x0a\x09\x09{{else}}\x0a\x09\x09\x09<span\x20class=\"badge\x20badge-danger\">incompatible</span>\x0a\x09\x09{{end}}\x0a\x09</p>\x0a\x0a\x09{{if\x20not\x20(or\x20.Removed\x20.Changed\x20.Added)}}\x0a\x09\x09<p>No\x20changes.</p>\x0a\x09{{end}}\x0a\x09{{with\x20.Removed}}\x0a\x09\x09<h2\x20id=\"pkg-removed\">Removed</h2>\x0a\x09\x09{{template\x20\"changes\"\x20.}}\x0a\x09{{end}}\x0a\x09{{with\x20.Changed}}\x0a\x09\x09<h2\x20id=\"pkg-changed\">Changed</h2>\x0a\x09\x09{{template\x20\"changes\"\x20.}}\x0a\x09{{end}}\x0a\x09{{with\x20.Added}}\x0a\x09\x09<h2\x20id=\"pkg-added\">Added</h2>\x0a\x09\x09{{template\x20\"changes\"\x20.}}\x0a\x09{{end}}\x0a{{end}}\x0a<!--\x20end\x20apidiff.html\x20-->\x0a",
+
+	"modules.html": "<!--\x20modules.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{with\x20.Alert}}\x0a\x09<p>\x0a\x09\x09<span\x20class=\"alert\"\x20style=\"font-size:120%\">{{html\x20.}}</span>\x0a\x09</p>\x0a{{end}}\x0a\x0a{{with\x20.Modules}}\x0a\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09<tr>\x0a\x09\x09\x09<th>Module</th>\x0a\x09\x09\x09<th>Versions</th>\x0a\x09\x09</tr>\x0a\x09{{range\x20.}}\x0a\x09\x09{{$path\x20:=\x20.Path}}\x0a\x09\x09<tr>\x0a\x09\x09\x09<td\x20id=\"{{html\x20.Path}}\">{{html\x20.Path}}</td>\x0a\x09\x09\x09<td>{{range\x20.Versions}}<a\x20href=\"/mod/{{html\x20$path}}@{{html\x20.}}/\">{{html\x20.}}</a>\x20{{end}}</td>\x0a\x09\x09</tr>\x0a\x09{{end}}\x0a\x09</table>\x0a{{end}}\x0a<!--\x20end\x20modules.html\x20-->\x0a",
+
+	"diagnostics.html": "<!--\x20diagnostics.html\x20-->\x0a<!--\x0a\x09Note:\x20Static\x20(i.e.,\x20not\x20template-generated)\x20href\x20and\x20id\x0a\x09attributes\x20start\x20with\x20\"pkg-\"\x20to\x20make\x20it\x20impossible\x20for\x0a\x09them\x20to\x20conflict\x20with\x20generated\x20attributes\x20(some\x20of\x20which\x0a\x09correspond\x20to\x20Go\x20identifiers).\x0a-->\x0a{{with\x20.Alert}}\x0a\x09<p>\x0a\x09\x09<span\x20class=\"alert\"\x20style=\"font-size:120%\">{{html\x20.}}</span>\x0a\x09</p>\x0a{{end}}\x0a\x0a{{with\x20.Missing}}\x0a\x09<h2\x20id=\"pkg-missing\">Missing\x20modules</h2>\x0a\x09{{if\x20$.BuildList.Vendor}}\x0a\x09\x09<p>Vendor\x20them\x20again\x20with\x20<code>go\x20mod\x20vendor</code>.</p>\x0a\x09{{else}}\x0a\x09\x09<p>Download\x20them\x20with\x20<code>go\x20mod\x20download</code>\x20where\x20the\x20network\x20is\x20available.</p>\x0a\x09{{end}}\x0a\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09<tr>\x0a\x09\x09\x09<th>Module</th>\x0a\x09\x09\x09<th>Error</th>\x0a\x09\x09</tr>\x0a\x09{{range\x20.}}\x0a\x09\x09<tr>\x0a\x09\x09\x09<td>{{html\x20.Path}}\x20{{html\x20.Version}}{{with\x20.Replace}}\x20=&gt;\x20{{html\x20.}}{{end}}</td>\x0a\x09\x09\x09<td>{{html\x20.Err}}</td>\x0a\x09\x09</tr>\x0a\x09{{end}}\x0a\x09</table>\x0a{{end}}\x0a\x0a{{with\x20.BuildList}}\x0a\x09{{with\x20.Errors}}\x0a\x09\x09<h2\x20id=\"pkg-errors\">Module\x20graph\x20errors</h2>\x0a\x09\x09<p>The\x20build\x20list\x20may\x20be\x20inaccurate.</p>\x0a\x09\x09<ul>\x0a\x09\x09{{range\x20.}}\x0a\x09\x09\x09<li>{{html\x20.}}</li>\x0a\x09\x09{{end}}\x0a\x09\x09</ul>\x0a\x09{{end}}\x0a\x0a\x09<h2\x20id=\"pkg-buildlist\">Build\x20list\x20of\x20{{with\x20.Vendor}}{{html\x20.}}{{else}}{{with\x20.GoWork}}{{html\x20.}}{{else}}{{html\x20.GoMod}}{{end}}{{end}}</h2>\x0a\x09<table\x20class=\"table\x20table-bordered\x20table-hover\">\x0a\x09\x09<tr>\x0a\x09\x09\x09<th>Module</th>\x0a\x09\x09\x09<th>Directory</th>\x0a\x09\x09</tr>\x0a\x09{{range\x20.Modules}}\x0a\x09\x09<tr>\x0a\x09\x09\x09<td>{{if\x20.FS}}<a\x20href=\"/pkg/{{html\x20.Path}}/\">{{html\x20.Path}}</a>{{else}}{{html\x20.Path}}{{end}}\x20{{html\x20.Version}}{{with\x20.Replace}}\x20=&gt;\x20{{html\x20.}}{{end}}</td>\x0a\x09\x09\x09<td>{{with\x20.Dir}}{{html\x20.}}{{else}}<span\x20class=\"text-muted\">{{with\x20.Err}}{{html\x20.}}{{else}}no\x20vendored\x20packages{{end}}</span>{{end}}</td>\x0a\x09\x09</tr>\x0a\x09{{end}}\x0a\x09</table>\x0a{{end}}\x0a<!--\x20end\x20diagnostics.html\x20-->\x0a",
 
 	"example.html": "<!--\x20example.html\x20-->\x0a<div\x20id=\"example_{{.Name}}\"\x20class=\"toggle\">\x0a\x09<div\x20class=\"collapsed\">\x0a\x09\x09<p\x20class=\"exampleHeading\x20toggleButton\">\xe2\x96\xb9\x20<span\x20class=\"text\">Example{{example_suffix\x20.Name}}</span></p>\x0a\x09</div>\x0a\x09<div\x20class=\"expanded\">\x0a\x09\x09<p\x20class=\"exampleHeading\x20toggleButton\">\xe2\x96\xbe\x20<span\x20class=\"text\">Example{{example_suffix\x20.Name}}</span></p>\x0a\x09\x09{{with\x20.Doc}}<p>{{html\x20.}}</p>{{end}}\x0a\x09\x09{{$output\x20:=\x20.Output}}\x0a\x09\x09{{with\x20.Play}}\x0a\x09\x09\x09<div\x20class=\"play\">\x0a\x09\x09\x09\x09<div\x20class=\"input\"><textarea\x20class=\"code\"\x20spellcheck=\"false\">{{html\x20.}}</textarea></div>\x0a\x09\x09\x09\x09<div\x20class=\"output\"><pre>{{html\x20$output}}</pre></div>\x0a\x09\x09\x09\x09<div\x20class=\"buttons\">\x0a\x09\x09\x09\x09\x09<a\x20class=\"run\"\x20title=\"Run\x20this\x20code\x20[shift-enter]\">Run</a>\x0a\x09\x09\x09\x09\x09<a\x20class=\"fmt\"\x20title=\"Format\x20this\x20code\">Format</a>\x0a\x09\x09\x09\x09</div>\x0a\x09\x09\x09</div>\x0a\x09\x09{{else}}\x0a\x09\x09\x09<p>Code:</p>\x0a\x09\x09\x09<pre\x20class=\"code\">{{.Code}}</pre>\x0a\x09\x09\x09{{with\x20.Output}}\x0a\x09\x09\x09<p>Output:</p>\x0a\x09\x09\x09<pre\x20class=\"output\">{{html\x20.}}</pre>\x0a\x09\x09\x09{{end}}\x0a\x09\x09{{end}}\x0a\x09</div>\x0a</div>\x0a<!--\x20end\x20example.html\x20-->\x0a",
 
@@ -0,0 +1,242 @@
+// This file contains the code keeping the directory tree of
+// a corpus in sync with its file system.
+//
+// The file system is polled: each sync reads all directories
+// of the tree (but doesn't parse any files) and computes a stamp
+// for each of them. Directories whose stamps changed since the
+// last sync are rebuilt, starting at the closest directory that
+// is part of the current tree, without rebuilding the subtrees
+// below them that did not change. The results are swapped into
+// a copy of the tree which shares all unchanged subtrees with
+// the old one.
+
+package godoc
+
+import (
+	"encoding/binary"
+	"go/token"
+	"hash/fnv"
+	"log"
+	"os"
+	pathpkg "path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultSyncInterval is the time between two syncs
+// if Corpus.SyncInterval is not set.
+const defaultSyncInterval = 10 * time.Second
+
+// A dirStamp summarizes the state of a directory as far as the
+// directory tree is concerned: the names, sizes and modification
+// times of its package files, and the names of its subdirectories.
+type dirStamp uint64
+
+func newDirStamp(list []os.FileInfo) dirStamp {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, fi := range list {
+		switch {
+		case isPkgDir(fi):
+			h.Write([]byte(fi.Name()))
+			h.Write([]byte{'/'})
+		case isPkgFile(fi):
+			h.Write([]byte(fi.Name()))
+			binary.LittleEndian.PutUint64(buf[:], uint64(fi.Size()))
+			h.Write(buf[:])
+			binary.LittleEndian.PutUint64(buf[:], uint64(fi.ModTime().UnixNano()))
+			h.Write(buf[:])
+		}
+	}
+	return dirStamp(h.Sum64())
+}
+
+// readStamps records the stamps of the directory tree rooted at path
+// in stamps. It follows the same directories as newDirTree does.
+func (c *Corpus) readStamps(path string, stamps map[string]dirStamp) {
+	if pathpkg.Base(path) == testdataDirName {
+		return
+	}
+	ioGate <- struct{}{}
+	list, err := c.fs.ReadDir(path)
+	<-ioGate
+	if err != nil && c.Verbose {
+		log.Printf("sync reading %s: %v", path, err)
+	}
+	stamps[path] = newDirStamp(list)
+	for _, d := range list {
+		if isPkgDir(d) {
+			c.readStamps(pathpkg.Join(path, d.Name()), stamps)
+		}
+	}
+}
+
+// RunSync runs forever, periodically checking the file system for
+// changes and updating the directory tree (and the search index and
+// implements analysis, if enabled) accordingly. It must be called
+// after Init.
+func (c *Corpus) RunSync() {
+	c.WaitIndex() // don't build the indexes twice at once
+	for {
+		time.Sleep(c.syncInterval())
+		if !c.sync() {
+			continue
+		}
+		if c.IndexEnabled {
+			c.updateIndex()
+		}
+		if c.ImplementsEnabled {
+			c.updateImplements()
+		}
+	}
+}
+
+// syncInterval returns the time between two syncs.
+func (c *Corpus) syncInterval() time.Duration {
+	if c.SyncInterval <= 0 {
+		return defaultSyncInterval
+	}
+	return c.SyncInterval
+}
+
+// sync brings the directory tree up to date with the file system.
+// It reports whether the tree changed.
+func (c *Corpus) sync() bool {
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	v, _ := c.fsTree.Get()
+	tree, _ := v.(*Directory)
+	if tree == nil {
+		return false
+	}
+
+	stamps := make(map[string]dirStamp)
+	c.readStamps(tree.Path, stamps)
+
+	// collect the directories that changed, appeared or disappeared
+	var changed []string
+	for path, stamp := range stamps {
+		if old, ok := c.fsStamps[path]; !ok || old != stamp {
+			changed = append(changed, path)
+		}
+	}
+	for path := range c.fsStamps {
+		if _, ok := stamps[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	c.fsStamps = stamps
+	if len(changed) == 0 {
+		return false
+	}
+
+	// refresh the closest directories of the current tree
+	// containing the changes, parents first
+	fset := token.NewFileSet()
+	for _, path := range changedDirs(tree, changed) {
+		d := tree.lookup(path)
+		if d == nil {
+			continue // removed while refreshing its parent
+		}
+		if c.Verbose {
+			log.Printf("sync: refreshing %s", d.Path)
+		}
+		if tree = replaceDirectory(tree, d.Path, c.refreshDirectory(fset, d)); tree == nil {
+			// the root directory itself disappeared; keep what we have
+			return false
+		}
+	}
+	c.setFSTree(tree)
+	return true
+}
+
+// changedDirs returns the sorted paths of the closest directories in
+// tree that contain the changed paths.
+func changedDirs(tree *Directory, changed []string) []string {
+	set := make(map[string]bool)
+	for _, path := range changed {
+		for tree.lookup(path) == nil && path != "/" {
+			path = pathpkg.Dir(path)
+		}
+		if tree.lookup(path) != nil {
+			set[path] = true
+		}
+	}
+
+	var list []string
+	for path := range set {
+		list = append(list, path)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// refreshDirectory rebuilds directory d without rebuilding its
+// subdirectories: subdirectories which are already part of d are
+// reused, new ones are built from scratch.
+func (c *Corpus) refreshDirectory(fset *token.FileSet, d *Directory) *Directory {
+	shallow := &TreeBuilder{corpus: c, maxDepth: d.Depth + 1}
+	dir := shallow.newDirTree(fset, d.Path, d.Name, d.Depth)
+	if dir == nil {
+		return nil
+	}
+
+	full := &TreeBuilder{corpus: c, maxDepth: 1e6} // "infinity", as for the initial tree
+	var list []*Directory
+	for _, sub := range dir.SubDirectories {
+		if old := d.lookupLocal(sub.Name); old != nil {
+			sub = old
+		} else {
+			sub = full.newDirTree(fset, sub.Path, sub.Name, sub.Depth)
+		}
+		if sub != nil {
+			list = append(list, sub)
+		}
+	}
+	dir.SubDirectories = list
+
+	if !dir.HasPkg && len(list) == 0 {
+		return nil
+	}
+	return dir
+}
+
+// replaceDirectory returns a copy of the tree root in which the
+// directory with the given path is replaced by dir, or removed if
+// dir is nil. Directories which don't contain the replaced directory
+// are shared between the old and the new tree. Directories which end
+// up without packages and subdirectories are removed as well, as
+// newDirTree would have done.
+func replaceDirectory(root *Directory, path string, dir *Directory) *Directory {
+	if root.Path == path {
+		return dir
+	}
+
+	copy := *root
+	copy.SubDirectories = make([]*Directory, 0, len(root.SubDirectories)+1)
+	found := false
+	for _, d := range root.SubDirectories {
+		if isSubdir(path, d.Path) {
+			found = true
+			if d = replaceDirectory(d, path, dir); d == nil {
+				continue
+			}
+		}
+		copy.SubDirectories = append(copy.SubDirectories, d)
+	}
+	if !found {
+		// path is not part of the tree; nothing to do
+		return root
+	}
+	if !copy.HasPkg && len(copy.SubDirectories) == 0 && copy.Depth > 0 {
+		return nil
+	}
+	return &copy
+}
+
+// isSubdir reports whether path is dir or one of its subdirectories.
+func isSubdir(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+"/")
+}
@@ -0,0 +1,57 @@
+package godoc
+
+import (
+	"testing"
+
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestSync(t *testing.T) {
+	files := map[string]string{
+		"src/foo/foo.go":     "// Package foo is great.\npackage foo\n",
+		"src/foo/bar/bar.go": "// Package bar is fine.\npackage bar\n",
+		"src/baz/baz.go":     "// Package baz is old.\npackage baz\n",
+	}
+	c := NewCorpus(mapfs.New(files))
+	c.IndexEnabled = false
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+	tree := func() *Directory {
+		v, _ := c.fsTree.Get()
+		return v.(*Directory)
+	}
+	old := tree()
+
+	if c.sync() {
+		t.Fatal("sync reported changes for an unchanged file system")
+	}
+	if tree() != old {
+		t.Fatal("sync replaced an unchanged tree")
+	}
+
+	// add a package, change a synopsis and remove a package
+	files["src/qux/qux.go"] = "// Package qux is new.\npackage qux\n"
+	files["src/baz/baz.go"] = "// Package baz is much newer.\npackage baz\n"
+	delete(files, "src/foo/bar/bar.go")
+	if !c.sync() {
+		t.Fatal("sync reported no changes")
+	}
+
+	root := tree()
+	if d := root.lookup("/src/qux"); d == nil || !d.HasPkg || d.Synopsis != "Package qux is new." {
+		t.Errorf("added package: got %+v", d)
+	}
+	if d := root.lookup("/src/baz"); d == nil || d.Synopsis != "Package baz is much newer." {
+		t.Errorf("changed package: got %+v", d)
+	}
+	if d := root.lookup("/src/foo/bar"); d != nil {
+		t.Errorf("removed package: got %+v; want nil", d)
+	}
+	if d := root.lookup("/src/foo"); d == nil || !d.HasPkg {
+		t.Errorf("unchanged package: got %+v", d)
+	}
+	if old.lookup("/src/foo/bar") == nil {
+		t.Error("sync modified the old tree")
+	}
+}
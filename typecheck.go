@@ -0,0 +1,143 @@
+// This file contains the code to type-check packages of a
+// corpus with go/types, for the TypeCheck PageInfoMode.
+//
+// Imported packages are type-checked from source as well,
+// without function bodies, reading them from the corpus file
+// system: there is no need for compiled export data, and the
+// imported packages are seen as godoc sees them.
+
+package godoc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/token"
+	"go/types"
+	"log"
+	pathpkg "path"
+	"sort"
+	"strings"
+)
+
+// A vfsImporter imports packages from the source files in the
+// corpus file system. An import path is resolved as the go command
+// does in GOPATH mode: the closest vendor directory of the importing
+// package takes precedence over /src.
+type vfsImporter struct {
+	c        *Corpus
+	ctxt     *build.Context
+	fset     *token.FileSet
+	packages map[string]*types.Package // by directory; nil while being imported
+}
+
+func newVFSImporter(c *Corpus, ctxt *build.Context) *vfsImporter {
+	return &vfsImporter{
+		c:        c,
+		ctxt:     ctxt,
+		fset:     token.NewFileSet(),
+		packages: make(map[string]*types.Package),
+	}
+}
+
+func (imp *vfsImporter) Import(path string) (*types.Package, error) {
+	return imp.ImportFrom(path, "", 0)
+}
+
+func (imp *vfsImporter) ImportFrom(path, srcDir string, _ types.ImportMode) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+
+	return imp.importDir(imp.findDir(path, srcDir))
+}
+
+// importDir type-checks the package in directory dir, without
+// function bodies, unless it was imported before. The package
+// may be incomplete if it has type errors.
+func (imp *vfsImporter) importDir(dir string) (*types.Package, error) {
+	if pkg, ok := imp.packages[dir]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle through %s", dir)
+		}
+		return pkg, nil
+	}
+	imp.packages[dir] = nil // mark as being imported
+
+	pkginfo, err := imp.ctxt.ImportDir(dir, 0)
+	if err != nil {
+		delete(imp.packages, dir)
+		return nil, err
+	}
+	var files []*ast.File
+	for _, name := range append(pkginfo.GoFiles, pkginfo.CgoFiles...) {
+		file, err := imp.c.parseFile(imp.fset, pathpkg.Join(dir, name), 0)
+		if err != nil {
+			delete(imp.packages, dir)
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	conf := imp.config()
+	conf.IgnoreFuncBodies = true
+	pkg, _ := conf.Check(srcImportPath(dir), imp.fset, files, nil) // errors are reported via conf.Error
+	imp.packages[dir] = pkg
+	return pkg, nil
+}
+
+// findDir returns the directory of the package with the given
+// import path, imported by a package in the directory srcDir.
+func (imp *vfsImporter) findDir(path, srcDir string) string {
+	for dir := srcDir; dir == "/src" || strings.HasPrefix(dir, "/src/"); dir = pathpkg.Dir(dir) {
+		vendored := pathpkg.Join(dir, "vendor", path)
+		if fi, err := imp.c.fs.Stat(vendored); err == nil && fi.IsDir() {
+			return vendored
+		}
+	}
+	return pathpkg.Join("/src", path)
+}
+
+// config returns a types.Config for checking packages with imp.
+func (imp *vfsImporter) config() *types.Config {
+	return &types.Config{
+		Importer:    imp,
+		FakeImportC: true,
+		Sizes:       types.SizesFor("gc", imp.ctxt.GOARCH),
+		// Type errors are common in documentation (missing
+		// dependencies, files for other platforms); check as
+		// much as possible and ignore them.
+		Error: func(err error) {
+			if imp.c.Verbose {
+				log.Printf("type-checking: %v", err)
+			}
+		},
+	}
+}
+
+// typeCheck type-checks the package with the given import path, made
+// of files. It returns the package and the type information for files;
+// both are incomplete if the package has type errors.
+func (c *Corpus) typeCheck(ctxt *build.Context, fset *token.FileSet, importPath string, files map[string]*ast.File) (*types.Package, *types.Info) {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := make([]*ast.File, len(names))
+	for i, name := range names {
+		list[i] = files[name]
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	// go/types resolves imports relative to the directory of the
+	// files, which is abspath (see parseFiles), as needed for vendoring
+	conf := newVFSImporter(c, ctxt).config()
+	pkg, _ := conf.Check(importPath, fset, list, info)
+	return pkg, info
+}
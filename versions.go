@@ -1,16 +1,18 @@
 // This file caches information about which standard library types, methods,
-// and functions appeared in what version of Go
+// functions, constants, variables, struct fields and interface methods
+// appeared in what version of Go
 
 package godoc
 
 import (
 	"bufio"
-	"go/build"
 	"log"
-	"os"
+	pathpkg "path"
 	"path/filepath"
 	"strings"
 	"unicode"
+
+	"github.com/miclle/godoc/vfs"
 )
 
 // apiVersions is a map of packages to information about those packages'
@@ -29,14 +31,18 @@ type pkgAPIVersions struct {
 	typeSince   map[string]string            // "Server" -> "1.7"
 	methodSince map[string]map[string]string // "*Server" ->"Shutdown"->1.8
 	funcSince   map[string]string            // "NewServer" -> "1.7"
-	fieldSince  map[string]map[string]string // "ClientTrace" -> "Got1xxResponse" -> "1.11"
+	fieldSince  map[string]map[string]string // "ClientTrace" -> "Got1xxResponse" -> "1.11"; also interface methods
+	constSince  map[string]string            // "StatusEarlyHints" -> "1.13"
+	varSince    map[string]string            // "ErrSchemeMismatch" -> "1.21"
 }
 
 // sinceVersionFunc returns a string (such as "1.7") specifying which Go
 // version introduced a symbol, unless it was introduced in Go1, in
-// which case it returns the empty string.
+// which case it returns the empty string. For the packages of modules,
+// the version is a module version (such as "v1.2.0"), and the empty
+// string for the first version; see WriteModuleAPIFiles.
 //
-// The kind is one of "type", "method", or "func".
+// The kind is one of "type", "method", "func", "const", or "var".
 //
 // The receiver is only used for "methods" and specifies the receiver type,
 // such as "*Server".
@@ -51,19 +57,33 @@ func (v apiVersions) sinceVersionFunc(kind, receiver, name, pkg string) string {
 	case "type":
 		return pv.typeSince[name]
 	case "method":
-		return pv.methodSince[receiver][name]
+		return pv.methodSince[trimTypeParams(receiver)][name]
+	case "const":
+		return pv.constSince[name]
+	case "var":
+		return pv.varSince[name]
 	}
 	return ""
 }
 
+// trimTypeParams returns the receiver type recv without its type
+// parameters, which are named differently in API files ("*List[$0]")
+// and declarations ("*List[T]").
+func trimTypeParams(recv string) string {
+	if i := strings.IndexByte(recv, '['); i != -1 {
+		return recv[:i]
+	}
+	return recv
+}
+
 // versionedRow represents an API feature, a parsed line of a
 // $GOROOT/api/go.*txt file.
 type versionedRow struct {
 	pkg        string // "net/http"
-	kind       string // "type", "func", "method", "field" TODO: "const", "var"
+	kind       string // "type", "func", "method", "field", "interface method", "const", "var"
 	recv       string // for methods, the receiver type ("Server", "*Server")
-	name       string // name of type, (struct) field, func, method
-	structName string // for struct fields, the outer struct name
+	name       string // name of type, (struct) field, func, method, interface method, const, var
+	structName string // for struct fields and interface methods, the outer type name
 }
 
 // versionParser parses $GOROOT/api/go*.txt files and stores them in in its rows field.
@@ -71,13 +91,15 @@ type versionParser struct {
 	res apiVersions // initialized lazily
 }
 
-func (vp *versionParser) parseFile(name string) error {
-	base := filepath.Base(name)
+// parseFile parses the API file of fs with the given slash-separated
+// name, which is named after the version of its API features.
+func (vp *versionParser) parseFile(fs vfs.Opener, name string) error {
+	base := pathpkg.Base(name)
 	ver := strings.TrimPrefix(strings.TrimSuffix(base, ".txt"), "go")
 	if ver == "1" {
 		return nil
 	}
-	f, err := os.Open(name)
+	f, err := fs.Open(name)
 	if err != nil {
 		return err
 	}
@@ -99,6 +121,8 @@ func (vp *versionParser) parseFile(name string) error {
 				methodSince: make(map[string]map[string]string),
 				funcSince:   make(map[string]string),
 				fieldSince:  make(map[string]map[string]string),
+				constSince:  make(map[string]string),
+				varSince:    make(map[string]string),
 			}
 			vp.res[row.pkg] = pkgi
 		}
@@ -112,7 +136,11 @@ func (vp *versionParser) parseFile(name string) error {
 				pkgi.methodSince[row.recv] = make(map[string]string)
 			}
 			pkgi.methodSince[row.recv][row.name] = ver
-		case "field":
+		case "const":
+			pkgi.constSince[row.name] = ver
+		case "var":
+			pkgi.varSince[row.name] = ver
+		case "field", "interface method":
 			if _, ok := pkgi.fieldSince[row.structName]; !ok {
 				pkgi.fieldSince[row.structName] = make(map[string]string)
 			}
@@ -128,7 +156,9 @@ func parseRow(s string) (vr versionedRow, ok bool) {
 		return
 	}
 	rest := s[len("pkg "):]
-	endPkg := strings.IndexFunc(rest, func(r rune) bool { return !(unicode.IsLetter(r) || r == '/' || unicode.IsDigit(r)) })
+	endPkg := strings.IndexFunc(rest, func(r rune) bool {
+		return !(unicode.IsLetter(r) || r == '/' || unicode.IsDigit(r) || strings.ContainsRune(".-_~", r)) // module paths too
+	})
 	if endPkg == -1 {
 		return
 	}
@@ -144,19 +174,47 @@ func parseRow(s string) (vr versionedRow, ok bool) {
 	switch {
 	case strings.HasPrefix(rest, "type "):
 		rest = rest[len("type "):]
-		sp := strings.IndexByte(rest, ' ')
+		sp := strings.IndexAny(rest, " [")
 		if sp == -1 {
 			return
 		}
-		vr.name, rest = rest[:sp], rest[sp+1:]
-		if !strings.HasPrefix(rest, "struct, ") {
+		vr.name, rest = rest[:sp], rest[sp:]
+		if strings.HasPrefix(rest, "[") { // type parameters: "List[$0 any] struct"
+			end := strings.Index(rest, "] ")
+			if end == -1 {
+				return
+			}
+			rest = rest[end+1:]
+		}
+		rest = rest[1:]
+		switch {
+		case strings.HasPrefix(rest, "struct, "): // "struct, Name string" or "struct, embedded *Info"
+			rest = rest[len("struct, "):]
+			vr.kind = "field"
+			if strings.HasPrefix(rest, "embedded ") {
+				typ := strings.TrimPrefix(rest[len("embedded "):], "*")
+				vr.structName, vr.name = vr.name, typ[strings.LastIndexByte(typ, '.')+1:]
+				return vr, true
+			}
+			if i := strings.IndexByte(rest, ' '); i != -1 {
+				vr.structName, vr.name = vr.name, rest[:i]
+				return vr, true
+			}
+		case strings.HasPrefix(rest, "interface, "): // "interface, Read([]uint8) (int, error)"
+			rest = rest[len("interface, "):]
+			vr.kind = "interface method"
+			if i := strings.IndexByte(rest, '('); i > 0 && !strings.HasPrefix(rest, "embedded ") {
+				vr.structName, vr.name = vr.name, rest[:i]
+				return vr, true
+			}
+		default:
 			vr.kind = "type"
 			return vr, true
 		}
-		rest = rest[len("struct, "):]
+	case strings.HasPrefix(rest, "const "), strings.HasPrefix(rest, "var "): // "const Name = 2", "var Name Type"
+		sp := strings.IndexByte(rest, ' ')
+		vr.kind, rest = rest[:sp], rest[sp+1:]
 		if i := strings.IndexByte(rest, ' '); i != -1 {
-			vr.kind = "field"
-			vr.structName = vr.name
 			vr.name = rest[:i]
 			return vr, true
 		}
@@ -174,8 +232,8 @@ func parseRow(s string) (vr versionedRow, ok bool) {
 		if sp == -1 {
 			return
 		}
-		vr.recv = strings.Trim(rest[:sp], "()") // "*File"
-		rest = rest[sp+1:]                      // SetMode(os.FileMode)
+		vr.recv = trimTypeParams(strings.Trim(rest[:sp], "()")) // "*File"
+		rest = rest[sp+1:]                                      // SetMode(os.FileMode)
 		paren := strings.IndexByte(rest, '(')
 		if paren == -1 {
 			return
@@ -183,38 +241,46 @@ func parseRow(s string) (vr versionedRow, ok bool) {
 		vr.name = rest[:paren]
 		return vr, true
 	}
-	return // TODO: handle more cases
+	return
 }
 
-// InitVersionInfo parses the $GOROOT/api/go*.txt API definition files to discover
-// which API features were added in which Go releases.
+// InitVersionInfo parses the /api/go*.txt API definition files of the
+// corpus file system, which has $GOROOT as its root, to discover which
+// API features were added in which Go releases, and the API files
+// matching c.APIFiles for the releases of modules.
 func (c *Corpus) InitVersionInfo() {
 	var err error
-	c.pkgAPIInfo, err = parsePackageAPIInfo()
+	c.pkgAPIInfo, err = parsePackageAPIInfo(c.fs, c.APIFiles)
 	if err != nil {
 		// TODO: consider making this fatal, after the Go 1.11 cycle.
 		log.Printf("godoc: error parsing API version files: %v", err)
 	}
 }
 
-func parsePackageAPIInfo() (apiVersions, error) {
-	var apiGlob string
-	if os.Getenv("GOROOT") == "" {
-		apiGlob = filepath.Join(build.Default.GOROOT, "api", "go*.txt")
-	} else {
-		apiGlob = filepath.Join(os.Getenv("GOROOT"), "api", "go*.txt")
-	}
-
-	files, err := filepath.Glob(apiGlob)
+// parsePackageAPIInfo parses the /api/go*.txt files of fs and the
+// files of the operating system's file system matching patterns.
+func parsePackageAPIInfo(fs vfs.FileSystem, patterns []string) (apiVersions, error) {
+	files, err := vfs.Glob(fs, "/api/go*.txt")
 	if err != nil {
 		return nil, err
 	}
-
 	vp := new(versionParser)
 	for _, f := range files {
-		if err := vp.parseFile(f); err != nil {
+		if err := vp.parseFile(fs, f); err != nil {
 			return nil, err
 		}
 	}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range matches {
+			if err := vp.parseFile(vfs.OS(filepath.Dir(f)), "/"+filepath.Base(f)); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return vp.res, nil
 }
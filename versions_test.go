@@ -3,6 +3,9 @@ package godoc
 import (
 	"go/build"
 	"testing"
+
+	"github.com/miclle/godoc/vfs"
+	"github.com/miclle/godoc/vfs/mapfs"
 )
 
 func TestParseVersionRow(t *testing.T) {
@@ -50,6 +53,14 @@ func TestParseVersionRow(t *testing.T) {
 				name: "FileInfoHeader",
 			},
 		},
+		{
+			row: "pkg example.com/go-mod_x/p, func NewServer(string) *Server",
+			want: versionedRow{
+				pkg:  "example.com/go-mod_x/p",
+				kind: "func",
+				name: "NewServer",
+			},
+		},
 		{
 			row: "pkg encoding/base32, method (Encoding) WithPadding(int32) *Encoding",
 			want: versionedRow{
@@ -59,6 +70,83 @@ func TestParseVersionRow(t *testing.T) {
 				recv: "Encoding",
 			},
 		},
+		{
+			row: "pkg archive/tar, const TypeBlock = 52",
+			want: versionedRow{
+				pkg:  "archive/tar",
+				kind: "const",
+				name: "TypeBlock",
+			},
+		},
+		{
+			row: "pkg archive/tar, const TypeBlock ideal-char",
+			want: versionedRow{
+				pkg:  "archive/tar",
+				kind: "const",
+				name: "TypeBlock",
+			},
+		},
+		{
+			row: "pkg bufio, var ErrTooLong error",
+			want: versionedRow{
+				pkg:  "bufio",
+				kind: "var",
+				name: "ErrTooLong",
+			},
+		},
+		{
+			row: "pkg io, type ByteWriter interface { WriteByte }",
+			want: versionedRow{
+				pkg:  "io",
+				kind: "type",
+				name: "ByteWriter",
+			},
+		},
+		{
+			row: "pkg io, type ByteWriter interface, WriteByte(uint8) error",
+			want: versionedRow{
+				pkg:        "io",
+				kind:       "interface method",
+				structName: "ByteWriter",
+				name:       "WriteByte",
+			},
+		},
+		{
+			row: "pkg runtime, type BlockProfileRecord struct, embedded StackRecord",
+			want: versionedRow{
+				pkg:        "runtime",
+				kind:       "field",
+				structName: "BlockProfileRecord",
+				name:       "StackRecord",
+			},
+		},
+		{
+			row: "pkg go/types, type Checker struct, embedded *types.Info",
+			want: versionedRow{
+				pkg:        "go/types",
+				kind:       "field",
+				structName: "Checker",
+				name:       "Info",
+			},
+		},
+		{
+			row: "pkg database/sql, type Null[$0 interface{}] struct, Valid bool #60370",
+			want: versionedRow{
+				pkg:        "database/sql",
+				kind:       "field",
+				structName: "Null",
+				name:       "Valid",
+			},
+		},
+		{
+			row: "pkg container/list, method (*List[$0]) Len() int",
+			want: versionedRow{
+				pkg:  "container/list",
+				kind: "method",
+				name: "Len",
+				recv: "*List",
+			},
+		},
 	}
 
 	for i, tt := range tests {
@@ -84,7 +172,7 @@ func hasTag(t string) bool {
 }
 
 func TestAPIVersion(t *testing.T) {
-	av, err := parsePackageAPIInfo()
+	av, err := parsePackageAPIInfo(vfs.OS(build.Default.GOROOT), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -109,6 +197,13 @@ func TestAPIVersion(t *testing.T) {
 		{"type", "strings", "Builder", "", "1.10"},
 		{"method", "strings", "WriteString", "*Builder", "1.10"},
 
+		{"const", "net/http", "StatusEarlyHints", "", "1.13"},
+		{"var", "bufio", "ErrFinalToken", "", "1.6"},
+
+		// Methods added to Go 1 interfaces don't change their versions
+		{"type", "reflect", "Type", "", ""},
+		{"type", "io", "StringWriter", "", "1.12"},
+
 		// Things from package syscall should never appear
 		{"func", "syscall", "FchFlags", "", ""},
 		{"type", "syscall", "Inet4Pktinfo", "", ""},
@@ -126,3 +221,31 @@ func TestAPIVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestInitVersionInfo(t *testing.T) {
+	fs := mapfs.New(map[string]string{
+		"api/go1.txt":   "pkg foo, func Old()\n",
+		"api/go1.5.txt": "pkg foo, func New()\npkg foo, type T struct, F int\n",
+		"api/next.txt":  "pkg foo, func Next()\n",
+	})
+	ns := vfs.NameSpace{}
+	ns.Bind("/", fs, "/", vfs.BindReplace)
+	for _, fs := range []vfs.FileSystem{fs, ns} {
+		c := NewCorpus(fs)
+		c.InitVersionInfo()
+		for _, tc := range []struct {
+			name, want string
+		}{
+			{"Old", ""},
+			{"New", "1.5"},
+			{"Next", ""},
+		} {
+			if got := c.pkgAPIInfo.sinceVersionFunc("func", "", tc.name, "foo"); got != tc.want {
+				t.Errorf("%s: since func %s = %q; want %q", fs, tc.name, got, tc.want)
+			}
+		}
+		if got := c.pkgAPIInfo["foo"].fieldSince["T"]["F"]; got != "1.5" {
+			t.Errorf("%s: since field T.F = %q; want 1.5", fs, got)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package vfs
+
+import (
+	pathpkg "path"
+	"sort"
+	"strings"
+)
+
+// Glob returns the names of the files of fs matching pattern, in
+// lexical order within each directory, or nil if there is no matching
+// file. The pattern is a slash-separated path whose elements use the
+// syntax of path.Match, such as "/api/go*.txt". As for filepath.Glob,
+// the only possible returned error is path.ErrBadPattern; I/O errors,
+// such as unreadable directories, are ignored.
+func Glob(fs FileSystem, pattern string) ([]string, error) {
+	if _, err := pathpkg.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	if !hasMeta(pattern) {
+		if _, err := fs.Lstat(pattern); err != nil {
+			return nil, nil
+		}
+		return []string{pattern}, nil
+	}
+
+	dir, file := pathpkg.Split(pattern)
+	dir = cleanGlobPath(dir)
+	if !hasMeta(dir) {
+		return glob(fs, dir, file, nil)
+	}
+	if dir == pattern {
+		return nil, pathpkg.ErrBadPattern // prevent infinite recursion
+	}
+	dirs, err := Glob(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, d := range dirs {
+		if matches, err = glob(fs, d, file, matches); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// cleanGlobPath prepares the directory dir of a pattern for globbing.
+func cleanGlobPath(dir string) string {
+	switch dir {
+	case "":
+		return "."
+	case "/":
+		return dir
+	}
+	return dir[:len(dir)-1] // chop off trailing separator
+}
+
+// glob appends to matches the names of the files of the directory dir
+// matching pattern, which is a single path element.
+func glob(fs FileSystem, dir, pattern string, matches []string) ([]string, error) {
+	list, err := fs.ReadDir(dir)
+	if err != nil {
+		return matches, nil // ignore I/O error
+	}
+	names := make([]string, 0, len(list))
+	for _, fi := range list {
+		names = append(names, fi.Name())
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		matched, err := pathpkg.Match(pattern, name)
+		if err != nil {
+			return matches, err
+		}
+		if matched {
+			matches = append(matches, pathpkg.Join(dir, name))
+		}
+	}
+	return matches, nil
+}
+
+// hasMeta reports whether path contains any of the magic characters
+// recognized by path.Match.
+func hasMeta(path string) bool {
+	return strings.ContainsAny(path, `*?[\`)
+}
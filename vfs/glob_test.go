@@ -0,0 +1,54 @@
+package vfs_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miclle/godoc/vfs"
+	"github.com/miclle/godoc/vfs/mapfs"
+)
+
+func TestGlob(t *testing.T) {
+	fs := mapfs.New(map[string]string{
+		"api/go1.txt":        "",
+		"api/go1.10.txt":     "",
+		"api/go1.2.txt":      "",
+		"api/except.txt":     "",
+		"src/a/a.go":         "",
+		"src/b/b.go":         "",
+		"src/b/b_test.go":    "",
+		"src/b/c/c.go":       "",
+		"src/testdata/t.txt": "",
+	})
+	ns := vfs.NameSpace{}
+	ns.Bind("/", fs, "/", vfs.BindReplace)
+
+	for _, tc := range []struct {
+		pattern string
+		want    []string
+	}{
+		{"/api/go*.txt", []string{"/api/go1.10.txt", "/api/go1.2.txt", "/api/go1.txt"}},
+		{"/api/go1.txt", []string{"/api/go1.txt"}},
+		{"/api/go2.txt", nil},
+		{"/api/*.go", nil},
+		{"/src/*/*.go", []string{"/src/a/a.go", "/src/b/b.go", "/src/b/b_test.go"}},
+		{"/src/?/[bc]*.go", []string{"/src/b/b.go", "/src/b/b_test.go"}},
+		{"/src/*", []string{"/src/a", "/src/b", "/src/testdata"}},
+		{"/missing/*", nil},
+	} {
+		for _, fs := range []vfs.FileSystem{fs, ns} {
+			got, err := vfs.Glob(fs, tc.pattern)
+			if err != nil {
+				t.Errorf("Glob(%s, %q): %v", fs, tc.pattern, err)
+				continue
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Glob(%s, %q) = %q; want %q", fs, tc.pattern, got, tc.want)
+			}
+		}
+	}
+
+	if _, err := vfs.Glob(fs, "/api/[.txt"); err == nil {
+		t.Errorf("Glob with a bad pattern succeeded; want error")
+	}
+}
@@ -0,0 +1,433 @@
+// Package modfs provides an implementation of the FileSystem interface
+// that serves the module versions of a module cache, such as
+// $GOPATH/pkg/mod, on top of another FileSystem.
+//
+// The files of the version v of module m are served at /src/m@v, so
+// that several versions of a module can be served side by side. The
+// module versions are looked up on demand: they are not listed in the
+// directories of the file system. They are read from the directories
+// extracted by the go command or, for the versions which were only
+// downloaded, from the module zip files of the download cache
+// (cache/download in the module cache).
+package modfs // import "github.com/miclle/godoc/vfs/modfs"
+
+import (
+	"archive/zip"
+	"container/list"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/miclle/godoc/vfs"
+	"github.com/miclle/godoc/vfs/zipfs"
+)
+
+// maxOpen is the maximum number of module versions whose file systems
+// a modFS keeps open, the least recently used being closed first.
+const maxOpen = 64
+
+// New returns a new FileSystem serving the files of fs and the module
+// versions of the module cache directory cacheDir.
+func New(fs vfs.FileSystem, cacheDir string) vfs.FileSystem {
+	return &modFS{
+		FileSystem: fs,
+		cacheDir:   cacheDir,
+		mods:       make(map[string]*list.Element),
+	}
+}
+
+type modFS struct {
+	vfs.FileSystem
+	cacheDir string
+
+	mu   sync.Mutex
+	mods map[string]*list.Element // of *openModule, by "module@version"
+	lru  list.List                // most recently used first
+}
+
+// An openModule is the file system of a module version served by a modFS.
+type openModule struct {
+	mod string // "module@version"
+	fs  vfs.FileSystem
+}
+
+func (fs *modFS) String() string {
+	return fmt.Sprintf("modcache(%s, %s)", fs.FileSystem.String(), fs.cacheDir)
+}
+
+// resolve returns the file system and the path in it of the file with
+// path p.
+func (fs *modFS) resolve(p string) (vfs.FileSystem, string) {
+	const src = "/src/"
+	p = pathpkg.Clean(p)
+	at := strings.IndexByte(p, '@')
+	if !strings.HasPrefix(p, src) || at < len(src) {
+		return fs.FileSystem, p
+	}
+	end := len(p)
+	if i := strings.IndexByte(p[at:], '/'); i >= 0 {
+		end = at + i
+	}
+	mod := p[len(src):end]
+	rest := "/" + strings.TrimPrefix(p[end:], "/")
+	if m := fs.lookup(mod); m != nil {
+		return m, rest
+	}
+
+	// The module version is opened outside of the lock, which would
+	// serialize the lookups of all files.
+	version := mod[at-len(src)+1:]
+	modpath, ok := Lookup(fs.cacheDir, mod[:at-len(src)], version)
+	if !ok {
+		return fs.FileSystem, p // not found
+	}
+	m, err := Open(fs.cacheDir, modpath, version)
+	if err != nil {
+		return fs.FileSystem, p
+	}
+	return fs.add(mod, m), rest
+}
+
+// lookup returns the open file system of the module version mod, or nil.
+func (fs *modFS) lookup(mod string) vfs.FileSystem {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if elem := fs.mods[mod]; elem != nil {
+		fs.lru.MoveToFront(elem)
+		return elem.Value.(*openModule).fs
+	}
+	return nil
+}
+
+// add records m as the open file system of the module version mod,
+// unless another one was opened concurrently, and returns the file
+// system recorded. The least recently used file systems beyond maxOpen
+// are closed.
+func (fs *modFS) add(mod string, m vfs.FileSystem) vfs.FileSystem {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if elem := fs.mods[mod]; elem != nil {
+		closeFS(m)
+		fs.lru.MoveToFront(elem)
+		return elem.Value.(*openModule).fs
+	}
+	fs.mods[mod] = fs.lru.PushFront(&openModule{mod, m})
+	for fs.lru.Len() > maxOpen {
+		old := fs.lru.Remove(fs.lru.Back()).(*openModule)
+		delete(fs.mods, old.mod)
+		closeFS(old.fs)
+	}
+	return m
+}
+
+// closeFS closes the file system fs if it holds open files, such as
+// the module zip file of a module version.
+func closeFS(fs vfs.FileSystem) {
+	if c, ok := fs.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+func (fs *modFS) Open(p string) (vfs.ReadSeekCloser, error) {
+	rfs, rp := fs.resolve(p)
+	return rfs.Open(rp)
+}
+
+func (fs *modFS) Lstat(p string) (os.FileInfo, error) {
+	rfs, rp := fs.resolve(p)
+	return rfs.Lstat(rp)
+}
+
+func (fs *modFS) Stat(p string) (os.FileInfo, error) {
+	rfs, rp := fs.resolve(p)
+	return rfs.Stat(rp)
+}
+
+func (fs *modFS) ReadDir(p string) ([]os.FileInfo, error) {
+	rfs, rp := fs.resolve(p)
+	return rfs.ReadDir(rp)
+}
+
+// EscapePath returns the module path or version s as stored in the
+// module cache, where upper-case letters are written as '!' followed
+// by the lower-case letter.
+func EscapePath(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// unescapePath is the inverse of EscapePath.
+func unescapePath(s string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range s {
+		switch {
+		case r == '!':
+			upper = true
+			continue
+		case upper:
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Dir returns the directory of the version of the module modpath in
+// the module cache directory cacheDir.
+func Dir(cacheDir, modpath, version string) string {
+	return filepath.Join(cacheDir, filepath.FromSlash(EscapePath(modpath))+"@"+EscapePath(version))
+}
+
+// ZipFile returns the module zip file of the version of the module
+// modpath in the download cache of the module cache directory cacheDir.
+func ZipFile(cacheDir, modpath, version string) string {
+	return downloadFile(cacheDir, modpath, version, ".zip")
+}
+
+// ModFile returns the go.mod file of the version of the module modpath
+// in the download cache of the module cache directory cacheDir.
+func ModFile(cacheDir, modpath, version string) string {
+	return downloadFile(cacheDir, modpath, version, ".mod")
+}
+
+func downloadFile(cacheDir, modpath, version, ext string) string {
+	return filepath.Join(cacheDir, "cache", "download", filepath.FromSlash(EscapePath(modpath)), "@v", EscapePath(version)+ext)
+}
+
+// exists reports whether the version of the module modpath is in the
+// module cache directory cacheDir.
+func exists(cacheDir, modpath, version string) bool {
+	if fi, err := os.Stat(Dir(cacheDir, modpath, version)); err == nil && fi.IsDir() {
+		return true
+	}
+	fi, err := os.Stat(ZipFile(cacheDir, modpath, version))
+	return err == nil && fi.Mode().IsRegular()
+}
+
+// Lookup returns the module path of the version of the module modpath
+// in the module cache directory cacheDir. For major versions 2 and
+// higher, the module path may be modpath with the major version
+// suffix, such as "example.com/lib/v2" for "example.com/lib". If the
+// version is not in the module cache, ok is false.
+func Lookup(cacheDir, modpath, version string) (path string, ok bool) {
+	if modpath == "" || version == "" || strings.ContainsAny(version, `/\`) || version == "." || version == ".." {
+		return "", false
+	}
+	if exists(cacheDir, modpath, version) {
+		return modpath, true
+	}
+	if major := semver.Major(version); major != "" && major != "v0" && major != "v1" {
+		path := modpath + "/" + major
+		if exists(cacheDir, path, version) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// Open returns a FileSystem serving the files of the version of the
+// module modpath in the module cache directory cacheDir, rooted at "/".
+// The files of an extracted module directory are preferred to those
+// of the module zip file. The FileSystem of a module zip file is an
+// io.Closer closing it.
+func Open(cacheDir, modpath, version string) (vfs.FileSystem, error) {
+	dir := Dir(cacheDir, modpath, version)
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		return vfs.OS(dir), nil
+	}
+	name := ZipFile(cacheDir, modpath, version)
+	rc, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, err
+	}
+	// The files of the zip file are in the directory module@version.
+	ns := vfs.NameSpace{}
+	ns.Bind("/", zipfs.New(rc, name), "/"+modpath+"@"+version, vfs.BindReplace)
+	return zipModule{ns, rc}, nil
+}
+
+// A zipModule is the FileSystem of a module zip file.
+type zipModule struct {
+	vfs.NameSpace
+	rc *zip.ReadCloser
+}
+
+func (m zipModule) Close() error {
+	return m.rc.Close()
+}
+
+// Versions returns the versions of the module modpath in the module
+// cache directory cacheDir, extracted or downloaded, in increasing
+// semantic version order; invalid versions come first.
+func Versions(cacheDir, modpath string) ([]string, error) {
+	var versions []string
+	add := func(dir, prefix, suffix string) error {
+		list, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		for _, fi := range list {
+			name := fi.Name()
+			if strings.HasPrefix(name, prefix) && strings.HasSuffix(name, suffix) && fi.IsDir() == (suffix == "") {
+				versions = append(versions, unescapePath(strings.TrimSuffix(name[len(prefix):], suffix)))
+			}
+		}
+		return nil
+	}
+	escaped := filepath.FromSlash(EscapePath(modpath))
+	if err := add(filepath.Dir(filepath.Join(cacheDir, escaped)), filepath.Base(escaped)+"@", ""); err != nil {
+		return nil, err
+	}
+	if err := add(filepath.Join(cacheDir, "cache", "download", escaped, "@v"), "", ".zip"); err != nil {
+		return nil, err
+	}
+	return sortVersions(versions), nil
+}
+
+// sortVersions sorts the list of versions in increasing order and
+// removes its duplicates.
+func sortVersions(list []string) []string {
+	sort.Slice(list, func(i, j int) bool {
+		// Invalid versions have the same precedence: order them lexically.
+		if c := semver.Compare(list[i], list[j]); c != 0 {
+			return c < 0
+		}
+		return list[i] < list[j]
+	})
+	var versions []string
+	for i, v := range list {
+		if i == 0 || v != list[i-1] {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// AllVersions returns the versions of the module modpath and of its
+// major version modules, such as "example.com/lib/v2" for the module
+// "example.com/lib", in the module cache directory cacheDir, in
+// increasing order.
+func AllVersions(cacheDir, modpath string) ([]string, error) {
+	versions, err := Versions(cacheDir, modpath)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{cacheDir, filepath.Join(cacheDir, "cache", "download")} {
+		list, err := ioutil.ReadDir(filepath.Join(dir, filepath.FromSlash(EscapePath(modpath))))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, fi := range list {
+			name := fi.Name()
+			if i := strings.IndexByte(name, '@'); i > 0 {
+				name = name[:i] // extracted module directory
+			}
+			major, err := strconv.Atoi(strings.TrimPrefix(name, "v"))
+			if err != nil || major < 2 || name != fmt.Sprintf("v%d", major) {
+				continue
+			}
+			list, err := Versions(cacheDir, modpath+"/"+name)
+			if err != nil {
+				return nil, err
+			}
+			versions = append(versions, list...)
+		}
+	}
+	return sortVersions(versions), nil
+}
+
+// A Module is a module of a module cache.
+type Module struct {
+	Path     string
+	Versions []string // in increasing order
+}
+
+// Modules returns the modules of the module cache directory cacheDir,
+// extracted or downloaded, sorted by path.
+func Modules(cacheDir string) ([]Module, error) {
+	versions := make(map[string][]string)
+	// extracted module directories: <module>@<version>
+	err := filepath.Walk(cacheDir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || !fi.IsDir() || p == cacheDir {
+			return err
+		}
+		rel, err := filepath.Rel(cacheDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		switch {
+		case rel == "cache":
+			return filepath.SkipDir
+		case strings.Contains(fi.Name(), "@"):
+			i := strings.LastIndexByte(rel, '@')
+			modpath := unescapePath(rel[:i])
+			versions[modpath] = append(versions[modpath], unescapePath(rel[i+1:]))
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// downloaded module zip files: cache/download/<module>/@v/<version>.zip
+	download := filepath.Join(cacheDir, "cache", "download")
+	err = filepath.Walk(download, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == download {
+				return nil
+			}
+			return err
+		}
+		if !fi.IsDir() || fi.Name() != "@v" {
+			return nil
+		}
+		rel, err := filepath.Rel(download, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		modpath := unescapePath(filepath.ToSlash(rel))
+		list, err := ioutil.ReadDir(p)
+		if err != nil {
+			return err
+		}
+		for _, fi := range list {
+			if name := fi.Name(); strings.HasSuffix(name, ".zip") && fi.Mode().IsRegular() {
+				versions[modpath] = append(versions[modpath], unescapePath(strings.TrimSuffix(name, ".zip")))
+			}
+		}
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mods := make([]Module, 0, len(versions))
+	for modpath, list := range versions {
+		mods = append(mods, Module{modpath, sortVersions(list)})
+	}
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Path < mods[j].Path })
+	return mods, nil
+}
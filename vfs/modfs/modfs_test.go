@@ -0,0 +1,197 @@
+package modfs_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/miclle/godoc/vfs"
+	"github.com/miclle/godoc/vfs/mapfs"
+	"github.com/miclle/godoc/vfs/modfs"
+)
+
+// modCache returns a module cache directory with the given files.
+func modCache(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "modfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestModFS(t *testing.T) {
+	cache := modCache(t, map[string]string{
+		"example.com/!lib@v1.8.0/lib.go":       "package lib // v1.8.0",
+		"example.com/!lib@v1.8.0/sub/sub.go":   "package sub",
+		"example.com/!lib/v2@v2.3.0/lib.go":    "package lib // v2.3.0",
+		"example.com/!lib@v1.10.0/lib.go":      "package lib // v1.10.0",
+		"example.com/!lib@v1.9.0-pre/lib.go":   "package lib // v1.9.0-pre",
+		"example.com/other@v0.1.0/other.go":    "package other",
+		"cache/download/example.com/x/@v/list": "",
+	})
+	defer os.RemoveAll(cache)
+	fs := modfs.New(mapfs.New(map[string]string{
+		"src/example.com/Lib/lib.go": "package lib // build list",
+	}), cache)
+
+	for _, tc := range []struct {
+		path, want string
+	}{
+		{"/src/example.com/Lib/lib.go", "package lib // build list"},
+		{"/src/example.com/Lib@v1.8.0/lib.go", "package lib // v1.8.0"},
+		{"/src/example.com/Lib@v1.8.0/sub/sub.go", "package sub"},
+		{"/src/example.com/Lib@v2.3.0/lib.go", "package lib // v2.3.0"},
+		{"/src/example.com/Lib/v2@v2.3.0/lib.go", "package lib // v2.3.0"},
+	} {
+		data, err := vfs.ReadFile(fs, tc.path)
+		if err != nil {
+			t.Errorf("ReadFile(%s): %v", tc.path, err)
+			continue
+		}
+		if string(data) != tc.want {
+			t.Errorf("ReadFile(%s) = %q; want %q", tc.path, data, tc.want)
+		}
+	}
+	for _, path := range []string{"/src/example.com/Lib@v1.7.0/lib.go", "/src/example.com/Lib@v1.8.0/missing.go", "/src/example.com/Lib@../x"} {
+		if _, err := fs.Stat(path); err == nil {
+			t.Errorf("Stat(%s) succeeded; want error", path)
+		}
+	}
+	if list, err := fs.ReadDir("/src/example.com/Lib@v1.8.0"); err != nil || len(list) != 2 {
+		t.Errorf("ReadDir of v1.8.0 = %v, %v; want 2 entries", list, err)
+	}
+
+	versions, err := modfs.Versions(cache, "example.com/Lib")
+	if want := []string{"v1.8.0", "v1.9.0-pre", "v1.10.0"}; err != nil || !reflect.DeepEqual(versions, want) {
+		t.Errorf("Versions = %v, %v; want %v", versions, err, want)
+	}
+	versions, err = modfs.AllVersions(cache, "example.com/Lib")
+	if want := []string{"v1.8.0", "v1.9.0-pre", "v1.10.0", "v2.3.0"}; err != nil || !reflect.DeepEqual(versions, want) {
+		t.Errorf("AllVersions = %v, %v; want %v", versions, err, want)
+	}
+	if versions, err := modfs.Versions(cache, "example.com/missing"); err != nil || versions != nil {
+		t.Errorf("Versions of a missing module = %v, %v; want none", versions, err)
+	}
+}
+
+// zipData returns the content of a module zip file with the given files.
+func zipData(t *testing.T, files map[string]string) string {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestDownloadedModules(t *testing.T) {
+	cache := modCache(t, map[string]string{
+		"example.com/!lib@v1.8.0/lib.go": "package lib // v1.8.0",
+		"cache/download/example.com/!lib/@v/v1.8.0.zip": zipData(t, map[string]string{
+			"example.com/Lib@v1.8.0/lib.go": "package lib // v1.8.0 zip",
+		}),
+		"cache/download/example.com/!lib/@v/v1.9.0.zip": zipData(t, map[string]string{
+			"example.com/Lib@v1.9.0/lib.go":     "package lib // v1.9.0",
+			"example.com/Lib@v1.9.0/sub/sub.go": "package sub",
+		}),
+		"cache/download/example.com/!lib/@v/v1.9.0.mod":   "module example.com/Lib",
+		"cache/download/example.com/!lib/@v/v1.10.0.info": "{}",
+		"cache/download/example.com/other/v2/@v/v2.0.0.zip": zipData(t, map[string]string{
+			"example.com/other/v2@v2.0.0/other.go": "package other",
+		}),
+	})
+	defer os.RemoveAll(cache)
+	fs := modfs.New(mapfs.New(nil), cache)
+
+	for _, tc := range []struct {
+		path, want string
+	}{
+		{"/src/example.com/Lib@v1.8.0/lib.go", "package lib // v1.8.0"}, // extracted first
+		{"/src/example.com/Lib@v1.9.0/lib.go", "package lib // v1.9.0"},
+		{"/src/example.com/Lib@v1.9.0/sub/sub.go", "package sub"},
+		{"/src/example.com/other@v2.0.0/other.go", "package other"},
+	} {
+		data, err := vfs.ReadFile(fs, tc.path)
+		if err != nil {
+			t.Errorf("ReadFile(%s): %v", tc.path, err)
+			continue
+		}
+		if string(data) != tc.want {
+			t.Errorf("ReadFile(%s) = %q; want %q", tc.path, data, tc.want)
+		}
+	}
+	if _, err := fs.Stat("/src/example.com/Lib@v1.10.0"); err == nil {
+		t.Error("Stat of a version without zip file succeeded; want error")
+	}
+
+	mods, err := modfs.Modules(cache)
+	want := []modfs.Module{
+		{Path: "example.com/Lib", Versions: []string{"v1.8.0", "v1.9.0"}},
+		{Path: "example.com/other/v2", Versions: []string{"v2.0.0"}},
+	}
+	if err != nil || !reflect.DeepEqual(mods, want) {
+		t.Errorf("Modules = %v, %v; want %v", mods, err, want)
+	}
+}
+
+func TestManyModules(t *testing.T) {
+	// More module zip files than are kept open.
+	files := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		mod := fmt.Sprintf("example.com/m%d@v1.0.0", i)
+		files["cache/download/"+strings.Replace(mod, "@", "/@v/", 1)+".zip"] = zipData(t, map[string]string{
+			mod + "/m.go": "package m",
+		})
+	}
+	cache := modCache(t, files)
+	defer os.RemoveAll(cache)
+	fs := modfs.New(mapfs.New(nil), cache)
+
+	for round := 0; round < 2; round++ {
+		for i := 0; i < 100; i++ {
+			name := fmt.Sprintf("/src/example.com/m%d@v1.0.0/m.go", i)
+			if data, err := vfs.ReadFile(fs, name); err != nil || string(data) != "package m" {
+				t.Fatalf("round %d: ReadFile(%s) = %q, %v; want %q", round, name, data, err, "package m")
+			}
+		}
+	}
+}
+
+func TestVersionsOrder(t *testing.T) {
+	want := []string{"bad", "v01.0.0", "v0.0.0-20200101000000-abcdef", "v0.1.0", "v1.0.0-rc.2", "v1.0.0-rc.10", "v1.0.0", "v1.0.1+incompatible", "v1.2.0", "v1.10.0"}
+	files := make(map[string]string)
+	for i := len(want) - 1; i >= 0; i-- {
+		files["example.com/m@"+want[i]+"/m.go"] = "package m"
+	}
+	cache := modCache(t, files)
+	defer os.RemoveAll(cache)
+
+	versions, err := modfs.Versions(cache, "example.com/m")
+	if err != nil || !reflect.DeepEqual(versions, want) {
+		t.Errorf("Versions = %v, %v; want %v", versions, err, want)
+	}
+}